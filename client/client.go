@@ -0,0 +1,68 @@
+package client
+
+import (
+	"github.com/xan-mortum/apimediaservice/components/urlsign"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+//собирает подписанные ссылки на ресайзы на лету и на скачивание файлов для сервисов которые отдают картинки наружу
+//ключ и соль должны быть такими же как у apimediaservice
+type ImageURLBuilder struct {
+	//адрес сервиса, например https://media.example.com
+	BaseURL string
+	Signing urlsign.Config
+}
+
+func NewImageURLBuilder(baseURL string, key string, salt string) *ImageURLBuilder {
+	return &ImageURLBuilder{
+		BaseURL: baseURL,
+		Signing: urlsign.NewConfig(key, salt),
+	}
+}
+
+//вечная ссылка на картинку image с параметрами options, например w:200,h:200,m:fill
+func (b *ImageURLBuilder) URL(options string, image string) string {
+	return b.build(options, image, 0)
+}
+
+//ссылка которая перестает работать после expires
+func (b *ImageURLBuilder) URLWithExpiry(options string, image string, expires time.Time) string {
+	return b.build(options, image, expires.Unix())
+}
+
+func (b *ImageURLBuilder) build(options string, image string, expires int64) string {
+	result := b.BaseURL + "/img/" + url.PathEscape(options) + "/" + url.PathEscape(image)
+	if !b.Signing.Enabled() {
+		return result
+	}
+	query := url.Values{}
+	query.Set("signature", urlsign.Sign(b.Signing, options, image, expires))
+	if expires != 0 {
+		query.Set("expires", strconv.FormatInt(expires, 10))
+	}
+	return result + "?" + query.Encode()
+}
+
+//вечная ссылка на скачивание оригинала или готового ресайза key без токена владельца
+func (b *ImageURLBuilder) DownloadURL(key string) string {
+	return b.buildDownload(key, 0)
+}
+
+func (b *ImageURLBuilder) DownloadURLWithExpiry(key string, expires time.Time) string {
+	return b.buildDownload(key, expires.Unix())
+}
+
+func (b *ImageURLBuilder) buildDownload(key string, expires int64) string {
+	result := b.BaseURL + "/v2/download/" + url.PathEscape(key)
+	if !b.Signing.Enabled() {
+		return result
+	}
+	query := url.Values{}
+	query.Set("signature", urlsign.SignDownload(b.Signing, key, expires))
+	if expires != 0 {
+		query.Set("expires", strconv.FormatInt(expires, 10))
+	}
+	return result + "?" + query.Encode()
+}
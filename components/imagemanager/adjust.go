@@ -0,0 +1,261 @@
+package imagemanager
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+	"strconv"
+)
+
+//пределы параметров цвета и резкости
+const MaxAdjustment = 100
+const MinGamma = 0.1
+const MaxGamma = 10
+const MaxSigma = 50
+const MaxSharpenAmount = 10
+
+//нерезкое маскирование: к картинке добавляеться ее разница с размытой копией
+//Sigma - радиус размытия, Amount - сила (1 значит разница добавляеться один раз)
+//Threshold - разница меньше этого значения (0-255) не усиливаеться, так не вылезает шум на ровных местах
+type UnsharpMask struct {
+	Sigma     float64 `json:"sigma"`
+	Amount    float64 `json:"amount"`
+	Threshold uint8   `json:"threshold,omitempty"`
+}
+
+//есть ли операции после ресайза
+func (o ResizeOptions) HasAdjust() bool {
+	return o.Grayscale || o.Sepia || o.Brightness != 0 || o.Contrast != 0 || (o.Gamma != 0 && o.Gamma != 1) ||
+		o.Saturation != 0 || o.Blur != 0 || o.Sharpen != nil
+}
+
+func (o ResizeOptions) validateAdjust() error {
+	for name, value := range map[string]float64{"brightness": o.Brightness, "contrast": o.Contrast, "saturation": o.Saturation} {
+		if math.IsNaN(value) || value < -MaxAdjustment || value > MaxAdjustment {
+			return fmt.Errorf("%w: %s must be from -%d to %d", ErrInvalidOptions, name, MaxAdjustment, MaxAdjustment)
+		}
+	}
+	if o.Gamma != 0 && !(o.Gamma >= MinGamma && o.Gamma <= MaxGamma) {
+		return fmt.Errorf("%w: gamma must be from %v to %v", ErrInvalidOptions, MinGamma, MaxGamma)
+	}
+	if !(o.Blur >= 0 && o.Blur <= MaxSigma) {
+		return fmt.Errorf("%w: blur must be from 0 to %d", ErrInvalidOptions, MaxSigma)
+	}
+	if o.Sharpen != nil {
+		if !(o.Sharpen.Sigma > 0 && o.Sharpen.Sigma <= MaxSigma) {
+			return fmt.Errorf("%w: sharpen sigma must be from 0 to %d", ErrInvalidOptions, MaxSigma)
+		}
+		if !(o.Sharpen.Amount > 0 && o.Sharpen.Amount <= MaxSharpenAmount) {
+			return fmt.Errorf("%w: sharpen amount must be from 0 to %d", ErrInvalidOptions, MaxSharpenAmount)
+		}
+	}
+	return nil
+}
+
+//часть имени файла для цветокоррекции, размытия и резкости
+func (o ResizeOptions) adjustName() string {
+	number := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	name := ""
+	if o.Brightness != 0 {
+		name += "_br" + number(o.Brightness)
+	}
+	if o.Contrast != 0 {
+		name += "_ct" + number(o.Contrast)
+	}
+	if o.Gamma != 0 && o.Gamma != 1 {
+		name += "_gm" + number(o.Gamma)
+	}
+	if o.Saturation != 0 {
+		name += "_sat" + number(o.Saturation)
+	}
+	if o.Grayscale {
+		name += "_gray"
+	}
+	if o.Sepia {
+		name += "_sepia"
+	}
+	if o.Blur != 0 {
+		name += "_blur" + number(o.Blur)
+	}
+	if o.Sharpen != nil {
+		name += "_sharp" + number(o.Sharpen.Sigma) + "x" + number(o.Sharpen.Amount)
+		if o.Sharpen.Threshold != 0 {
+			name += "x" + strconv.Itoa(int(o.Sharpen.Threshold))
+		}
+	}
+	return name
+}
+
+//цветокоррекция, размытие и резкость. делаеться после ресайза, так резкость считаеться уже для итогового размера
+//порядок: яркость, контраст, гамма, насыщенность, серый, сепия, размытие, резкость
+func (o ResizeOptions) adjust(src image.Image) image.Image {
+	if !o.HasAdjust() {
+		return src
+	}
+	if o.Brightness != 0 || o.Contrast != 0 || (o.Gamma != 0 && o.Gamma != 1) || o.Saturation != 0 || o.Grayscale || o.Sepia {
+		src = o.adjustColors(src)
+	}
+	if o.Blur != 0 {
+		src = gaussianBlur(toRGBA(src), o.Blur)
+	}
+	if o.Sharpen != nil {
+		src = unsharpMask(toRGBA(src), *o.Sharpen)
+	}
+	return src
+}
+
+//поканальные изменения. прозрачность не меняеться, поэтому считаем без премультипликации
+func (o ResizeOptions) adjustColors(src image.Image) image.Image {
+	//яркость, контраст и гамма зависят только от значения канала, поэтому считаються один раз таблицей
+	var table [256]uint8
+	contrast := (100 + o.Contrast) / 100
+	gamma := 1.0
+	if o.Gamma != 0 {
+		gamma = 1 / o.Gamma
+	}
+	for i := range table {
+		value := float64(i) + 255*o.Brightness/100
+		value = (value-127.5)*contrast + 127.5
+		value = 255 * math.Pow(clamp(value, 0, 255)/255, gamma)
+		table[i] = uint8(math.Round(clamp(value, 0, 255)))
+	}
+	saturation := (100 + o.Saturation) / 100
+
+	dst := toNRGBA(src)
+	for i := 0; i < len(dst.Pix); i += 4 {
+		pixel := dst.Pix[i : i+3 : i+3]
+		r, g, b := float64(table[pixel[0]]), float64(table[pixel[1]]), float64(table[pixel[2]])
+		if saturation != 1 {
+			luma := 0.299*r + 0.587*g + 0.114*b
+			r, g, b = luma+(r-luma)*saturation, luma+(g-luma)*saturation, luma+(b-luma)*saturation
+		}
+		if o.Grayscale {
+			luma := 0.299*r + 0.587*g + 0.114*b
+			r, g, b = luma, luma, luma
+		}
+		if o.Sepia {
+			r, g, b = 0.393*r+0.769*g+0.189*b, 0.349*r+0.686*g+0.168*b, 0.272*r+0.534*g+0.131*b
+		}
+		pixel[0] = uint8(math.Round(clamp(r, 0, 255)))
+		pixel[1] = uint8(math.Round(clamp(g, 0, 255)))
+		pixel[2] = uint8(math.Round(clamp(b, 0, 255)))
+	}
+	return dst
+}
+
+//размытие по гауссу. ядро разделимое, поэтому сначала по строкам, потом по столбцам
+//считаеться на премультиплицированных каналах, иначе цвет прозрачных точек проступает по краям
+func gaussianBlur(src *image.RGBA, sigma float64) *image.RGBA {
+	kernel := gaussianKernel(sigma)
+	radius := len(kernel) / 2
+	width, height := src.Rect.Dx(), src.Rect.Dy()
+	tmp := make([]float64, len(src.Pix))
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	//края продлеваються крайними точками
+	index := func(x, y int, stride int) int {
+		if x < 0 {
+			x = 0
+		} else if x >= width {
+			x = width - 1
+		}
+		if y < 0 {
+			y = 0
+		} else if y >= height {
+			y = height - 1
+		}
+		return y*stride + x*4
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var sum [4]float64
+			for k, weight := range kernel {
+				i := index(x+k-radius, y, src.Stride)
+				for c := 0; c < 4; c++ {
+					sum[c] += float64(src.Pix[i+c]) * weight
+				}
+			}
+			copy(tmp[y*width*4+x*4:], sum[:])
+		}
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var sum [4]float64
+			for k, weight := range kernel {
+				i := index(x, y+k-radius, width*4)
+				for c := 0; c < 4; c++ {
+					sum[c] += tmp[i+c] * weight
+				}
+			}
+			i := y*dst.Stride + x*4
+			for c := 0; c < 4; c++ {
+				dst.Pix[i+c] = uint8(math.Round(clamp(sum[c], 0, 255)))
+			}
+		}
+	}
+	return dst
+}
+
+//ядро радиусом 3 sigma, дальше веса уже ничего не меняют
+func gaussianKernel(sigma float64) []float64 {
+	radius := int(math.Ceil(sigma * 3))
+	kernel := make([]float64, radius*2+1)
+	sum := 0.0
+	for i := range kernel {
+		x := float64(i - radius)
+		kernel[i] = math.Exp(-x * x / (2 * sigma * sigma))
+		sum += kernel[i]
+	}
+	for i := range kernel {
+		kernel[i] /= sum
+	}
+	return kernel
+}
+
+func unsharpMask(src *image.RGBA, mask UnsharpMask) *image.RGBA {
+	blurred := gaussianBlur(src, mask.Sigma)
+	dst := image.NewRGBA(blurred.Rect)
+	width, height := src.Rect.Dx(), src.Rect.Dy()
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			i := y*src.Stride + x*4
+			j := y*dst.Stride + x*4
+			alpha := src.Pix[i+3]
+			for c := 0; c < 3; c++ {
+				value := float64(src.Pix[i+c])
+				diff := value - float64(blurred.Pix[j+c])
+				if math.Abs(diff) > float64(mask.Threshold) {
+					value += diff * mask.Amount
+				}
+				//каналы премультиплицированы, поэтому не могут быть больше прозрачности
+				dst.Pix[j+c] = uint8(math.Round(clamp(value, 0, float64(alpha))))
+			}
+			dst.Pix[j+3] = alpha
+		}
+	}
+	return dst
+}
+
+func toNRGBA(src image.Image) *image.NRGBA {
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	return dst
+}
+
+func toRGBA(src image.Image) *image.RGBA {
+	if rgba, ok := src.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	return dst
+}
+
+func clamp(value float64, min float64, max float64) float64 {
+	return math.Max(min, math.Min(max, value))
+}
@@ -0,0 +1,161 @@
+package imagemanager
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestValidateAdjust(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ResizeOptions
+		valid   bool
+	}{
+		//без ресайза можно если есть что поменять
+		{"adjust only", ResizeOptions{Brightness: 10, Contrast: -10, Saturation: 100}, true},
+		{"gamma", ResizeOptions{Width: 10, Gamma: MinGamma}, true},
+		{"blur", ResizeOptions{Blur: MaxSigma}, true},
+		{"sharpen", ResizeOptions{Sharpen: &UnsharpMask{Sigma: 1, Amount: MaxSharpenAmount}}, true},
+		{"brightness too big", ResizeOptions{Width: 10, Brightness: 101}, false},
+		{"contrast too small", ResizeOptions{Width: 10, Contrast: -101}, false},
+		{"saturation is nan", ResizeOptions{Width: 10, Saturation: math.NaN()}, false},
+		{"gamma too small", ResizeOptions{Width: 10, Gamma: 0.01}, false},
+		{"negative blur", ResizeOptions{Width: 10, Blur: -1}, false},
+		{"sharpen without sigma", ResizeOptions{Width: 10, Sharpen: &UnsharpMask{Amount: 1}}, false},
+		{"sharpen amount too big", ResizeOptions{Width: 10, Sharpen: &UnsharpMask{Sigma: 1, Amount: 11}}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.options.Validate()
+			if (err == nil) != test.valid {
+				t.Fatalf("Validate() = %v, valid %v", err, test.valid)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidOptions) {
+				t.Fatalf("error %v is not %v", err, ErrInvalidOptions)
+			}
+		})
+	}
+}
+
+func TestAdjustName(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  ResizeOptions
+		fileName string
+	}{
+		{"colors", ResizeOptions{Width: 10, Brightness: 10, Contrast: -5.5, Saturation: 20}, "thumb10_br10_ct-5.5_sat20.a.png"},
+		//гамма 1 ничего не меняет и в имя не попадает
+		{"neutral gamma", ResizeOptions{Width: 10, Gamma: 1}, "thumb10.a.png"},
+		{"gamma", ResizeOptions{Width: 10, Gamma: 2.2}, "thumb10_gm2.2.a.png"},
+		{"grayscale and sepia", ResizeOptions{Width: 10, Grayscale: true, Sepia: true}, "thumb10_gray_sepia.a.png"},
+		{"blur", ResizeOptions{Width: 10, Blur: 1.5}, "thumb10_blur1.5.a.png"},
+		{"sharpen", ResizeOptions{Width: 10, Sharpen: &UnsharpMask{Sigma: 1, Amount: 2}}, "thumb10_sharp1x2.a.png"},
+		{"sharpen with threshold", ResizeOptions{Width: 10, Sharpen: &UnsharpMask{Sigma: 1, Amount: 2, Threshold: 10}}, "thumb10_sharp1x2x10.a.png"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fileName := test.options.FileName("a.png")
+			if fileName != test.fileName {
+				t.Fatalf("FileName() = %s, want %s", fileName, test.fileName)
+			}
+		})
+	}
+}
+
+func TestAdjustColors(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ResizeOptions
+		source  color.NRGBA
+		result  color.NRGBA
+	}{
+		{"brighter", ResizeOptions{Brightness: 100}, color.NRGBA{R: 10, G: 100, B: 200, A: 255}, color.NRGBA{R: 255, G: 255, B: 255, A: 255}},
+		{"darker", ResizeOptions{Brightness: -100}, color.NRGBA{R: 10, G: 100, B: 200, A: 255}, color.NRGBA{A: 255}},
+		//без контраста все цвета становяться средне серыми
+		{"no contrast", ResizeOptions{Contrast: -100}, color.NRGBA{R: 10, G: 100, B: 200, A: 255}, color.NRGBA{R: 128, G: 128, B: 128, A: 255}},
+		{"gamma", ResizeOptions{Gamma: 2}, color.NRGBA{R: 64, G: 0, B: 255, A: 255}, color.NRGBA{R: 128, G: 0, B: 255, A: 255}},
+		{"no saturation", ResizeOptions{Saturation: -100}, color.NRGBA{R: 255, A: 255}, color.NRGBA{R: 76, G: 76, B: 76, A: 255}},
+		{"grayscale", ResizeOptions{Grayscale: true}, color.NRGBA{R: 255, A: 255}, color.NRGBA{R: 76, G: 76, B: 76, A: 255}},
+		{"sepia", ResizeOptions{Sepia: true}, color.NRGBA{R: 255, G: 255, B: 255, A: 255}, color.NRGBA{R: 255, G: 255, B: 239, A: 255}},
+		//прозрачность не меняеться
+		{"keeps alpha", ResizeOptions{Brightness: 100}, color.NRGBA{R: 10, A: 128}, color.NRGBA{R: 255, G: 255, B: 255, A: 128}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+			src.SetNRGBA(0, 0, test.source)
+			result := color.NRGBAModel.Convert(test.options.adjust(src).At(0, 0)).(color.NRGBA)
+			if result != test.result {
+				t.Fatalf("adjust(%v) = %v, want %v", test.source, result, test.result)
+			}
+		})
+	}
+}
+
+//половина картинки с яркостью left, половина right
+func newTestStep(left uint8, right uint8) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 20, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 20; x++ {
+			value := left
+			if x >= 10 {
+				value = right
+			}
+			img.SetRGBA(x, y, color.RGBA{R: value, G: value, B: value, A: 255})
+		}
+	}
+	return img
+}
+
+func TestGaussianKernel(t *testing.T) {
+	for _, sigma := range []float64{0.5, 1, 2.5} {
+		kernel := gaussianKernel(sigma)
+		sum := 0.0
+		for _, weight := range kernel {
+			sum += weight
+		}
+		if len(kernel) != int(math.Ceil(sigma*3))*2+1 || math.Abs(sum-1) > 1e-9 {
+			t.Fatalf("sigma %v: %d weights with sum %v", sigma, len(kernel), sum)
+		}
+		//ядро симметричное и самый большой вес в середине
+		radius := len(kernel) / 2
+		if kernel[0] != kernel[len(kernel)-1] || kernel[radius] < kernel[radius-1] {
+			t.Fatalf("sigma %v: kernel %v", sigma, kernel)
+		}
+	}
+}
+
+func TestBlurAndSharpen(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ResizeOptions
+		//яркость точек слева и справа от границы
+		left  func(uint8) bool
+		right func(uint8) bool
+	}{
+		//размытие смешивает края
+		{"blur", ResizeOptions{Blur: 2}, func(v uint8) bool { return v > 100 && v < 150 }, func(v uint8) bool { return v > 150 && v < 200 }},
+		//резкость делает темную сторону темнее, а светлую светлее
+		{"sharpen", ResizeOptions{Sharpen: &UnsharpMask{Sigma: 1, Amount: 1}}, func(v uint8) bool { return v < 100 }, func(v uint8) bool { return v > 200 }},
+		//разница меньше порога не усиливаеться
+		{"sharpen under threshold", ResizeOptions{Sharpen: &UnsharpMask{Sigma: 1, Amount: 1, Threshold: 255}}, func(v uint8) bool { return v == 100 }, func(v uint8) bool { return v == 200 }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := test.options.adjust(newTestStep(100, 200))
+			left := color.RGBAModel.Convert(result.At(9, 2)).(color.RGBA)
+			right := color.RGBAModel.Convert(result.At(10, 2)).(color.RGBA)
+			if !test.left(left.R) || !test.right(right.R) {
+				t.Fatalf("edge %d|%d", left.R, right.R)
+			}
+			//далеко от границы ничего не меняеться
+			far := color.RGBAModel.Convert(result.At(0, 0)).(color.RGBA)
+			if far.R != 100 || far.A != 255 {
+				t.Fatalf("far point %v", far)
+			}
+		})
+	}
+}
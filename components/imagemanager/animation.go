@@ -0,0 +1,215 @@
+package imagemanager
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+)
+
+//типы блоков gif
+const gifExtension = 0x21
+const gifImageDescriptor = 0x2c
+const gifTrailer = 0x3b
+
+//анимированный gif. кадры уже собраны с учетом смещений и disposal, каждый кадр размером с весь gif
+//как image.Image ведет себя как первый кадр, так анимацию можно передавать туда же куда и обычную картинку
+type Animation struct {
+	Frames []image.Image
+	//задержки кадров в сотых долях секунды
+	Delays []int
+	//сколько раз повторять, 0 - бесконечно, -1 - показать один раз
+	LoopCount int
+}
+
+func (a *Animation) ColorModel() color.Model {
+	return a.Frames[0].ColorModel()
+}
+
+func (a *Animation) Bounds() image.Rectangle {
+	return a.Frames[0].Bounds()
+}
+
+func (a *Animation) At(x, y int) color.Color {
+	return a.Frames[0].At(x, y)
+}
+
+//собирает полные кадры из gif. в gif кадр может быть меньше картинки и рисуеться поверх предыдущего,
+//а после показа его область очищаеться или возвращаеться как было, в зависимости от disposal
+func newAnimation(decoded *gif.GIF) (*Animation, error) {
+	bounds := image.Rect(0, 0, decoded.Config.Width, decoded.Config.Height)
+	if bounds.Empty() {
+		bounds = decoded.Image[0].Bounds()
+	}
+	err := checkAnimation(len(decoded.Image), bounds.Dx(), bounds.Dy())
+	if err != nil {
+		return nil, err
+	}
+	canvas := image.NewNRGBA(bounds)
+	animation := &Animation{
+		Frames:    make([]image.Image, 0, len(decoded.Image)),
+		Delays:    decoded.Delay,
+		LoopCount: decoded.LoopCount,
+	}
+	for i, frame := range decoded.Image {
+		disposal := byte(0)
+		if i < len(decoded.Disposal) {
+			disposal = decoded.Disposal[i]
+		}
+		var previous *image.NRGBA
+		if disposal == gif.DisposalPrevious {
+			previous = cloneNRGBA(canvas)
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+		animation.Frames = append(animation.Frames, cloneNRGBA(canvas))
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+	return animation, nil
+}
+
+//считает кадры gif по блокам файла, не распаковывая пиксели. gif.DecodeAll для этого пришлось бы
+//держать в памяти все кадры сразу
+func countGIFFrames(r io.Reader) (int, error) {
+	reader := bufio.NewReader(r)
+	//заголовок и описание экрана, после них может идти глобальная палитра
+	header := make([]byte, 13)
+	_, err := io.ReadFull(reader, header)
+	if err != nil {
+		return 0, err
+	}
+	if string(header[:3]) != "GIF" {
+		return 0, errors.New("gif: can't recognize format")
+	}
+	if header[10]&0x80 != 0 {
+		_, err = reader.Discard(3 << (header[10]&0x07 + 1))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	frames := 0
+	for {
+		block, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch block {
+		case gifExtension:
+			//метка расширения, потом данные подблоками
+			_, err = reader.ReadByte()
+			if err == nil {
+				err = skipGIFSubBlocks(reader)
+			}
+		case gifImageDescriptor:
+			frames++
+			descriptor := make([]byte, 9)
+			_, err = io.ReadFull(reader, descriptor)
+			if err == nil && descriptor[8]&0x80 != 0 {
+				_, err = reader.Discard(3 << (descriptor[8]&0x07 + 1))
+			}
+			//минимальный размер кода lzw, потом сжатые пиксели подблоками
+			if err == nil {
+				_, err = reader.ReadByte()
+			}
+			if err == nil {
+				err = skipGIFSubBlocks(reader)
+			}
+		case gifTrailer:
+			return frames, nil
+		default:
+			return 0, fmt.Errorf("gif: unknown block type: 0x%.2x", block)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+//данные в gif идут подблоками по 255 байт с длинной в начале, последний подблок пустой
+func skipGIFSubBlocks(reader *bufio.Reader) error {
+	for {
+		size, err := reader.ReadByte()
+		if err != nil {
+			return err
+		}
+		if size == 0 {
+			return nil
+		}
+		_, err = reader.Discard(int(size))
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func cloneNRGBA(src *image.NRGBA) *image.NRGBA {
+	dst := image.NewNRGBA(src.Rect)
+	copy(dst.Pix, src.Pix)
+	return dst
+}
+
+//обрабатывает каждый кадр через process и собирает gif обратно с теми же задержками и количеством повторов
+//кадры пишуться целиком, поэтому каждый очищаеться перед следующим и исходные смещения больше не нужны
+func encodeAnimation(w io.Writer, animation *Animation, process func(image.Image) (image.Image, error), options ResizeOptions) error {
+	result := &gif.GIF{
+		Image:     make([]*image.Paletted, 0, len(animation.Frames)),
+		Delay:     make([]int, 0, len(animation.Frames)),
+		Disposal:  make([]byte, 0, len(animation.Frames)),
+		LoopCount: animation.LoopCount,
+	}
+	//сначала обрабатываем все кадры, палитра одна на всю анимацию и строиться из цветов всех кадров
+	frames := make([]image.Image, 0, len(animation.Frames))
+	for i, frame := range animation.Frames {
+		resized, err := process(frame)
+		if err != nil {
+			return err
+		}
+		//после ресайза кадры могут стать больше исходных, размер у всех одинаковый, так что хватает первого
+		if i == 0 {
+			err = checkAnimation(len(animation.Frames), resized.Bounds().Dx(), resized.Bounds().Dy())
+			if err != nil {
+				return err
+			}
+		}
+		frames = append(frames, resized)
+	}
+	framePalette := animationPalette(frames, options)
+	var drawer draw.Drawer = draw.FloydSteinberg
+	if options.NoDither {
+		drawer = draw.Src
+	}
+	for i, resized := range frames {
+		bounds := resized.Bounds()
+		paletted := image.NewPaletted(image.Rect(0, 0, bounds.Dx(), bounds.Dy()), framePalette)
+		drawer.Draw(paletted, paletted.Bounds(), resized, bounds.Min)
+		result.Image = append(result.Image, paletted)
+		delay := 0
+		if i < len(animation.Delays) {
+			delay = animation.Delays[i]
+		}
+		result.Delay = append(result.Delay, delay)
+		result.Disposal = append(result.Disposal, gif.DisposalBackground)
+	}
+	return gif.EncodeAll(w, result)
+}
+
+//палитра из цветов всех кадров как у статичных gif, плюс прозрачный цвет для пустых мест кадров
+func animationPalette(frames []image.Image, options ResizeOptions) color.Palette {
+	colors := 255
+	if options.Colors > 1 && options.Colors < 256 {
+		colors = int(options.Colors) - 1
+	}
+	histogram, _ := newColorHistogram(frames)
+	return append(histogram.palette(colors), color.Transparent)
+}
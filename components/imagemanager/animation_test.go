@@ -0,0 +1,183 @@
+package imagemanager
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"os"
+	"reflect"
+	"testing"
+)
+
+var testGreen = color.RGBA{G: 255, A: 255}
+
+func testFrame(rect image.Rectangle, c color.Color) *image.Paletted {
+	frame := image.NewPaletted(rect, color.Palette{color.Transparent, testRed, testGreen, testBlue})
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			frame.Set(x, y, c)
+		}
+	}
+	return frame
+}
+
+func TestNewAnimation(t *testing.T) {
+	tests := []struct {
+		name     string
+		disposal byte
+		//цвет левого верхнего угла третьего кадра
+		corner color.Color
+	}{
+		//второй кадр остаеться на холсте
+		{"none", gif.DisposalNone, testBlue},
+		//область второго кадра очищаеться
+		{"background", gif.DisposalBackground, color.Transparent},
+		//холст возвращаеться к первому кадру
+		{"previous", gif.DisposalPrevious, testRed},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//красный фон 4x4, синий кадр 2x2 в левом верхнем углу, зеленый 2x2 в правом нижнем
+			decoded := &gif.GIF{
+				Image:     []*image.Paletted{testFrame(image.Rect(0, 0, 4, 4), testRed), testFrame(image.Rect(0, 0, 2, 2), testBlue), testFrame(image.Rect(2, 2, 4, 4), testGreen)},
+				Delay:     []int{10, 20, 30},
+				Disposal:  []byte{gif.DisposalNone, test.disposal, gif.DisposalNone},
+				LoopCount: 3,
+				Config:    image.Config{Width: 4, Height: 4},
+			}
+			animation, err := newAnimation(decoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(animation.Frames) != 3 || !reflect.DeepEqual(animation.Delays, decoded.Delay) || animation.LoopCount != 3 {
+				t.Fatalf("%d frames, delays %v, loop count %d", len(animation.Frames), animation.Delays, animation.LoopCount)
+			}
+			for i, frame := range animation.Frames {
+				if frame.Bounds() != image.Rect(0, 0, 4, 4) {
+					t.Fatalf("frame %d bounds %v", i, frame.Bounds())
+				}
+			}
+			//второй кадр нарисован поверх первого
+			if !sameColor(animation.Frames[1].At(0, 0), testBlue) || !sameColor(animation.Frames[1].At(3, 3), testRed) {
+				t.Fatalf("second frame %v %v", animation.Frames[1].At(0, 0), animation.Frames[1].At(3, 3))
+			}
+			last := animation.Frames[2]
+			if !sameColor(last.At(0, 0), test.corner) || !sameColor(last.At(3, 3), testGreen) {
+				t.Fatalf("third frame %v %v, want %v", last.At(0, 0), last.At(3, 3), test.corner)
+			}
+			//как картинка анимация выглядит первым кадром
+			if !sameColor(animation.At(0, 0), testRed) {
+				t.Fatalf("animation color %v", animation.At(0, 0))
+			}
+		})
+	}
+}
+
+//кадры храняться размером с весь gif, так что маленькие кадры на большом холсте тоже считаються
+func TestNewAnimationLimit(t *testing.T) {
+	decoded := &gif.GIF{
+		Image:  []*image.Paletted{testFrame(image.Rect(0, 0, 1, 1), testRed), testFrame(image.Rect(0, 0, 1, 1), testRed), testFrame(image.Rect(0, 0, 1, 1), testRed)},
+		Delay:  []int{0, 0, 0},
+		Config: image.Config{Width: 7000, Height: 7000},
+	}
+	_, err := newAnimation(decoded)
+	if !errors.Is(err, ErrImageTooLarge) {
+		t.Fatalf("newAnimation() = %v, want %v", err, ErrImageTooLarge)
+	}
+}
+
+func TestCountGIFFrames(t *testing.T) {
+	threeFrames := testGIF(t, 3, image.Point{})
+	tests := []struct {
+		name   string
+		data   []byte
+		frames int
+		valid  bool
+	}{
+		{"one frame", testGIF(t, 1, image.Point{}), 1, true},
+		{"three frames", threeFrames, 3, true},
+		{"truncated", threeFrames[:len(threeFrames)/2], 0, false},
+		{"not a gif", testPNG(t, newTestImage()), 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			frames, err := countGIFFrames(bytes.NewReader(test.data))
+			if (err == nil) != test.valid || frames != test.frames {
+				t.Fatalf("countGIFFrames() = %d %v, want %d", frames, err, test.frames)
+			}
+		})
+	}
+}
+
+func TestResizeAnimation(t *testing.T) {
+	var source bytes.Buffer
+	err := gif.EncodeAll(&source, &gif.GIF{
+		Image:     []*image.Paletted{testFrame(image.Rect(0, 0, 40, 20), testRed), testFrame(image.Rect(0, 0, 40, 20), testGreen), testFrame(image.Rect(0, 0, 40, 20), testBlue)},
+		Delay:     []int{10, 20, 30},
+		LoopCount: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		options ResizeOptions
+		format  string
+		frames  int
+	}{
+		{"animation", ResizeOptions{Width: 20}, FormatGIF, 3},
+		{"first frame", ResizeOptions{Width: 20, FirstFrame: true}, FormatGIF, 1},
+		//в других форматах анимации нет, берется первый кадр
+		{"to png", ResizeOptions{Width: 20, Format: FormatPNG}, FormatPNG, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			im := newTestManager(t)
+			defer im.Clear()
+			file, err := im.SaveFile("a.gif", source.Bytes())
+			if err != nil {
+				t.Fatal(err)
+			}
+			thumbFile, err := im.ResizeFile(file, test.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			thumb, err := os.Open(thumbFile.Path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer thumb.Close()
+
+			if test.format == FormatPNG {
+				img, err := png.Decode(thumb)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if img.Bounds() != image.Rect(0, 0, 20, 10) || !sameColor(img.At(0, 0), testRed) {
+					t.Fatalf("png %v %v", img.Bounds(), img.At(0, 0))
+				}
+				return
+			}
+			decoded, err := gif.DecodeAll(thumb)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(decoded.Image) != test.frames {
+				t.Fatalf("%d frames, want %d", len(decoded.Image), test.frames)
+			}
+			colors := []color.Color{testRed, testGreen, testBlue}
+			for i, frame := range decoded.Image {
+				if frame.Bounds() != image.Rect(0, 0, 20, 10) || !sameColor(frame.At(5, 5), colors[i]) {
+					t.Fatalf("frame %d %v %v", i, frame.Bounds(), frame.At(5, 5))
+				}
+			}
+			//задержки и количество повторов остаються как в исходнике
+			if test.frames == 3 && (!reflect.DeepEqual(decoded.Delay, []int{10, 20, 30}) || decoded.LoopCount != 2) {
+				t.Fatalf("delays %v, loop count %d", decoded.Delay, decoded.LoopCount)
+			}
+		})
+	}
+}
@@ -2,10 +2,19 @@ package imagemanager
 
 type Config struct {
 	TmpDir string
+	//фильтр для ресайзов в которых он не указан
+	DefaultFilter string
+	//что делать с метаданными: MetadataStrip, MetadataCopyright или MetadataKeep
+	MetadataPolicy string
+	//чистить ли метаданные оригиналов при загрузке по той же политике
+	SanitizeOriginals bool
 }
 
-func NewConfig(tmpDir string) Config {
+func NewConfig(tmpDir string, defaultFilter string, metadataPolicy string, sanitizeOriginals bool) Config {
 	return Config{
-		TmpDir: tmpDir,
+		TmpDir:            tmpDir,
+		DefaultFilter:     defaultFilter,
+		MetadataPolicy:    metadataPolicy,
+		SanitizeOriginals: sanitizeOriginals,
 	}
 }
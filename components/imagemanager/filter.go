@@ -0,0 +1,38 @@
+package imagemanager
+
+import (
+	"github.com/nfnt/resize"
+)
+
+//фильтры которыми можно ресайзить. nearest и bilinear быстрее, lanczos дает лучшую картинку
+const FilterNearest = "nearest"
+const FilterBilinear = "bilinear"
+const FilterBicubic = "bicubic"
+const FilterMitchell = "mitchell"
+const FilterLanczos2 = "lanczos2"
+const FilterLanczos3 = "lanczos3"
+
+//раньше всегда ресайзили этим фильтром, поэтому в имени файла он не пишеться
+const DefaultFilter = FilterLanczos3
+
+var filters = map[string]resize.InterpolationFunction{
+	FilterNearest:  resize.NearestNeighbor,
+	FilterBilinear: resize.Bilinear,
+	FilterBicubic:  resize.Bicubic,
+	FilterMitchell: resize.MitchellNetravali,
+	FilterLanczos2: resize.Lanczos2,
+	FilterLanczos3: resize.Lanczos3,
+}
+
+func IsFilterSupported(filter string) bool {
+	_, ok := filters[filter]
+	return ok
+}
+
+func (o ResizeOptions) interpolation() resize.InterpolationFunction {
+	interpolation, ok := filters[o.Filter]
+	if !ok {
+		return filters[DefaultFilter]
+	}
+	return interpolation
+}
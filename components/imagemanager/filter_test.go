@@ -0,0 +1,76 @@
+package imagemanager
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestFilters(t *testing.T) {
+	tests := []struct {
+		filter    string
+		supported bool
+		fileName  string
+	}{
+		{FilterNearest, true, "thumb10_nearest.a.png"},
+		{FilterBilinear, true, "thumb10_bilinear.a.png"},
+		{FilterMitchell, true, "thumb10_mitchell.a.png"},
+		//фильтр по умолчанию в имени не пишеться, так старые ресайзы находяться по прежним именам
+		{DefaultFilter, true, "thumb10.a.png"},
+		{"", true, "thumb10.a.png"},
+		{"gaussian", false, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			options := ResizeOptions{Width: 10, Filter: test.filter}
+			err := options.Validate()
+			if (err == nil) != test.supported {
+				t.Fatalf("Validate() = %v, supported %v", err, test.supported)
+			}
+			if test.supported && options.FileName("a.png") != test.fileName {
+				t.Fatalf("FileName() = %s, want %s", options.FileName("a.png"), test.fileName)
+			}
+		})
+	}
+}
+
+func TestWithDefaultFilter(t *testing.T) {
+	im := NewImageManager(NewConfig("", FilterBilinear, MetadataStrip, false))
+	if im.WithDefaults(ResizeOptions{}).Filter != FilterBilinear {
+		t.Fatal("default filter is not set")
+	}
+	if im.WithDefaults(ResizeOptions{Filter: FilterNearest}).Filter != FilterNearest {
+		t.Fatal("filter of the request is replaced")
+	}
+}
+
+//шахматка 2x2 увеличенная в четыре раза: nearest оставляет только исходные цвета, bilinear дает переходы между ними
+func TestFilterResult(t *testing.T) {
+	checkerboard := image.NewGray(image.Rect(0, 0, 2, 2))
+	checkerboard.SetGray(0, 0, color.Gray{Y: 255})
+	checkerboard.SetGray(1, 1, color.Gray{Y: 255})
+	tests := []struct {
+		filter string
+		mixed  bool
+	}{
+		{FilterNearest, false},
+		{FilterBilinear, true},
+	}
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			result := ResizeOptions{Width: 8, Filter: test.filter}.apply(checkerboard)
+			mixed := false
+			for y := 0; y < 8; y++ {
+				for x := 0; x < 8; x++ {
+					gray := color.GrayModel.Convert(result.At(x, y)).(color.Gray).Y
+					if gray != 0 && gray != 255 {
+						mixed = true
+					}
+				}
+			}
+			if mixed != test.mixed {
+				t.Fatalf("mixed colors %v, want %v", mixed, test.mixed)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package imagemanager
+
+import (
+	"fmt"
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/tiff"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+)
+
+//форматы в которые можно сохранить ресайз
+const FormatJPEG = "jpeg"
+const FormatPNG = "png"
+const FormatGIF = "gif"
+const FormatBMP = "bmp"
+const FormatTIFF = "tiff"
+
+//уровни сжатия png
+const CompressionDefault = "default"
+const CompressionNone = "none"
+const CompressionSpeed = "speed"
+const CompressionBest = "best"
+
+var compressionLevels = map[string]png.CompressionLevel{
+	CompressionDefault: png.DefaultCompression,
+	CompressionNone:    png.NoCompression,
+	CompressionSpeed:   png.BestSpeed,
+	CompressionBest:    png.BestCompression,
+}
+
+//расширение которое получает файл в этом формате
+var formatExtension = map[string]string{
+	FormatJPEG: ".jpg",
+	FormatPNG:  ".png",
+	FormatGIF:  ".gif",
+	FormatBMP:  ".bmp",
+	FormatTIFF: ".tiff",
+}
+
+var extensionFormat = map[string]string{
+	".jpg":  FormatJPEG,
+	".jpeg": FormatJPEG,
+	".png":  FormatPNG,
+	".gif":  FormatGIF,
+	".bmp":  FormatBMP,
+	".tif":  FormatTIFF,
+	".tiff": FormatTIFF,
+}
+
+//формат файла по расширению, пустая строка если формат не поддерживаеться
+func FormatByExtension(extension string) string {
+	return extensionFormat[strings.ToLower(extension)]
+}
+
+//mime тип формата для Content-Type, у всех поддерживаемых форматов он image/<формат>
+func ContentType(format string) string {
+	if !IsFormatSupported(format) {
+		return "application/octet-stream"
+	}
+	return "image/" + format
+}
+
+func IsFormatSupported(format string) bool {
+	_, ok := formatExtension[format]
+	return ok
+}
+
+//кодирует картинку в format с настройками кодировщика из options
+func encode(w io.Writer, img image.Image, format string, options ResizeOptions) error {
+	switch format {
+	case FormatJPEG:
+		jpegOptions := &jpeg.Options{Quality: jpeg.DefaultQuality}
+		if options.Quality != 0 {
+			jpegOptions.Quality = int(options.Quality)
+		}
+		return jpeg.Encode(w, img, jpegOptions)
+	case FormatPNG:
+		encoder := &png.Encoder{CompressionLevel: compressionLevels[options.Compression]}
+		return encoder.Encode(w, img)
+	case FormatGIF:
+		//палитра из цветов картинки, дизеринг рисует ее уже этой палитрой
+		gifOptions := &gif.Options{NumColors: 256, Quantizer: medianCutQuantizer{}, Drawer: draw.FloydSteinberg}
+		if options.Colors != 0 {
+			gifOptions.NumColors = int(options.Colors)
+		}
+		if options.NoDither {
+			gifOptions.Drawer = draw.Src
+		}
+		return gif.Encode(w, img, gifOptions)
+	case FormatBMP:
+		return bmp.Encode(w, img)
+	case FormatTIFF:
+		return tiff.Encode(w, img, nil)
+	default:
+		return fmt.Errorf("%s format %w", format, ErrNotSupported)
+	}
+}
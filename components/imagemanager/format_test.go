@@ -0,0 +1,220 @@
+package imagemanager
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// ImageManager со своей временной папкой
+func newTestManager(t *testing.T) ImageManager {
+	tmpDir, err := ioutil.TempDir("", "imagemanager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(tmpDir)
+	})
+	return NewImageManager(NewConfig(tmpDir+string(os.PathSeparator), DefaultFilter, MetadataStrip, false))
+}
+
+func TestEncodeFormats(t *testing.T) {
+	for _, format := range []string{FormatJPEG, FormatPNG, FormatGIF, FormatBMP, FormatTIFF} {
+		t.Run(format, func(t *testing.T) {
+			var encoded bytes.Buffer
+			err := encode(&encoded, newTestImage(), format, ResizeOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			config, decodedFormat, err := image.DecodeConfig(&encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decodedFormat != format || config.Width != 40 || config.Height != 20 {
+				t.Fatalf("decoded %s %dx%d", decodedFormat, config.Width, config.Height)
+			}
+		})
+	}
+
+	var encoded bytes.Buffer
+	err := encode(&encoded, newTestImage(), "webp", ResizeOptions{})
+	if err == nil {
+		t.Fatal("webp is encoded")
+	}
+}
+
+func TestFormatByExtension(t *testing.T) {
+	tests := []struct {
+		extension   string
+		format      string
+		contentType string
+	}{
+		{".jpg", FormatJPEG, "image/jpeg"},
+		{".JPEG", FormatJPEG, "image/jpeg"},
+		{".png", FormatPNG, "image/png"},
+		{".tif", FormatTIFF, "image/tiff"},
+		{".webp", "", "application/octet-stream"},
+	}
+	for _, test := range tests {
+		t.Run(test.extension, func(t *testing.T) {
+			format := FormatByExtension(test.extension)
+			if format != test.format || ContentType(format) != test.contentType {
+				t.Fatalf("format %q %s, want %q %s", format, ContentType(format), test.format, test.contentType)
+			}
+		})
+	}
+}
+
+func TestConvertFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		fileName string
+		decoded  string
+	}{
+		{"same format", "", "thumb20.a.png", FormatPNG},
+		{"format of the original", FormatPNG, "thumb20.a.png", FormatPNG},
+		//к имени дописываеться новое расширение, так ресайзы a.png и a.jpg не совпадут
+		{"to jpeg", FormatJPEG, "thumb20.a.png.jpg", FormatJPEG},
+		{"to gif", FormatGIF, "thumb20.a.png.gif", FormatGIF},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			im := newTestManager(t)
+			defer im.Clear()
+			var data bytes.Buffer
+			err := encode(&data, newTestImage(), FormatPNG, ResizeOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			file, err := im.SaveFile("a.png", data.Bytes())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			thumbFile, err := im.ResizeFile(file, ResizeOptions{Width: 20, Format: test.format})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if thumbFile.Name != test.fileName {
+				t.Fatalf("name %s, want %s", thumbFile.Name, test.fileName)
+			}
+			thumb, err := os.Open(thumbFile.Path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, format, err := image.DecodeConfig(thumb)
+			_ = thumb.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if format != test.decoded {
+				t.Fatalf("format %s, want %s", format, test.decoded)
+			}
+		})
+	}
+}
+
+// картинка с плавным градиентом, на ней видна разница в настройках кодировщика
+func newTestGradient() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 4), G: uint8(y * 4), B: uint8((x + y) * 2), A: 255})
+		}
+	}
+	return img
+}
+
+func encodedSize(t *testing.T, format string, options ResizeOptions) int {
+	var encoded bytes.Buffer
+	err := encode(&encoded, newTestGradient(), format, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded.Len()
+}
+
+func TestEncoderOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		smaller ResizeOptions
+		larger  ResizeOptions
+	}{
+		{"jpeg quality", FormatJPEG, ResizeOptions{Quality: 10}, ResizeOptions{Quality: 95}},
+		{"png compression", FormatPNG, ResizeOptions{Compression: CompressionBest}, ResizeOptions{Compression: CompressionNone}},
+		{"gif colors", FormatGIF, ResizeOptions{Colors: 4}, ResizeOptions{Colors: 256}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			smaller := encodedSize(t, test.format, test.smaller)
+			larger := encodedSize(t, test.format, test.larger)
+			if smaller >= larger {
+				t.Fatalf("%+v gives %d bytes, %+v gives %d bytes", test.smaller, smaller, test.larger, larger)
+			}
+		})
+	}
+}
+
+func TestGIFPalette(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ResizeOptions
+		colors  int
+	}{
+		{"default", ResizeOptions{}, 256},
+		{"limited", ResizeOptions{Colors: 8}, 8},
+		{"limited without dithering", ResizeOptions{Colors: 8, NoDither: true}, 8},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var encoded bytes.Buffer
+			err := encode(&encoded, newTestGradient(), FormatGIF, test.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := gif.Decode(&encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			palette := decoded.(*image.Paletted).Palette
+			if len(palette) > test.colors {
+				t.Fatalf("%d colors, want at most %d", len(palette), test.colors)
+			}
+		})
+	}
+}
+
+func TestValidateEncoder(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  ResizeOptions
+		valid    bool
+		fileName string
+	}{
+		{"quality", ResizeOptions{Width: 100, Quality: 80}, true, "thumb100_q80.a.png"},
+		{"compression", ResizeOptions{Width: 100, Compression: CompressionBest}, true, "thumb100_cbest.a.png"},
+		{"colors without dithering", ResizeOptions{Width: 100, Colors: 16, NoDither: true}, true, "thumb100_p16_nodither.a.png"},
+		{"quality above 100", ResizeOptions{Width: 100, Quality: 101}, false, ""},
+		{"unknown compression", ResizeOptions{Width: 100, Compression: "fast"}, false, ""},
+		{"too many colors", ResizeOptions{Width: 100, Colors: 257}, false, ""},
+		{"unknown format", ResizeOptions{Width: 100, Format: "webp"}, false, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.options.Validate()
+			if (err == nil) != test.valid {
+				t.Fatalf("Validate() = %v, valid %v", err, test.valid)
+			}
+			//разные настройки кодировщика дают разные файлы
+			if test.valid && test.options.FileName("a.png") != test.fileName {
+				t.Fatalf("FileName() = %s, want %s", test.options.FileName("a.png"), test.fileName)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package imagemanager
+
+import (
+	"github.com/nfnt/resize"
+	"image"
+	"image/draw"
+	"math"
+)
+
+//меняет размер картинки по параметрам
+func (o ResizeOptions) apply(src image.Image) image.Image {
+	if o.Width == 0 || o.Height == 0 {
+		return resize.Resize(o.Width, o.Height, src, o.interpolation())
+	}
+
+	bounds := src.Bounds()
+	scaleX := float64(o.Width) / float64(bounds.Dx())
+	scaleY := float64(o.Height) / float64(bounds.Dy())
+
+	switch o.mode() {
+	case ModeStretch:
+		return resize.Resize(o.Width, o.Height, src, o.interpolation())
+	case ModeFill:
+		//масштабируем так что бы рамка была покрыта полностью, потом обрезаем лишнее
+		scale := math.Max(scaleX, scaleY)
+		width := maxUint(o.Width, scaled(bounds.Dx(), scale))
+		height := maxUint(o.Height, scaled(bounds.Dy(), scale))
+		resized := resize.Resize(width, height, src, o.interpolation())
+		return crop(resized, int(o.Width), int(o.Height), o.gravity())
+	case ModePad:
+		scale := math.Min(scaleX, scaleY)
+		resized := resize.Resize(scaled(bounds.Dx(), scale), scaled(bounds.Dy(), scale), src, o.interpolation())
+		return pad(resized, int(o.Width), int(o.Height), o.gravity(), o)
+	default:
+		scale := math.Min(scaleX, scaleY)
+		return resize.Resize(scaled(bounds.Dx(), scale), scaled(bounds.Dy(), scale), src, o.interpolation())
+	}
+}
+
+//вырезает из src прямоугольник width x height
+func crop(src image.Image, width int, height int, gravity string) image.Image {
+	bounds := src.Bounds()
+	offset := anchor(gravity, bounds.Dx()-width, bounds.Dy()-height)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min.Add(offset), draw.Src)
+	return dst
+}
+
+//кладет src на холст width x height залитый цветом фона
+func pad(src image.Image, width int, height int, gravity string, options ResizeOptions) image.Image {
+	bounds := src.Bounds()
+	offset := anchor(gravity, width-bounds.Dx(), height-bounds.Dy())
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(options.background()), image.Point{}, draw.Src)
+	target := image.Rectangle{Min: offset, Max: offset.Add(bounds.Size())}
+	draw.Draw(dst, target, src, bounds.Min, draw.Over)
+	return dst
+}
+
+//смещение картинки внутри рамки, dx и dy это разница размеров
+func anchor(gravity string, dx int, dy int) image.Point {
+	point := image.Point{X: dx / 2, Y: dy / 2}
+	switch gravity {
+	case GravityWest, GravityNorthWest, GravitySouthWest:
+		point.X = 0
+	case GravityEast, GravityNorthEast, GravitySouthEast:
+		point.X = dx
+	}
+	switch gravity {
+	case GravityNorth, GravityNorthWest, GravityNorthEast:
+		point.Y = 0
+	case GravitySouth, GravitySouthWest, GravitySouthEast:
+		point.Y = dy
+	}
+	return point
+}
+
+func scaled(size int, scale float64) uint {
+	result := uint(math.Round(float64(size) * scale))
+	if result < 1 {
+		return 1
+	}
+	return result
+}
+
+func maxUint(a uint, b uint) uint {
+	if a > b {
+		return a
+	}
+	return b
+}
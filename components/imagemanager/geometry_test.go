@@ -0,0 +1,87 @@
+package imagemanager
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+var testRed = color.RGBA{R: 255, A: 255}
+var testBlue = color.RGBA{B: 255, A: 255}
+
+//картинка 40x20, левая половина красная, правая синяя
+func newTestImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	draw.Draw(img, image.Rect(0, 0, 20, 20), image.NewUniform(testRed), image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(20, 0, 40, 20), image.NewUniform(testBlue), image.Point{}, draw.Src)
+	return img
+}
+
+func sameColor(a color.Color, b color.Color) bool {
+	r1, g1, b1, a1 := a.RGBA()
+	r2, g2, b2, a2 := b.RGBA()
+	near := func(x uint32, y uint32) bool {
+		return x-y < 0x1000 || y-x < 0x1000
+	}
+	return near(r1, r2) && near(g1, g2) && near(b1, b2) && near(a1, a2)
+}
+
+func TestApplyGeometry(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ResizeOptions
+		width   int
+		height  int
+		//цвет левого верхнего и правого нижнего угла результата
+		topLeft     color.Color
+		bottomRight color.Color
+	}{
+		{"width only", ResizeOptions{Width: 20}, 20, 10, testRed, testBlue},
+		{"height only", ResizeOptions{Height: 10}, 20, 10, testRed, testBlue},
+		{"fit", ResizeOptions{Width: 20, Height: 20}, 20, 10, testRed, testBlue},
+		{"stretch", ResizeOptions{Width: 20, Height: 20, Mode: ModeStretch}, 20, 20, testRed, testBlue},
+		{"fill center", ResizeOptions{Width: 10, Height: 10, Mode: ModeFill}, 10, 10, testRed, testBlue},
+		{"fill west", ResizeOptions{Width: 10, Height: 10, Mode: ModeFill, Gravity: GravityWest}, 10, 10, testRed, testRed},
+		{"fill east", ResizeOptions{Width: 10, Height: 10, Mode: ModeFill, Gravity: GravityEast}, 10, 10, testBlue, testBlue},
+		{"pad", ResizeOptions{Width: 20, Height: 20, Mode: ModePad, Background: "0f0"}, 20, 20, color.RGBA{G: 255, A: 255}, color.RGBA{G: 255, A: 255}},
+		{"pad north", ResizeOptions{Width: 20, Height: 20, Mode: ModePad, Gravity: GravityNorth}, 20, 20, testRed, color.White},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := test.options.apply(newTestImage())
+			bounds := result.Bounds()
+			if bounds.Dx() != test.width || bounds.Dy() != test.height {
+				t.Fatalf("size %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), test.width, test.height)
+			}
+			topLeft := result.At(bounds.Min.X, bounds.Min.Y)
+			bottomRight := result.At(bounds.Max.X-1, bounds.Max.Y-1)
+			if !sameColor(topLeft, test.topLeft) || !sameColor(bottomRight, test.bottomRight) {
+				t.Fatalf("corners %v %v, want %v %v", topLeft, bottomRight, test.topLeft, test.bottomRight)
+			}
+		})
+	}
+}
+
+func TestAnchor(t *testing.T) {
+	tests := []struct {
+		gravity string
+		point   image.Point
+	}{
+		{GravityCenter, image.Point{X: 5, Y: 10}},
+		{GravityNorth, image.Point{X: 5, Y: 0}},
+		{GravitySouth, image.Point{X: 5, Y: 20}},
+		{GravityWest, image.Point{X: 0, Y: 10}},
+		{GravityEast, image.Point{X: 10, Y: 10}},
+		{GravityNorthWest, image.Point{X: 0, Y: 0}},
+		{GravitySouthEast, image.Point{X: 10, Y: 20}},
+	}
+	for _, test := range tests {
+		t.Run(test.gravity, func(t *testing.T) {
+			point := anchor(test.gravity, 10, 20)
+			if point != test.point {
+				t.Fatalf("anchor = %v, want %v", point, test.point)
+			}
+		})
+	}
+}
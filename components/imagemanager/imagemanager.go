@@ -1,21 +1,22 @@
 package imagemanager
 
 import (
+	"bytes"
 	"errors"
-	"github.com/nfnt/resize"
+	"fmt"
 	"image"
 	"image/gif"
-	"image/jpeg"
-	"image/png"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
-	"strconv"
 )
 
 const ThumbPrefix = "thumb"
-var supportedExtension = map[string]string{".jpg": "jpg", ".jpeg": "jpeg", ".png": "png", ".gif": "gif"}
+var supportedExtension = map[string]string{".jpg": "jpg", ".jpeg": "jpeg", ".png": "png", ".gif": "gif", ".bmp": "bmp", ".tif": "tif", ".tiff": "tiff"}
+
+var ErrNotSupported = errors.New("is not supported")
 
 //структура которая занимаеться манипуляциями с файлами
 //сохраняет файлы во временную папку путь к кторой указываеться в конфиге
@@ -24,6 +25,8 @@ var supportedExtension = map[string]string{".jpg": "jpg", ".jpeg": "jpeg", ".png
 type ImageManager struct {
 	Config Config
 	TmpFiles []*File
+	//декодированные картинки водяных знаков по id, добавляються через AddWatermarkImage
+	watermarks map[string]image.Image
 }
 
 func NewImageManager(config Config) ImageManager {
@@ -40,6 +43,8 @@ func (im *ImageManager) Clear() {
 	for _, file := range im.TmpFiles {
 		_ = os.Remove(file.Path)
 	}
+	im.TmpFiles = nil
+	im.watermarks = nil
 }
 
 func (im *ImageManager) IsExtensionSupported(extension string) bool {
@@ -112,48 +117,136 @@ func (im *ImageManager) CreateEmptyFile(fileName string) (*os.File, error) {
 	return file, nil
 }
 
-func (im *ImageManager) ResizeFile(file *File, width uint) (*File, error) {
+func (im *ImageManager) ResizeFile(file *File, options ResizeOptions) (*File, error) {
+	decodedImage, err := im.DecodeFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return im.ResizeImage(decodedImage, file, options)
+}
+
+//декодирует файл один раз, что бы потом сделать из него несколько ресайзов через ResizeImage
+func (im *ImageManager) DecodeFile(file *File) (image.Image, error) {
 	fileToDecode, err := os.Open(file.Path)
 	if err != nil {
 		return nil, err
 	}
 
-	decodedImage, _, err := image.Decode(fileToDecode)
+	//размер известен из заголовка, слишком большую картинку даже не начинаем декодировать
+	err = CheckImageSize(fileToDecode)
+	if err != nil {
+		_ = fileToDecode.Close()
+		return nil, err
+	}
+	_, err = fileToDecode.Seek(0, io.SeekStart)
+	if err != nil {
+		_ = fileToDecode.Close()
+		return nil, err
+	}
+
+	decodedImage, format, err := image.Decode(fileToDecode)
 	if err != nil {
+		_ = fileToDecode.Close()
 		return nil, err
 	}
 
+	//телефоны не поворачивают пиксели, а пишут в exif как картинку нужно повернуть
+	//image.Decode этот тег игнорирует, поэтому поворачиваем сами
+	//в ресайзы exif не пишеться, так что там картинка уже просто повернута правильно
+	if format == FormatJPEG {
+		_, err = fileToDecode.Seek(0, io.SeekStart)
+		if err != nil {
+			_ = fileToDecode.Close()
+			return nil, err
+		}
+		decodedImage = Orient(decodedImage, ReadOrientation(fileToDecode))
+	}
+
+	//image.Decode читает только первый кадр gif, для анимации читаем все
+	if format == FormatGIF {
+		_, err = fileToDecode.Seek(0, io.SeekStart)
+		if err != nil {
+			_ = fileToDecode.Close()
+			return nil, err
+		}
+		decodedGIF, err := gif.DecodeAll(fileToDecode)
+		if err != nil {
+			_ = fileToDecode.Close()
+			return nil, err
+		}
+		if len(decodedGIF.Image) > 1 {
+			decodedImage, err = newAnimation(decodedGIF)
+			if err != nil {
+				_ = fileToDecode.Close()
+				return nil, err
+			}
+		}
+	}
+
 	err = fileToDecode.Close()
 	if err != nil {
 		return nil, err
 	}
+	return decodedImage, nil
+}
 
-	thumbImage := resize.Resize(width, 0, decodedImage, resize.Lanczos3)
+//подставляет в параметры значения по умолчанию из конфига
+//результат стоит сохранять вместе с ресайзом, так его можно повторить даже если конфиг поменяеться
+func (im *ImageManager) WithDefaults(options ResizeOptions) ResizeOptions {
+	if options.Filter == "" {
+		options.Filter = im.Config.DefaultFilter
+	}
+	return options
+}
 
-	thumbFileName := ThumbPrefix + strconv.Itoa(int(width)) + "." + file.Name
-	thumbFilePath := im.Config.TmpDir + thumbFileName
-	thumbFile, err := os.Create(thumbFilePath)
+//ресайзит уже декодированную картинку. file нужен для имени и формата результата
+func (im *ImageManager) ResizeImage(decodedImage image.Image, file *File, options ResizeOptions) (*File, error) {
+	options = im.WithDefaults(options)
+	err := options.Validate()
 	if err != nil {
 		return nil, err
 	}
+	return im.render(decodedImage, file, options.FileName(file.Name), im.withWatermark(options.process, options.Watermark), options)
+}
 
+//обрабатывает картинку через process и сохраняет результат в файл thumbFileName
+//формат берется из расширения имени, настройки кодировщика из encoder
+func (im *ImageManager) render(
+	decodedImage image.Image,
+	file *File,
+	thumbFileName string,
+	process func(image.Image) (image.Image, error),
+	encoder ResizeOptions,
+) (*File, error) {
+	thumbFilePath := im.Config.TmpDir + thumbFileName
 	fileExt := filepath.Ext(thumbFilePath)
-	switch fileExt {
-	case ".jpg", ".jpeg":
-		err = jpeg.Encode(thumbFile, thumbImage, nil)
-	case ".png":
-		err = png.Encode(thumbFile, thumbImage)
-	case ".gif":
-		err = gif.Encode(thumbFile, thumbImage, nil)
-	default:
-		return nil, errors.New(fileExt + " is not supported")
+	format := FormatByExtension(fileExt)
+	if format == "" {
+		return nil, fmt.Errorf("%s %w", fileExt, ErrNotSupported)
+	}
+
+	//кодируем в память, что бы перед записью перенести метаданные исходника
+	//анимация остаеться анимацией только в gif, в остальные форматы и с FirstFrame идет первый кадр
+	var encoded bytes.Buffer
+	var err error
+	animation, animated := decodedImage.(*Animation)
+	if animated && format == FormatGIF && !encoder.FirstFrame {
+		err = encodeAnimation(&encoded, animation, process, encoder)
+	} else {
+		if animated {
+			decodedImage = animation.Frames[0]
+		}
+		var thumbImage image.Image
+		thumbImage, err = process(decodedImage)
+		if err != nil {
+			return nil, err
+		}
+		err = encode(&encoded, thumbImage, format, encoder)
 	}
 	if err != nil {
-		_ = thumbFile.Close()
 		return nil, err
 	}
-
-	err = thumbFile.Close()
+	err = ioutil.WriteFile(thumbFilePath, im.copyMetadata(encoded.Bytes(), file), 0644)
 	if err != nil {
 		return nil, err
 	}
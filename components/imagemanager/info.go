@@ -0,0 +1,243 @@
+package imagemanager
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"io/ioutil"
+	"math"
+	"strings"
+	"time"
+)
+
+//теги exif которые попадают в ImageInfo
+const exifMakeTag = 0x010f
+const exifModelTag = 0x0110
+const exifDateTimeTag = 0x0132
+const exifSubIFDTag = 0x8769
+const exifGPSIFDTag = 0x8825
+const exifDateTimeOriginalTag = 0x9003
+const gpsLatitudeRefTag = 0x0001
+const gpsLatitudeTag = 0x0002
+const gpsLongitudeRefTag = 0x0003
+const gpsLongitudeTag = 0x0004
+
+const exifDateFormat = "2006:01:02 15:04:05"
+
+//информация о картинке. сохраняеться вместе с картинкой в базе, поэтому у полей есть json теги
+//размеры указаны после поворота по exif, то есть такие же как у ресайзов
+type ImageInfo struct {
+	Width      int       `json:"width"`
+	Height     int       `json:"height"`
+	Format     string    `json:"format"`
+	ColorModel string    `json:"colorModel"`
+	Frames     int       `json:"frames"`
+	FileSize   int64     `json:"fileSize"`
+	Exif       *ExifInfo `json:"exif,omitempty"`
+}
+
+type ExifInfo struct {
+	CameraMake  string `json:"cameraMake,omitempty"`
+	CameraModel string `json:"cameraModel,omitempty"`
+	//время съемки без часового пояса, exif его не хранит
+	DateTaken   string   `json:"dateTaken,omitempty"`
+	Orientation int      `json:"orientation,omitempty"`
+	GPS         *GPSInfo `json:"gps,omitempty"`
+}
+
+type GPSInfo struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+//собирает информацию о картинке. пиксели не декодируються, у gif кадры считаються по блокам файла
+func ExtractInfo(data []byte) (*ImageInfo, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	info := &ImageInfo{
+		Width:      config.Width,
+		Height:     config.Height,
+		Format:     format,
+		ColorModel: colorModelName(config.ColorModel),
+		Frames:     1,
+		FileSize:   int64(len(data)),
+	}
+	if format == FormatGIF {
+		info.Frames, err = countGIFFrames(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		err = checkAnimation(info.Frames, config.Width, config.Height)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	//метаданные могут быть битыми, картинка от этого хуже не становиться
+	m, err := readMetadata(data)
+	if err == nil {
+		info.Exif = parseExifInfo(m.exif)
+	}
+	if info.Exif != nil && info.Exif.Orientation >= OrientationTranspose {
+		info.Width, info.Height = info.Height, info.Width
+	}
+	return info, nil
+}
+
+func (im *ImageManager) ExtractFileInfo(file *File) (*ImageInfo, error) {
+	data, err := ioutil.ReadFile(file.Path)
+	if err != nil {
+		return nil, err
+	}
+	return ExtractInfo(data)
+}
+
+func colorModelName(model color.Model) string {
+	if _, ok := model.(color.Palette); ok {
+		return "paletted"
+	}
+	switch model {
+	case color.RGBAModel:
+		return "rgba"
+	case color.RGBA64Model:
+		return "rgba64"
+	case color.NRGBAModel:
+		return "nrgba"
+	case color.NRGBA64Model:
+		return "nrgba64"
+	case color.AlphaModel:
+		return "alpha"
+	case color.Alpha16Model:
+		return "alpha16"
+	case color.GrayModel:
+		return "gray"
+	case color.Gray16Model:
+		return "gray16"
+	case color.YCbCrModel:
+		return "ycbcr"
+	case color.CMYKModel:
+		return "cmyk"
+	default:
+		return "unknown"
+	}
+}
+
+func parseExifInfo(tiff []byte) *ExifInfo {
+	order, ok := exifByteOrder(tiff)
+	if !ok {
+		return nil
+	}
+	entries, ok := exifIFD(tiff, order, int(order.Uint32(tiff[4:8])))
+	if !ok {
+		return nil
+	}
+	info := &ExifInfo{}
+	for _, entry := range entries {
+		switch entry.tag {
+		case exifMakeTag:
+			info.CameraMake = exifString(entry)
+		case exifModelTag:
+			info.CameraModel = exifString(entry)
+		case exifDateTimeTag:
+			//время изменения файла, если нет времени съемки сойдет и оно
+			if info.DateTaken == "" {
+				info.DateTaken = exifDate(exifString(entry))
+			}
+		case exifOrientationTag:
+			if len(entry.value) == 2 {
+				info.Orientation = int(order.Uint16(entry.value))
+			}
+		case exifSubIFDTag:
+			subEntries, ok := exifIFD(tiff, order, exifOffset(entry, order))
+			if !ok {
+				continue
+			}
+			for _, subEntry := range subEntries {
+				if subEntry.tag == exifDateTimeOriginalTag {
+					info.DateTaken = exifDate(exifString(subEntry))
+				}
+			}
+		case exifGPSIFDTag:
+			gpsEntries, ok := exifIFD(tiff, order, exifOffset(entry, order))
+			if ok {
+				info.GPS = parseGPS(gpsEntries, order)
+			}
+		}
+	}
+	if *info == (ExifInfo{}) {
+		return nil
+	}
+	return info
+}
+
+func parseGPS(entries []exifEntry, order binary.ByteOrder) *GPSInfo {
+	var latitude, longitude float64
+	var latitudeRef, longitudeRef string
+	var hasLatitude, hasLongitude bool
+	for _, entry := range entries {
+		switch entry.tag {
+		case gpsLatitudeRefTag:
+			latitudeRef = exifString(entry)
+		case gpsLongitudeRefTag:
+			longitudeRef = exifString(entry)
+		case gpsLatitudeTag:
+			latitude, hasLatitude = exifDegrees(entry, order)
+		case gpsLongitudeTag:
+			longitude, hasLongitude = exifDegrees(entry, order)
+		}
+	}
+	if !hasLatitude || !hasLongitude {
+		return nil
+	}
+	if latitudeRef == "S" {
+		latitude = -latitude
+	}
+	if longitudeRef == "W" {
+		longitude = -longitude
+	}
+	return &GPSInfo{Latitude: latitude, Longitude: longitude}
+}
+
+//градусы, минуты и секунды тремя дробями RATIONAL
+func exifDegrees(entry exifEntry, order binary.ByteOrder) (float64, bool) {
+	if entry.kind != 5 || entry.count != 3 || len(entry.value) != 24 {
+		return 0, false
+	}
+	var parts [3]float64
+	for i := range parts {
+		numerator := order.Uint32(entry.value[i*8:])
+		denominator := order.Uint32(entry.value[i*8+4:])
+		if denominator == 0 {
+			return 0, false
+		}
+		parts[i] = float64(numerator) / float64(denominator)
+	}
+	degrees := parts[0] + parts[1]/60 + parts[2]/3600
+	return math.Round(degrees*1e6) / 1e6, true
+}
+
+func exifString(entry exifEntry) string {
+	if entry.kind != 2 {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimRight(string(entry.value), "\x00"))
+}
+
+func exifOffset(entry exifEntry, order binary.ByteOrder) int {
+	if entry.kind != 4 || len(entry.value) != 4 {
+		return 0
+	}
+	return int(order.Uint32(entry.value))
+}
+
+//exif хранит дату как 2006:01:02 15:04:05, отдаем ее в виде 2006-01-02T15:04:05
+func exifDate(value string) string {
+	date, err := time.Parse(exifDateFormat, value)
+	if err != nil {
+		return value
+	}
+	return date.Format("2006-01-02T15:04:05")
+}
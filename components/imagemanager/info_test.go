@@ -0,0 +1,110 @@
+package imagemanager
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"reflect"
+	"testing"
+)
+
+//gif из frames кадров 40x20. если задан screen, кадры рисуються на холсте такого размера
+func testGIF(t *testing.T, frames int, screen image.Point) []byte {
+	animation := &gif.GIF{}
+	if screen != (image.Point{}) {
+		animation.Config = image.Config{Width: screen.X, Height: screen.Y, ColorModel: color.Palette(palette.Plan9)}
+	}
+	for i := 0; i < frames; i++ {
+		animation.Image = append(animation.Image, image.NewPaletted(image.Rect(0, 0, 40, 20), palette.Plan9))
+		animation.Delay = append(animation.Delay, 10)
+	}
+	var encoded bytes.Buffer
+	err := gif.EncodeAll(&encoded, animation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded.Bytes()
+}
+
+func TestExtractInfo(t *testing.T) {
+	order := binary.BigEndian
+	photo := testEXIF(order,
+		asciiTag(exifMakeTag, "Camera "),
+		asciiTag(exifModelTag, "Model 1"),
+		asciiTag(exifDateTimeTag, "2020:01:02 03:04:05"),
+		shortTag(exifOrientationTag, OrientationRotate90, order),
+		//время съемки из вложенного IFD важнее времени изменения
+		ifdTag(exifSubIFDTag, asciiTag(exifDateTimeOriginalTag, "2019:12:31 23:59:58")),
+		ifdTag(exifGPSIFDTag,
+			asciiTag(gpsLatitudeRefTag, "N"),
+			rationalTag(gpsLatitudeTag, order, [2]uint32{55, 1}, [2]uint32{45, 1}, [2]uint32{2100, 100}),
+			asciiTag(gpsLongitudeRefTag, "W"),
+			rationalTag(gpsLongitudeTag, order, [2]uint32{37, 1}, [2]uint32{37, 1}, [2]uint32{3, 1}),
+		),
+	)
+	img := newTestImage()
+	pngData := testPNG(t, img)
+	jpegData := testJPEG(t, img, nil)
+	photoData := testJPEG(t, img, photo)
+	modified := testJPEG(t, img, testEXIF(order, asciiTag(exifDateTimeTag, "2020:01:02 03:04:05")))
+	//без знаменателя координаты не считаються, остальное читаеться
+	brokenGPS := testJPEG(t, img, testEXIF(order,
+		asciiTag(exifMakeTag, "Camera"),
+		ifdTag(exifGPSIFDTag, rationalTag(gpsLatitudeTag, order, [2]uint32{55, 0}, [2]uint32{0, 1}, [2]uint32{0, 1}), rationalTag(gpsLongitudeTag, order, [2]uint32{37, 1}, [2]uint32{0, 1}, [2]uint32{0, 1})),
+	))
+	gifData := testGIF(t, 3, image.Point{})
+	tests := []struct {
+		name string
+		data []byte
+		info *ImageInfo
+	}{
+		{"png", pngData, &ImageInfo{Width: 40, Height: 20, Format: FormatPNG, ColorModel: "rgba", Frames: 1, FileSize: int64(len(pngData))}},
+		{"jpeg without exif", jpegData, &ImageInfo{Width: 40, Height: 20, Format: FormatJPEG, ColorModel: "ycbcr", Frames: 1, FileSize: int64(len(jpegData))}},
+		//размеры отдаються после поворота, как у ресайзов
+		{"photo", photoData, &ImageInfo{Width: 20, Height: 40, Format: FormatJPEG, ColorModel: "ycbcr", Frames: 1, FileSize: int64(len(photoData)), Exif: &ExifInfo{
+			CameraMake:  "Camera",
+			CameraModel: "Model 1",
+			DateTaken:   "2019-12-31T23:59:58",
+			Orientation: OrientationRotate90,
+			GPS:         &GPSInfo{Latitude: 55.755833, Longitude: -37.6175},
+		}}},
+		{"only modification date", modified, &ImageInfo{Width: 40, Height: 20, Format: FormatJPEG, ColorModel: "ycbcr", Frames: 1, FileSize: int64(len(modified)), Exif: &ExifInfo{DateTaken: "2020-01-02T03:04:05"}}},
+		{"broken gps", brokenGPS, &ImageInfo{Width: 40, Height: 20, Format: FormatJPEG, ColorModel: "ycbcr", Frames: 1, FileSize: int64(len(brokenGPS)), Exif: &ExifInfo{CameraMake: "Camera"}}},
+		{"animated gif", gifData, &ImageInfo{Width: 40, Height: 20, Format: FormatGIF, ColorModel: "paletted", Frames: 3, FileSize: int64(len(gifData))}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, err := ExtractInfo(test.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(info, test.info) {
+				t.Fatalf("ExtractInfo() = %+v %+v, want %+v %+v", info, info.Exif, test.info, test.info.Exif)
+			}
+		})
+	}
+}
+
+func TestExtractInfoErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"not an image", []byte("text"), image.ErrFormat},
+		//каждый кадр в памяти размером с холст, три кадра 7000x7000 больше MaxAnimationPixels
+		{"too large animation", testGIF(t, 3, image.Point{X: 7000, Y: 7000}), ErrImageTooLarge},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ExtractInfo(test.data)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("ExtractInfo() = %v, want %v", err, test.err)
+			}
+		})
+	}
+}
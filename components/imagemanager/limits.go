@@ -0,0 +1,83 @@
+package imagemanager
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"math"
+)
+
+//ограничения размеров. ресайз держит в памяти исходник и результат целиком, поэтому без них один запрос
+//вроде w:100000,h:100000 или картинка 50000x50000 на пару килобайт съедают всю память
+//MaxDimension - самая большая ширина или высота результата, MaxPixels - ширина на высоту исходника и результата
+const MaxDimension = 8192
+const MaxPixels = 50000000
+
+//анимация храниться покадрово и каждый кадр размером с весь gif, поэтому ограничиваеться сумма пикселей всех кадров
+const MaxAnimationPixels = 100000000
+
+var ErrImageTooLarge = errors.New("image is too large")
+
+//проверяет размер картинки по заголовку, пиксели при этом не декодируються
+//у gif еще считаються кадры, для этого r перематываеться в начало
+func CheckImageSize(r io.ReadSeeker) error {
+	config, format, err := image.DecodeConfig(r)
+	if err != nil {
+		return err
+	}
+	err = checkPixels(config.Width, config.Height)
+	if err != nil || format != FormatGIF {
+		return err
+	}
+	_, err = r.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	frames, err := countGIFFrames(r)
+	if err != nil {
+		return err
+	}
+	return checkAnimation(frames, config.Width, config.Height)
+}
+
+func checkPixels(width int, height int) error {
+	if int64(width)*int64(height) > MaxPixels {
+		return fmt.Errorf("%w: %dx%d is more than %d pixels", ErrImageTooLarge, width, height, MaxPixels)
+	}
+	return nil
+}
+
+func checkAnimation(frames int, width int, height int) error {
+	if int64(frames)*int64(width)*int64(height) > MaxAnimationPixels {
+		return fmt.Errorf("%w: %d frames of %dx%d are more than %d pixels", ErrImageTooLarge, frames, width, height, MaxAnimationPixels)
+	}
+	return nil
+}
+
+//параметры проверяют ширину и высоту по отдельности, но при ресайзе только по одной стороне вторая считаеться
+//от исходника, а fill сначала масштабирует больше рамки. поэтому размер проверяеться еще раз перед ресайзом
+func (o ResizeOptions) checkSize(bounds image.Rectangle) error {
+	width, height := float64(bounds.Dx()), float64(bounds.Dy())
+	if width == 0 || height == 0 {
+		return nil
+	}
+	switch {
+	case o.Width == 0 && o.Height == 0:
+		//без ресайза результат размером с исходник после поворота, ограничиваем только пиксели
+		return checkPixels(bounds.Dx(), bounds.Dy())
+	case o.Height == 0:
+		width, height = float64(o.Width), height*float64(o.Width)/width
+	case o.Width == 0:
+		width, height = width*float64(o.Height)/height, float64(o.Height)
+	case o.mode() == ModeFill:
+		scale := math.Max(float64(o.Width)/width, float64(o.Height)/height)
+		width, height = width*scale, height*scale
+	default:
+		width, height = float64(o.Width), float64(o.Height)
+	}
+	if width > MaxDimension || height > MaxDimension {
+		return fmt.Errorf("%w: result %.0fx%.0f is larger than %d", ErrImageTooLarge, width, height, MaxDimension)
+	}
+	return checkPixels(int(math.Round(width)), int(math.Round(height)))
+}
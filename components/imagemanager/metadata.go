@@ -0,0 +1,597 @@
+package imagemanager
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io/ioutil"
+	"sort"
+)
+
+//что делать с метаданными (exif, icc профиль, xmp, iptc, комментарии)
+//strip - ничего не сохраняеться
+//copyright - сохраняеться только icc профиль и копирайт, gps, серийники камеры и остальное удаляеться
+//keep - сохраняеться все
+//в оригиналах при strip и copyright остаеться еще тег Orientation, иначе фото с телефона будут лежать на боку
+//метаданные переносяться только в jpeg и png, в остальных форматах ресайзы всегда без метаданных
+const MetadataStrip = "strip"
+const MetadataCopyright = "copyright"
+const MetadataKeep = "keep"
+
+var ErrBadMetadata = errors.New("can not parse image metadata")
+
+const exifCopyrightTag = 0x8298
+
+var jpegExifHeader = []byte("Exif\x00\x00")
+var jpegXMPHeader = []byte("http://ns.adobe.com/xap/1.0/\x00")
+var jpegICCHeader = []byte("ICC_PROFILE\x00")
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+const pngXMPKeyword = "XML:com.adobe.xmp"
+const pngCopyrightKeyword = "Copyright"
+const pngCommentKeyword = "Comment"
+
+//размер одного значения каждого типа exif тега
+var exifTypeSizes = map[uint16]int{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8}
+
+func IsMetadataPolicySupported(policy string) bool {
+	return policy == MetadataStrip || policy == MetadataCopyright || policy == MetadataKeep
+}
+
+//метаданные картинки независимо от формата
+type metadata struct {
+	//tiff заголовок с IFD, как в jpeg после "Exif\0\0" и в png в чанке eXIf
+	exif     []byte
+	icc      []byte
+	xmp      []byte
+	iptc     []byte
+	comments []string
+	texts    []textChunk
+}
+
+type textChunk struct {
+	key   string
+	value string
+}
+
+//оставляет только то что разрешает policy
+//derivative значит картинка уже повернута, поэтому Orientation нужно сбросить
+func (m metadata) filter(policy string, derivative bool) metadata {
+	orientationTags := map[uint16]bool{}
+	if !derivative {
+		orientationTags[exifOrientationTag] = true
+	}
+	switch policy {
+	case MetadataKeep:
+		if derivative {
+			m.exif = normalizeOrientation(m.exif)
+		}
+		return m
+	case MetadataCopyright:
+		orientationTags[exifCopyrightTag] = true
+		result := metadata{icc: m.icc, exif: reduceEXIF(m.exif, orientationTags)}
+		for _, text := range m.texts {
+			if text.key == pngCopyrightKeyword {
+				result.texts = append(result.texts, text)
+			}
+		}
+		return result
+	default:
+		return metadata{exif: reduceEXIF(m.exif, orientationTags)}
+	}
+}
+
+//читает метаданные из jpeg или png. для других форматов метаданных нет
+func readMetadata(data []byte) (metadata, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xff, 0xd8}):
+		segments, _, err := jpegSegments(data)
+		if err != nil {
+			return metadata{}, err
+		}
+		return jpegMetadata(segments), nil
+	case bytes.HasPrefix(data, pngSignature):
+		chunks, err := pngChunks(data)
+		if err != nil {
+			return metadata{}, err
+		}
+		return pngMetadata(chunks), nil
+	default:
+		return metadata{}, nil
+	}
+}
+
+//заменяет метаданные картинки на m. для форматов кроме jpeg и png возвращает data как есть
+func writeMetadata(data []byte, m metadata) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xff, 0xd8}):
+		return writeJPEGMetadata(data, m)
+	case bytes.HasPrefix(data, pngSignature):
+		return writePNGMetadata(data, m)
+	default:
+		return data, nil
+	}
+}
+
+//переносит в ресайз метаданные исходника, которые разрешает политика из конфига
+func (im *ImageManager) copyMetadata(encoded []byte, file *File) []byte {
+	if !IsMetadataPolicySupported(im.Config.MetadataPolicy) || im.Config.MetadataPolicy == MetadataStrip {
+		return encoded
+	}
+	source, err := ioutil.ReadFile(file.Path)
+	if err != nil {
+		return encoded
+	}
+	//если метаданные исходника не читаються, ресайз просто остаеться без них
+	sourceMetadata, err := readMetadata(source)
+	if err != nil {
+		return encoded
+	}
+	result, err := writeMetadata(encoded, sourceMetadata.filter(im.Config.MetadataPolicy, true))
+	if err != nil {
+		return encoded
+	}
+	return result
+}
+
+//чистит метаданные оригинала по политике из конфига, пиксели при этом не перекодируються
+//неизвестная политика считаеться как strip, лучше удалить лишнее чем оставить gps
+func (im *ImageManager) SanitizeOriginal(data []byte) ([]byte, error) {
+	if im.Config.MetadataPolicy == MetadataKeep {
+		return data, nil
+	}
+	m, err := readMetadata(data)
+	if err != nil {
+		return nil, err
+	}
+	return writeMetadata(data, m.filter(im.Config.MetadataPolicy, false))
+}
+
+//то же самое для файла во временной папке
+func (im *ImageManager) SanitizeFile(file *File) error {
+	data, err := ioutil.ReadFile(file.Path)
+	if err != nil {
+		return err
+	}
+	data, err = im.SanitizeOriginal(data)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file.Path, data, 0644)
+}
+
+type jpegSegment struct {
+	marker byte
+	data   []byte
+}
+
+func (s jpegSegment) isMetadata() bool {
+	switch {
+	case s.marker == 0xe1 || s.marker == 0xe2 || s.marker == 0xed || s.marker == 0xfe:
+		return true
+	//остальные APPn кроме APP0 (JFIF) и APP14 (Adobe), они нужны для декодирования
+	case s.marker >= 0xe3 && s.marker <= 0xef && s.marker != 0xee:
+		return true
+	default:
+		return false
+	}
+}
+
+//сегменты jpeg до начала данных картинки и позиция с которой начинаеться SOS
+func jpegSegments(data []byte) ([]jpegSegment, int, error) {
+	var segments []jpegSegment
+	position := 2
+	for {
+		if position+4 > len(data) || data[position] != 0xff {
+			return nil, 0, ErrBadMetadata
+		}
+		marker := data[position+1]
+		//байты заполнения между сегментами
+		if marker == 0xff {
+			position++
+			continue
+		}
+		if marker == 0xda {
+			return segments, position, nil
+		}
+		length := int(binary.BigEndian.Uint16(data[position+2:]))
+		if length < 2 || position+2+length > len(data) {
+			return nil, 0, ErrBadMetadata
+		}
+		segments = append(segments, jpegSegment{marker: marker, data: data[position+4 : position+2+length]})
+		position += 2 + length
+	}
+}
+
+func jpegMetadata(segments []jpegSegment) metadata {
+	var m metadata
+	iccChunks := map[byte][]byte{}
+	for _, segment := range segments {
+		switch {
+		case segment.marker == 0xe1 && bytes.HasPrefix(segment.data, jpegExifHeader):
+			m.exif = segment.data[len(jpegExifHeader):]
+		case segment.marker == 0xe1 && bytes.HasPrefix(segment.data, jpegXMPHeader):
+			m.xmp = segment.data[len(jpegXMPHeader):]
+		//icc профиль может быть разбит на несколько сегментов, после заголовка идут номер куска и их количество
+		case segment.marker == 0xe2 && bytes.HasPrefix(segment.data, jpegICCHeader) && len(segment.data) > len(jpegICCHeader)+2:
+			iccChunks[segment.data[len(jpegICCHeader)]] = segment.data[len(jpegICCHeader)+2:]
+		case segment.marker == 0xed:
+			m.iptc = segment.data
+		case segment.marker == 0xfe:
+			m.comments = append(m.comments, string(segment.data))
+		}
+	}
+	for i := 1; i <= len(iccChunks); i++ {
+		chunk, ok := iccChunks[byte(i)]
+		if !ok {
+			m.icc = nil
+			break
+		}
+		m.icc = append(m.icc, chunk...)
+	}
+	return m
+}
+
+func writeJPEGMetadata(data []byte, m metadata) ([]byte, error) {
+	segments, scan, err := jpegSegments(data)
+	if err != nil {
+		return nil, err
+	}
+	result := bytes.NewBuffer(make([]byte, 0, len(data)))
+	result.Write(data[:2])
+	//APP0 должен идти первым
+	for _, segment := range segments {
+		if segment.marker == 0xe0 {
+			writeJPEGSegment(result, segment.marker, segment.data)
+		}
+	}
+	if len(m.exif) > 0 {
+		writeJPEGSegment(result, 0xe1, append(append([]byte{}, jpegExifHeader...), m.exif...))
+	}
+	if len(m.xmp) > 0 {
+		writeJPEGSegment(result, 0xe1, append(append([]byte{}, jpegXMPHeader...), m.xmp...))
+	}
+	//в один сегмент влезает 65535 байт вместе с длинной и заголовком
+	const iccChunkSize = 65535 - 2 - 14
+	iccCount := (len(m.icc) + iccChunkSize - 1) / iccChunkSize
+	for i := 0; i < iccCount; i++ {
+		end := (i + 1) * iccChunkSize
+		if end > len(m.icc) {
+			end = len(m.icc)
+		}
+		chunk := append(append([]byte{}, jpegICCHeader...), byte(i+1), byte(iccCount))
+		writeJPEGSegment(result, 0xe2, append(chunk, m.icc[i*iccChunkSize:end]...))
+	}
+	if len(m.iptc) > 0 {
+		writeJPEGSegment(result, 0xed, m.iptc)
+	}
+	for _, comment := range m.comments {
+		writeJPEGSegment(result, 0xfe, []byte(comment))
+	}
+	for _, segment := range segments {
+		if segment.marker != 0xe0 && !segment.isMetadata() {
+			writeJPEGSegment(result, segment.marker, segment.data)
+		}
+	}
+	result.Write(data[scan:])
+	return result.Bytes(), nil
+}
+
+func writeJPEGSegment(w *bytes.Buffer, marker byte, data []byte) {
+	if len(data)+2 > 0xffff {
+		return
+	}
+	w.Write([]byte{0xff, marker})
+	_ = binary.Write(w, binary.BigEndian, uint16(len(data)+2))
+	w.Write(data)
+}
+
+type pngChunk struct {
+	kind string
+	data []byte
+}
+
+func (c pngChunk) isMetadata() bool {
+	switch c.kind {
+	case "iCCP", "eXIf", "tEXt", "zTXt", "iTXt", "tIME":
+		return true
+	default:
+		return false
+	}
+}
+
+func pngChunks(data []byte) ([]pngChunk, error) {
+	var chunks []pngChunk
+	position := len(pngSignature)
+	for position < len(data) {
+		if position+12 > len(data) {
+			return nil, ErrBadMetadata
+		}
+		length := int(binary.BigEndian.Uint32(data[position:]))
+		if length < 0 || position+12+length > len(data) {
+			return nil, ErrBadMetadata
+		}
+		chunks = append(chunks, pngChunk{
+			kind: string(data[position+4 : position+8]),
+			data: data[position+8 : position+8+length],
+		})
+		position += 12 + length
+	}
+	if len(chunks) == 0 || chunks[0].kind != "IHDR" {
+		return nil, ErrBadMetadata
+	}
+	return chunks, nil
+}
+
+func pngMetadata(chunks []pngChunk) metadata {
+	var m metadata
+	for _, chunk := range chunks {
+		switch chunk.kind {
+		case "eXIf":
+			m.exif = chunk.data
+		case "iCCP":
+			//имя профиля, 0, метод сжатия, сжатый профиль
+			separator := bytes.IndexByte(chunk.data, 0)
+			if separator < 0 || separator+2 > len(chunk.data) {
+				continue
+			}
+			icc, err := inflate(chunk.data[separator+2:])
+			if err == nil {
+				m.icc = icc
+			}
+		case "tEXt":
+			separator := bytes.IndexByte(chunk.data, 0)
+			if separator < 0 {
+				continue
+			}
+			m.texts = append(m.texts, textChunk{key: string(chunk.data[:separator]), value: string(chunk.data[separator+1:])})
+		case "zTXt":
+			separator := bytes.IndexByte(chunk.data, 0)
+			if separator < 0 || separator+2 > len(chunk.data) {
+				continue
+			}
+			value, err := inflate(chunk.data[separator+2:])
+			if err == nil {
+				m.texts = append(m.texts, textChunk{key: string(chunk.data[:separator]), value: string(value)})
+			}
+		case "iTXt":
+			text, ok := parseITXt(chunk.data)
+			if !ok {
+				continue
+			}
+			if text.key == pngXMPKeyword {
+				m.xmp = []byte(text.value)
+				continue
+			}
+			m.texts = append(m.texts, text)
+		}
+	}
+	//комментарий jpeg в png пишеться текстом с ключем Comment, обратно он тоже должен стать комментарием
+	texts := m.texts[:0]
+	for _, text := range m.texts {
+		if text.key == pngCommentKeyword {
+			m.comments = append(m.comments, text.value)
+			continue
+		}
+		texts = append(texts, text)
+	}
+	m.texts = texts
+	return m
+}
+
+//ключ, 0, флаг сжатия, метод, язык, 0, переведенный ключ, 0, текст
+func parseITXt(data []byte) (textChunk, bool) {
+	fields := bytes.SplitN(data, []byte{0}, 2)
+	if len(fields) != 2 || len(fields[1]) < 2 {
+		return textChunk{}, false
+	}
+	key := string(fields[0])
+	compressed := fields[1][0] == 1
+	rest := bytes.SplitN(fields[1][2:], []byte{0}, 3)
+	if len(rest) != 3 {
+		return textChunk{}, false
+	}
+	value := rest[2]
+	if compressed {
+		var err error
+		value, err = inflate(value)
+		if err != nil {
+			return textChunk{}, false
+		}
+	}
+	return textChunk{key: key, value: string(value)}, true
+}
+
+func writePNGMetadata(data []byte, m metadata) ([]byte, error) {
+	chunks, err := pngChunks(data)
+	if err != nil {
+		return nil, err
+	}
+	result := bytes.NewBuffer(make([]byte, 0, len(data)))
+	result.Write(pngSignature)
+	//IHDR всегда первый, метаданные сразу после него, icc профиль должен быть до IDAT
+	writePNGChunk(result, chunks[0])
+	if len(m.icc) > 0 {
+		var compressed bytes.Buffer
+		compressed.WriteString("ICC profile\x00\x00")
+		writer := zlib.NewWriter(&compressed)
+		_, _ = writer.Write(m.icc)
+		_ = writer.Close()
+		writePNGChunk(result, pngChunk{kind: "iCCP", data: compressed.Bytes()})
+	}
+	if len(m.exif) > 0 {
+		writePNGChunk(result, pngChunk{kind: "eXIf", data: m.exif})
+	}
+	if len(m.xmp) > 0 {
+		m.texts = append(m.texts, textChunk{key: pngXMPKeyword, value: string(m.xmp)})
+	}
+	for _, comment := range m.comments {
+		m.texts = append(m.texts, textChunk{key: pngCommentKeyword, value: comment})
+	}
+	//все тексты пишуться как iTXt без сжатия, он в utf-8 в отличии от tEXt
+	for _, text := range m.texts {
+		chunk := append([]byte(text.key), 0, 0, 0, 0, 0)
+		writePNGChunk(result, pngChunk{kind: "iTXt", data: append(chunk, text.value...)})
+	}
+	for _, chunk := range chunks[1:] {
+		if !chunk.isMetadata() {
+			writePNGChunk(result, chunk)
+		}
+	}
+	return result.Bytes(), nil
+}
+
+func writePNGChunk(w *bytes.Buffer, chunk pngChunk) {
+	_ = binary.Write(w, binary.BigEndian, uint32(len(chunk.data)))
+	crc := crc32.NewIEEE()
+	_, _ = crc.Write([]byte(chunk.kind))
+	_, _ = crc.Write(chunk.data)
+	w.WriteString(chunk.kind)
+	w.Write(chunk.data)
+	_ = binary.Write(w, binary.BigEndian, crc.Sum32())
+}
+
+func inflate(data []byte) ([]byte, error) {
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	return ioutil.ReadAll(reader)
+}
+
+type exifEntry struct {
+	tag   uint16
+	kind  uint16
+	count uint32
+	value []byte
+}
+
+//записи первого IFD exif и порядок байт
+func exifEntries(tiff []byte) ([]exifEntry, binary.ByteOrder, bool) {
+	order, ok := exifByteOrder(tiff)
+	if !ok {
+		return nil, nil, false
+	}
+	entries, ok := exifIFD(tiff, order, int(order.Uint32(tiff[4:8])))
+	if !ok {
+		return nil, nil, false
+	}
+	return entries, order, true
+}
+
+func exifByteOrder(tiff []byte) (binary.ByteOrder, bool) {
+	if len(tiff) < 8 {
+		return nil, false
+	}
+	switch string(tiff[:2]) {
+	case "II":
+		return binary.LittleEndian, true
+	case "MM":
+		return binary.BigEndian, true
+	default:
+		return nil, false
+	}
+}
+
+//записи IFD который начинаеться с offset. вложенные IFD (gps, exif) читаються этим же методом
+func exifIFD(tiff []byte, order binary.ByteOrder, offset int) ([]exifEntry, bool) {
+	if offset < 8 || offset+2 > len(tiff) {
+		return nil, false
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	entries := make([]exifEntry, 0, count)
+	for i := 0; i < count; i++ {
+		position := offset + 2 + i*12
+		if position+12 > len(tiff) {
+			return nil, false
+		}
+		entry := exifEntry{
+			tag:   order.Uint16(tiff[position:]),
+			kind:  order.Uint16(tiff[position+2:]),
+			count: order.Uint32(tiff[position+4:]),
+		}
+		size := exifTypeSizes[entry.kind] * int(entry.count)
+		if size <= 4 {
+			entry.value = tiff[position+8 : position+8+size]
+		} else {
+			valueOffset := int(order.Uint32(tiff[position+8:]))
+			if valueOffset < 0 || size < 0 || valueOffset+size > len(tiff) {
+				continue
+			}
+			entry.value = tiff[valueOffset : valueOffset+size]
+		}
+		entries = append(entries, entry)
+	}
+	return entries, true
+}
+
+//собирает новый exif только с тегами tags из первого IFD. ссылки на вложенные IFD (gps, exif) не переносяться
+func reduceEXIF(tiff []byte, tags map[uint16]bool) []byte {
+	entries, order, ok := exifEntries(tiff)
+	if !ok {
+		return nil
+	}
+	var kept []exifEntry
+	for _, entry := range entries {
+		if tags[entry.tag] {
+			kept = append(kept, entry)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	sort.Slice(kept, func(i, j int) bool {
+		return kept[i].tag < kept[j].tag
+	})
+
+	header := make([]byte, 8)
+	copy(header, tiff[:2])
+	order.PutUint16(header[2:], 42)
+	order.PutUint32(header[4:], 8)
+	ifd := make([]byte, 2+len(kept)*12+4)
+	order.PutUint16(ifd, uint16(len(kept)))
+	var values []byte
+	valuesOffset := len(header) + len(ifd)
+	for i, entry := range kept {
+		position := 2 + i*12
+		order.PutUint16(ifd[position:], entry.tag)
+		order.PutUint16(ifd[position+2:], entry.kind)
+		order.PutUint32(ifd[position+4:], entry.count)
+		if len(entry.value) <= 4 {
+			copy(ifd[position+8:], entry.value)
+			continue
+		}
+		order.PutUint32(ifd[position+8:], uint32(valuesOffset+len(values)))
+		values = append(values, entry.value...)
+		//значения должны начинаться с четного смещения
+		if len(values)%2 == 1 {
+			values = append(values, 0)
+		}
+	}
+	return append(append(header, ifd...), values...)
+}
+
+//копия exif в которой Orientation равен 1, остальное не меняеться
+func normalizeOrientation(tiff []byte) []byte {
+	if len(tiff) == 0 {
+		return tiff
+	}
+	result := append([]byte{}, tiff...)
+	entries, order, ok := exifEntries(result)
+	if !ok {
+		return nil
+	}
+	for _, entry := range entries {
+		if entry.tag == exifOrientationTag && entry.kind == 3 && len(entry.value) == 2 {
+			//value это срез result, так что меняем прямо в нем
+			order.PutUint16(entry.value, OrientationNormal)
+		}
+	}
+	return result
+}
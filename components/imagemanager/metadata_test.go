@@ -0,0 +1,291 @@
+package imagemanager
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+const testMakeTag = 0x010f
+
+//exif как у фото с телефона: производитель, поворот и копирайт
+func testPhotoEXIF() []byte {
+	order := binary.LittleEndian
+	return testEXIF(order, asciiTag(testMakeTag, "Camera"), shortTag(exifOrientationTag, OrientationRotate90, order), asciiTag(exifCopyrightTag, "Author"))
+}
+
+//теги первого IFD и значение Orientation (0 если его нет)
+func testEXIFTags(t *testing.T, tiff []byte) ([]uint16, int) {
+	if len(tiff) == 0 {
+		return nil, 0
+	}
+	entries, order, ok := exifEntries(tiff)
+	if !ok {
+		t.Fatalf("can not parse exif %q", tiff)
+	}
+	var tags []uint16
+	orientation := 0
+	for _, entry := range entries {
+		tags = append(tags, entry.tag)
+		if entry.tag == exifOrientationTag {
+			orientation = int(order.Uint16(entry.value))
+		}
+	}
+	return tags, orientation
+}
+
+func TestMetadataFilter(t *testing.T) {
+	source := metadata{
+		exif:     testPhotoEXIF(),
+		icc:      []byte("icc"),
+		xmp:      []byte("<x:xmpmeta/>"),
+		iptc:     []byte("iptc"),
+		comments: []string{"comment"},
+		texts:    []textChunk{{key: pngCopyrightKeyword, value: "Author"}, {key: "Software", value: "editor"}},
+	}
+	tests := []struct {
+		name       string
+		policy     string
+		derivative bool
+		tags       []uint16
+		//0 значит тега нет
+		orientation int
+		icc         bool
+		xmp         bool
+		texts       int
+		comments    int
+	}{
+		//в оригинале Orientation остаеться всегда, без него картинка покажеться повернутой
+		{"strip original", MetadataStrip, false, []uint16{exifOrientationTag}, OrientationRotate90, false, false, 0, 0},
+		{"strip derivative", MetadataStrip, true, nil, 0, false, false, 0, 0},
+		{"copyright original", MetadataCopyright, false, []uint16{exifOrientationTag, exifCopyrightTag}, OrientationRotate90, true, false, 1, 0},
+		{"copyright derivative", MetadataCopyright, true, []uint16{exifCopyrightTag}, 0, true, false, 1, 0},
+		{"keep original", MetadataKeep, false, []uint16{testMakeTag, exifOrientationTag, exifCopyrightTag}, OrientationRotate90, true, true, 2, 1},
+		//ресайз уже повернут, поэтому Orientation сбрасываеться в 1
+		{"keep derivative", MetadataKeep, true, []uint16{testMakeTag, exifOrientationTag, exifCopyrightTag}, OrientationNormal, true, true, 2, 1},
+		//неизвестная политика работает как strip
+		{"unknown policy", "all", false, []uint16{exifOrientationTag}, OrientationRotate90, false, false, 0, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := source.filter(test.policy, test.derivative)
+			tags, orientation := testEXIFTags(t, result.exif)
+			if !reflect.DeepEqual(tags, test.tags) || orientation != test.orientation {
+				t.Fatalf("exif tags %x orientation %d, want %x %d", tags, orientation, test.tags, test.orientation)
+			}
+			if (len(result.icc) > 0) != test.icc || (len(result.xmp) > 0) != test.xmp {
+				t.Fatalf("icc %q xmp %q", result.icc, result.xmp)
+			}
+			if len(result.texts) != test.texts || len(result.comments) != test.comments {
+				t.Fatalf("texts %v comments %v", result.texts, result.comments)
+			}
+			//исходные метаданные не меняються
+			if _, orientation := testEXIFTags(t, source.exif); orientation != OrientationRotate90 {
+				t.Fatalf("source orientation is changed to %d", orientation)
+			}
+		})
+	}
+}
+
+func testPNG(t *testing.T, img image.Image) []byte {
+	var encoded bytes.Buffer
+	err := png.Encode(&encoded, img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded.Bytes()
+}
+
+//записанные метаданные читаються обратно без изменений
+func TestMetadataRoundTrip(t *testing.T) {
+	img := newTestImage()
+	tests := []struct {
+		name     string
+		data     []byte
+		metadata metadata
+	}{
+		{"jpeg", testJPEG(t, img, nil), metadata{
+			exif:     testPhotoEXIF(),
+			icc:      []byte("icc"),
+			xmp:      []byte("<x:xmpmeta/>"),
+			iptc:     []byte("iptc"),
+			comments: []string{"first", "second"},
+		}},
+		//в png нет iptc, а комментарии пишуться текстом с ключем Comment
+		{"png", testPNG(t, img), metadata{
+			exif:     testPhotoEXIF(),
+			icc:      []byte("icc"),
+			xmp:      []byte("<x:xmpmeta/>"),
+			comments: []string{"first"},
+			texts:    []textChunk{{key: pngCopyrightKeyword, value: "Автор"}},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			written, err := writeMetadata(test.data, test.metadata)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _, err = image.Decode(bytes.NewReader(written))
+			if err != nil {
+				t.Fatalf("image is broken: %v", err)
+			}
+			result, err := readMetadata(written)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result, test.metadata) {
+				t.Fatalf("readMetadata() = %+v, want %+v", result, test.metadata)
+			}
+
+			//пустые метаданные удаляют все что было
+			written, err = writeMetadata(written, metadata{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, err = readMetadata(written)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result, metadata{}) {
+				t.Fatalf("metadata is not removed: %+v", result)
+			}
+		})
+	}
+}
+
+func TestReadBrokenMetadata(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"truncated jpeg", []byte{0xff, 0xd8, 0xff, 0xe1, 0xff}, ErrBadMetadata},
+		{"jpeg without markers", []byte{0xff, 0xd8, 0x00, 0x00, 0x00, 0x00}, ErrBadMetadata},
+		//метаданные других форматов не читаються и не пишуться
+		{"gif", []byte("GIF89a"), nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := readMetadata(test.data)
+			if err != test.err {
+				t.Fatalf("readMetadata() = %v, want %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestSanitizeOriginal(t *testing.T) {
+	source, err := writeMetadata(testJPEG(t, newTestImage(), nil), metadata{
+		exif:     testPhotoEXIF(),
+		xmp:      []byte("<x:xmpmeta/>"),
+		comments: []string{"comment"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		policy   string
+		tags     []uint16
+		xmp      bool
+		comments int
+	}{
+		{MetadataStrip, []uint16{exifOrientationTag}, false, 0},
+		{MetadataCopyright, []uint16{exifOrientationTag, exifCopyrightTag}, false, 0},
+		{MetadataKeep, []uint16{testMakeTag, exifOrientationTag, exifCopyrightTag}, true, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.policy, func(t *testing.T) {
+			im := newTestManager(t)
+			im.Config.MetadataPolicy = test.policy
+			sanitized, err := im.SanitizeOriginal(source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, err := readMetadata(sanitized)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tags, orientation := testEXIFTags(t, result.exif)
+			if !reflect.DeepEqual(tags, test.tags) || orientation != OrientationRotate90 {
+				t.Fatalf("exif tags %x orientation %d, want %x", tags, orientation, test.tags)
+			}
+			if (len(result.xmp) > 0) != test.xmp || len(result.comments) != test.comments {
+				t.Fatalf("xmp %q comments %v", result.xmp, result.comments)
+			}
+			//пиксели не перекодируються, меняються только сегменты до SOS
+			_, scan, _ := jpegSegments(source)
+			if !bytes.HasSuffix(sanitized, source[scan:]) {
+				t.Fatal("image data is changed")
+			}
+		})
+	}
+
+	im := newTestManager(t)
+	_, err = im.SanitizeOriginal([]byte{0xff, 0xd8, 0x00})
+	if err != ErrBadMetadata {
+		t.Fatalf("SanitizeOriginal() = %v, want %v", err, ErrBadMetadata)
+	}
+}
+
+func TestResizeMetadata(t *testing.T) {
+	source, err := writeMetadata(testJPEG(t, newTestImage(), nil), metadata{
+		exif:     testPhotoEXIF(),
+		comments: []string{"comment"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		policy      string
+		tags        []uint16
+		orientation int
+		comments    int
+	}{
+		{MetadataStrip, nil, 0, 0},
+		{MetadataCopyright, []uint16{exifCopyrightTag}, 0, 0},
+		{MetadataKeep, []uint16{testMakeTag, exifOrientationTag, exifCopyrightTag}, OrientationNormal, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.policy, func(t *testing.T) {
+			im := newTestManager(t)
+			defer im.Clear()
+			im.Config.MetadataPolicy = test.policy
+			file, err := im.SaveFile("a.jpg", source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			thumbFile, err := im.ResizeFile(file, ResizeOptions{Width: 10})
+			if err != nil {
+				t.Fatal(err)
+			}
+			thumb, err := ioutil.ReadFile(thumbFile.Path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, err := readMetadata(thumb)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tags, orientation := testEXIFTags(t, result.exif)
+			if !reflect.DeepEqual(tags, test.tags) || orientation != test.orientation {
+				t.Fatalf("exif tags %x orientation %d, want %x %d", tags, orientation, test.tags, test.orientation)
+			}
+			if len(result.comments) != test.comments {
+				t.Fatalf("comments %v", result.comments)
+			}
+			//картинка повернута по exif исходника
+			config, _, err := image.DecodeConfig(bytes.NewReader(thumb))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if config.Width != 10 || config.Height != 20 {
+				t.Fatalf("thumb %dx%d, want 10x20", config.Width, config.Height)
+			}
+		})
+	}
+}
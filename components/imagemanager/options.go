@@ -0,0 +1,296 @@
+package imagemanager
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//как вписывать картинку если заданы и ширина и высота
+//fit - целиком помещаеться в рамку, пропорции сохраняються
+//fill - заполняет рамку целиком, лишнее обрезаеться по gravity
+//stretch - растягиваеться ровно до рамки, пропорции не сохраняються
+//pad - как fit, но пустое место заливаеться цветом Background и результат ровно размером с рамку
+const ModeFit = "fit"
+const ModeFill = "fill"
+const ModeStretch = "stretch"
+const ModePad = "pad"
+
+//к какому краю прижимать картинку при обрезке в fill и при заливке в pad
+const GravityCenter = "center"
+const GravityNorth = "north"
+const GravitySouth = "south"
+const GravityEast = "east"
+const GravityWest = "west"
+const GravityNorthEast = "northeast"
+const GravityNorthWest = "northwest"
+const GravitySouthEast = "southeast"
+const GravitySouthWest = "southwest"
+
+//белый непрозрачный
+const DefaultBackground = "ffffff"
+
+var ErrInvalidOptions = errors.New("invalid resize options")
+
+var supportedModes = map[string]bool{ModeFit: true, ModeFill: true, ModeStretch: true, ModePad: true}
+var supportedGravities = map[string]bool{
+	GravityCenter: true, GravityNorth: true, GravitySouth: true, GravityEast: true, GravityWest: true,
+	GravityNorthEast: true, GravityNorthWest: true, GravitySouthEast: true, GravitySouthWest: true,
+}
+
+//параметры ресайза. если задана только ширина или только высота, вторая сторона считаеться пропорционально
+//структура сохраняеться вместе с задачей, поэтому у полей есть json теги
+type ResizeOptions struct {
+	Width      uint   `json:"width"`
+	Height     uint   `json:"height,omitempty"`
+	Mode       string `json:"mode,omitempty"`
+	Gravity    string `json:"gravity,omitempty"`
+	Background string `json:"background,omitempty"`
+	//формат результата, пустой значит такой же как у исходника
+	Format string `json:"format,omitempty"`
+	//фильтр ресайза, пустой значит фильтр по умолчанию из конфига
+	Filter string `json:"filter,omitempty"`
+	//настройки кодировщика, нулевые значения значат настройки по умолчанию
+	//каждая применяеться только к своему формату: Quality к jpeg, Compression к png, Colors и NoDither к gif
+	Quality     uint   `json:"quality,omitempty"`
+	Compression string `json:"compression,omitempty"`
+	Colors      uint   `json:"colors,omitempty"`
+	NoDither    bool   `json:"noDither,omitempty"`
+	//из анимированного gif брать только первый кадр
+	FirstFrame bool `json:"firstFrame,omitempty"`
+	//операции до ресайза: обрезка, поворот по часовой стрелке в градусах и отражение
+	Crop   *CropRect `json:"crop,omitempty"`
+	Rotate float64   `json:"rotate,omitempty"`
+	Flip   string    `json:"flip,omitempty"`
+	//операции после ресайза: цветокоррекция в процентах от -100 до 100, гамма (1 без изменений), серый, сепия,
+	//размытие по гауссу с радиусом Blur и резкость
+	Brightness float64      `json:"brightness,omitempty"`
+	Contrast   float64      `json:"contrast,omitempty"`
+	Gamma      float64      `json:"gamma,omitempty"`
+	Saturation float64      `json:"saturation,omitempty"`
+	Grayscale  bool         `json:"grayscale,omitempty"`
+	Sepia      bool         `json:"sepia,omitempty"`
+	Blur       float64      `json:"blur,omitempty"`
+	Sharpen    *UnsharpMask `json:"sharpen,omitempty"`
+	//водяной знак, рисуеться последним поверх всех операций
+	Watermark *Watermark `json:"watermark,omitempty"`
+}
+
+func NewResizeOptions(width uint, height uint, mode string, gravity string, background string, format string) ResizeOptions {
+	return ResizeOptions{
+		Width:      width,
+		Height:     height,
+		Mode:       mode,
+		Gravity:    gravity,
+		Background: background,
+		Format:     format,
+	}
+}
+
+//ресайз только по ширине, как было раньше
+func WidthOptions(width uint) ResizeOptions {
+	return ResizeOptions{Width: width}
+}
+
+func (o ResizeOptions) Validate() error {
+	//без ресайза можно только если есть другие операции, тогда размер остаеться как после них
+	if o.Width == 0 && o.Height == 0 && !o.hasTransform() && !o.HasAdjust() && o.Watermark == nil {
+		return fmt.Errorf("%w: width or height is required", ErrInvalidOptions)
+	}
+	err := o.validateTransform()
+	if err != nil {
+		return err
+	}
+	err = o.validateAdjust()
+	if err != nil {
+		return err
+	}
+	if o.Watermark != nil {
+		err = o.Watermark.validate()
+		if err != nil {
+			return err
+		}
+	}
+	err = o.validateGeometry()
+	if err != nil {
+		return err
+	}
+	return o.validateEncoder()
+}
+
+//параметры самого ресайза
+func (o ResizeOptions) validateGeometry() error {
+	if o.Width > MaxDimension || o.Height > MaxDimension {
+		return fmt.Errorf("%w: width and height must be at most %d", ErrInvalidOptions, MaxDimension)
+	}
+	if uint64(o.Width)*uint64(o.Height) > MaxPixels {
+		return fmt.Errorf("%w: width x height must be at most %d pixels", ErrInvalidOptions, MaxPixels)
+	}
+	if o.Mode != "" && !supportedModes[o.Mode] {
+		return fmt.Errorf("%w: unknown mode %q", ErrInvalidOptions, o.Mode)
+	}
+	if o.mode() != ModeFit && (o.Width == 0 || o.Height == 0) {
+		return fmt.Errorf("%w: mode %s needs both width and height", ErrInvalidOptions, o.Mode)
+	}
+	if o.Gravity != "" && !supportedGravities[o.Gravity] {
+		return fmt.Errorf("%w: unknown gravity %q", ErrInvalidOptions, o.Gravity)
+	}
+	if o.Background != "" {
+		_, err := parseColor(o.Background)
+		if err != nil {
+			return err
+		}
+	}
+	if o.Filter != "" && !IsFilterSupported(o.Filter) {
+		return fmt.Errorf("%w: unknown filter %q", ErrInvalidOptions, o.Filter)
+	}
+	return nil
+}
+
+//формат результата и настройки кодировщика
+func (o ResizeOptions) validateEncoder() error {
+	if o.Format != "" && !IsFormatSupported(o.Format) {
+		return fmt.Errorf("%w: unknown format %q", ErrInvalidOptions, o.Format)
+	}
+	if o.Quality > 100 {
+		return fmt.Errorf("%w: quality must be from 1 to 100", ErrInvalidOptions)
+	}
+	if o.Compression != "" {
+		_, ok := compressionLevels[o.Compression]
+		if !ok {
+			return fmt.Errorf("%w: unknown compression %q", ErrInvalidOptions, o.Compression)
+		}
+	}
+	if o.Colors > 256 {
+		return fmt.Errorf("%w: colors must be from 1 to 256", ErrInvalidOptions)
+	}
+	return nil
+}
+
+func (o ResizeOptions) mode() string {
+	if o.Mode == "" {
+		return ModeFit
+	}
+	return o.Mode
+}
+
+func (o ResizeOptions) gravity() string {
+	if o.Gravity == "" {
+		return GravityCenter
+	}
+	return o.Gravity
+}
+
+func (o ResizeOptions) background() color.Color {
+	if o.Background == "" {
+		o.Background = DefaultBackground
+	}
+	background, err := parseColor(o.Background)
+	if err != nil {
+		return color.White
+	}
+	return background
+}
+
+//часть имени файла с ресайзом. разные параметры дают разные имена, что бы ресайзы не перезаписывали друг друга
+//для ресайза только по ширине имя остаеться прежним, например thumb100.
+//фильтр и настройки кодировщика дописываються в конце, например thumb100_bilinear_q80.
+func (o ResizeOptions) Name() string {
+	name := o.geometryName() + o.transformName()
+	if o.Filter != "" && o.Filter != DefaultFilter {
+		name += "_" + o.Filter
+	}
+	name += o.adjustName()
+	if o.Watermark != nil {
+		name += o.Watermark.name()
+	}
+	return name + o.encoderName()
+}
+
+func (o ResizeOptions) geometryName() string {
+	if o.Height == 0 {
+		return strconv.Itoa(int(o.Width))
+	}
+	name := strconv.Itoa(int(o.Width)) + "x" + strconv.Itoa(int(o.Height))
+	if o.Width == 0 {
+		return name
+	}
+	name += "_" + o.mode()
+	switch o.mode() {
+	case ModeFill:
+		name += "_" + o.gravity()
+	case ModePad:
+		name += "_" + o.gravity() + "_" + o.backgroundName()
+	}
+	return name
+}
+
+func (o ResizeOptions) backgroundName() string {
+	background := o.Background
+	if background == "" {
+		background = DefaultBackground
+	}
+	return strings.ToLower(strings.TrimPrefix(background, "#"))
+}
+
+func (o ResizeOptions) encoderName() string {
+	name := ""
+	if o.Quality != 0 {
+		name += "_q" + strconv.Itoa(int(o.Quality))
+	}
+	if o.Compression != "" {
+		name += "_c" + o.Compression
+	}
+	if o.Colors != 0 {
+		name += "_p" + strconv.Itoa(int(o.Colors))
+	}
+	if o.NoDither {
+		name += "_nodither"
+	}
+	if o.FirstFrame {
+		name += "_still"
+	}
+	return name
+}
+
+//имя файла с ресайзом. если формат меняеться, к исходному имени дописываеться новое расширение,
+//например thumb100.a.png.jpg, иначе ресайзы a.png и a.jpg в jpeg получили бы одно имя
+func (o ResizeOptions) FileName(fileName string) string {
+	return o.thumbFileName(o.Name(), fileName)
+}
+
+//имя файла с ресайзом с частью name вместо Name(). формат и расширение берутся из o
+func (o ResizeOptions) thumbFileName(name string, fileName string) string {
+	thumbFileName := ThumbPrefix + name + "." + fileName
+	if o.Format != "" && FormatByExtension(filepath.Ext(fileName)) != o.Format {
+		thumbFileName += formatExtension[o.Format]
+	}
+	return thumbFileName
+}
+
+//цвет в виде rgb, rrggbb или rrggbbaa, можно с # в начале
+func parseColor(value string) (color.NRGBA, error) {
+	hex := strings.TrimPrefix(value, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return color.NRGBA{}, fmt.Errorf("%w: bad color %q", ErrInvalidOptions, value)
+	}
+	rgba, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, fmt.Errorf("%w: bad color %q", ErrInvalidOptions, value)
+	}
+	return color.NRGBA{
+		R: uint8(rgba >> 24),
+		G: uint8(rgba >> 16),
+		B: uint8(rgba >> 8),
+		A: uint8(rgba),
+	}, nil
+}
@@ -0,0 +1,84 @@
+package imagemanager
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+)
+
+func TestValidateGeometry(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ResizeOptions
+		valid   bool
+	}{
+		{"width only", ResizeOptions{Width: 100}, true},
+		{"height only", ResizeOptions{Height: 100}, true},
+		{"fill", ResizeOptions{Width: 100, Height: 50, Mode: ModeFill, Gravity: GravityNorthEast}, true},
+		{"pad with background", ResizeOptions{Width: 100, Height: 50, Mode: ModePad, Background: "#ff000080"}, true},
+		{"nothing to do", ResizeOptions{}, false},
+		{"unknown mode", ResizeOptions{Width: 100, Height: 50, Mode: "zoom"}, false},
+		{"fill without height", ResizeOptions{Width: 100, Mode: ModeFill}, false},
+		{"unknown gravity", ResizeOptions{Width: 100, Height: 50, Mode: ModeFill, Gravity: "up"}, false},
+		{"bad background", ResizeOptions{Width: 100, Height: 50, Mode: ModePad, Background: "red"}, false},
+		{"too wide", ResizeOptions{Width: MaxDimension + 1}, false},
+		{"too many pixels", ResizeOptions{Width: MaxDimension, Height: MaxDimension}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.options.Validate()
+			if (err == nil) != test.valid {
+				t.Fatalf("Validate() = %v, valid %v", err, test.valid)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidOptions) {
+				t.Fatalf("error %v is not %v", err, ErrInvalidOptions)
+			}
+		})
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  ResizeOptions
+		fileName string
+	}{
+		//ресайз только по ширине называеться как раньше
+		{"width only", ResizeOptions{Width: 100}, "thumb100.a.png"},
+		{"height only", ResizeOptions{Height: 50}, "thumb0x50.a.png"},
+		{"fit", ResizeOptions{Width: 100, Height: 50}, "thumb100x50_fit.a.png"},
+		{"fill", ResizeOptions{Width: 100, Height: 50, Mode: ModeFill}, "thumb100x50_fill_center.a.png"},
+		{"pad", ResizeOptions{Width: 100, Height: 50, Mode: ModePad, Background: "#FF0000"}, "thumb100x50_pad_center_ff0000.a.png"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fileName := test.options.FileName("a.png")
+			if fileName != test.fileName {
+				t.Fatalf("FileName() = %s, want %s", fileName, test.fileName)
+			}
+		})
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		value string
+		color color.NRGBA
+		valid bool
+	}{
+		{"f00", color.NRGBA{R: 255, A: 255}, true},
+		{"#00ff00", color.NRGBA{G: 255, A: 255}, true},
+		{"0000ff80", color.NRGBA{B: 255, A: 128}, true},
+		{"red", color.NRGBA{}, false},
+		{"12345", color.NRGBA{}, false},
+		{"gggggg", color.NRGBA{}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			result, err := parseColor(test.value)
+			if (err == nil) != test.valid || result != test.color {
+				t.Fatalf("parseColor() = %v %v, want %v", result, err, test.color)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package imagemanager
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"image"
+	"io"
+)
+
+//значения exif тега Orientation. 1 значит картинка уже повернута правильно
+//2-8 это отражения и повороты которые камера записала вместо того что бы поворачивать пиксели
+const OrientationNormal = 1
+const OrientationFlipHorizontal = 2
+const OrientationRotate180 = 3
+const OrientationFlipVertical = 4
+const OrientationTranspose = 5
+const OrientationRotate90 = 6
+const OrientationTransverse = 7
+const OrientationRotate270 = 8
+
+const exifOrientationTag = 0x0112
+
+//читает тег Orientation из exif в jpeg. если exif нет или он битый возвращает OrientationNormal
+//читаються только маркеры до начала данных картинки, сами пиксели не читаються
+func ReadOrientation(r io.Reader) int {
+	br := bufio.NewReader(r)
+	var marker [2]byte
+	_, err := io.ReadFull(br, marker[:])
+	if err != nil || marker[0] != 0xff || marker[1] != 0xd8 {
+		return OrientationNormal
+	}
+	for {
+		_, err = io.ReadFull(br, marker[:])
+		if err != nil || marker[0] != 0xff {
+			return OrientationNormal
+		}
+		//после SOS идут данные картинки, exif должен быть раньше
+		if marker[1] == 0xda || marker[1] == 0xd9 {
+			return OrientationNormal
+		}
+		var length [2]byte
+		_, err = io.ReadFull(br, length[:])
+		if err != nil {
+			return OrientationNormal
+		}
+		size := int(binary.BigEndian.Uint16(length[:])) - 2
+		if size < 0 {
+			return OrientationNormal
+		}
+		segment := make([]byte, size)
+		_, err = io.ReadFull(br, segment)
+		if err != nil {
+			return OrientationNormal
+		}
+		//APP1 с exif
+		if marker[1] == 0xe1 && bytes.HasPrefix(segment, jpegExifHeader) {
+			return parseOrientation(segment[len(jpegExifHeader):])
+		}
+	}
+}
+
+//ищет тег Orientation в первом IFD tiff заголовка
+func parseOrientation(tiff []byte) int {
+	entries, order, ok := exifEntries(tiff)
+	if !ok {
+		return OrientationNormal
+	}
+	for _, entry := range entries {
+		//тип SHORT, значение лежит прямо в записи
+		if entry.tag != exifOrientationTag || len(entry.value) != 2 {
+			continue
+		}
+		orientation := int(order.Uint16(entry.value))
+		if orientation < OrientationNormal || orientation > OrientationRotate270 {
+			return OrientationNormal
+		}
+		return orientation
+	}
+	return OrientationNormal
+}
+
+//поворачивает и отражает картинку так как она должна выглядеть по тегу Orientation
+func Orient(src image.Image, orientation int) image.Image {
+	if orientation <= OrientationNormal || orientation > OrientationRotate270 {
+		return src
+	}
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= OrientationTranspose {
+		dstWidth, dstHeight = height, width
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		for x := 0; x < dstWidth; x++ {
+			//для каждой точки результата ищем откуда она берется в исходнике
+			var sx, sy int
+			switch orientation {
+			case OrientationFlipHorizontal:
+				sx, sy = width-1-x, y
+			case OrientationRotate180:
+				sx, sy = width-1-x, height-1-y
+			case OrientationFlipVertical:
+				sx, sy = x, height-1-y
+			case OrientationTranspose:
+				sx, sy = y, x
+			case OrientationRotate90:
+				sx, sy = y, height-1-x
+			case OrientationTransverse:
+				sx, sy = width-1-y, height-1-x
+			case OrientationRotate270:
+				sx, sy = width-1-y, x
+			}
+			dst.Set(x, y, src.At(bounds.Min.X+sx, bounds.Min.Y+sy))
+		}
+	}
+	return dst
+}
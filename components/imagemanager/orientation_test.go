@@ -0,0 +1,197 @@
+package imagemanager
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"strconv"
+	"testing"
+)
+
+//тег exif для тестов. значения длиннее 4 байт кладуться после IFD, как у настоящих файлов
+//если задан ifd, тег ссылается на вложенный IFD (gps, exif)
+type testTag struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	value []byte
+	ifd   []testTag
+}
+
+func shortTag(tag uint16, value uint16, order binary.ByteOrder) testTag {
+	data := make([]byte, 2)
+	order.PutUint16(data, value)
+	return testTag{tag: tag, typ: 3, count: 1, value: data}
+}
+
+func asciiTag(tag uint16, value string) testTag {
+	return testTag{tag: tag, typ: 2, count: uint32(len(value) + 1), value: append([]byte(value), 0)}
+}
+
+//дроби RATIONAL, каждая числитель и знаменатель
+func rationalTag(tag uint16, order binary.ByteOrder, values ...[2]uint32) testTag {
+	data := make([]byte, 8*len(values))
+	for i, value := range values {
+		order.PutUint32(data[i*8:], value[0])
+		order.PutUint32(data[i*8+4:], value[1])
+	}
+	return testTag{tag: tag, typ: 5, count: uint32(len(values)), value: data}
+}
+
+func ifdTag(tag uint16, tags ...testTag) testTag {
+	return testTag{tag: tag, typ: 4, count: 1, ifd: tags}
+}
+
+//tiff заголовок с одним IFD
+func testEXIF(order binary.ByteOrder, tags ...testTag) []byte {
+	var tiff bytes.Buffer
+	if order == binary.LittleEndian {
+		tiff.WriteString("II")
+	} else {
+		tiff.WriteString("MM")
+	}
+	header := make([]byte, 6)
+	order.PutUint16(header, 42)
+	order.PutUint32(header[2:], 8)
+	tiff.Write(header)
+	tiff.Write(testIFD(order, tags, 8))
+	return tiff.Bytes()
+}
+
+//IFD и значения его тегов, offset это смещение IFD от начала tiff
+func testIFD(order binary.ByteOrder, tags []testTag, offset int) []byte {
+	ifdSize := 2 + 12*len(tags) + 4
+	dataOffset := offset + ifdSize
+	var data bytes.Buffer
+	ifd := make([]byte, ifdSize)
+	order.PutUint16(ifd, uint16(len(tags)))
+	for i, tag := range tags {
+		entry := ifd[2+12*i:]
+		order.PutUint16(entry, tag.tag)
+		order.PutUint16(entry[2:], tag.typ)
+		order.PutUint32(entry[4:], tag.count)
+		if tag.ifd != nil {
+			order.PutUint32(entry[8:], uint32(dataOffset+data.Len()))
+			data.Write(testIFD(order, tag.ifd, dataOffset+data.Len()))
+			continue
+		}
+		if len(tag.value) <= 4 {
+			copy(entry[8:12], tag.value)
+			continue
+		}
+		order.PutUint32(entry[8:], uint32(dataOffset+data.Len()))
+		data.Write(tag.value)
+	}
+	return append(ifd, data.Bytes()...)
+}
+
+//jpeg с exif сразу после SOI
+func testJPEG(t *testing.T, img image.Image, exif []byte) []byte {
+	var encoded bytes.Buffer
+	err := jpeg.Encode(&encoded, img, &jpeg.Options{Quality: 95})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exif == nil {
+		return encoded.Bytes()
+	}
+	var result bytes.Buffer
+	result.Write(encoded.Bytes()[:2])
+	segment := append(append([]byte{}, jpegExifHeader...), exif...)
+	writeJPEGSegment(&result, 0xe1, segment)
+	result.Write(encoded.Bytes()[2:])
+	return result.Bytes()
+}
+
+func TestReadOrientation(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 4))
+	tests := []struct {
+		name        string
+		data        []byte
+		orientation int
+	}{
+		{"rotate 90 little endian", testJPEG(t, img, testEXIF(binary.LittleEndian, shortTag(exifOrientationTag, 6, binary.LittleEndian))), OrientationRotate90},
+		{"rotate 270 big endian", testJPEG(t, img, testEXIF(binary.BigEndian, shortTag(exifOrientationTag, 8, binary.BigEndian))), OrientationRotate270},
+		{"after other tags", testJPEG(t, img, testEXIF(binary.LittleEndian, asciiTag(0x010f, "Camera"), shortTag(exifOrientationTag, 3, binary.LittleEndian))), OrientationRotate180},
+		{"without orientation", testJPEG(t, img, testEXIF(binary.LittleEndian, asciiTag(0x010f, "Camera"))), OrientationNormal},
+		{"unknown value", testJPEG(t, img, testEXIF(binary.LittleEndian, shortTag(exifOrientationTag, 9, binary.LittleEndian))), OrientationNormal},
+		{"without exif", testJPEG(t, img, nil), OrientationNormal},
+		{"broken exif", testJPEG(t, img, []byte("XX*\x00")), OrientationNormal},
+		{"not a jpeg", []byte("\x89PNG\r\n\x1a\n"), OrientationNormal},
+		{"empty", nil, OrientationNormal},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			orientation := ReadOrientation(bytes.NewReader(test.data))
+			if orientation != test.orientation {
+				t.Fatalf("ReadOrientation() = %d, want %d", orientation, test.orientation)
+			}
+		})
+	}
+}
+
+func TestOrient(t *testing.T) {
+	//картинка 3x2, красная точка в левом верхнем углу
+	src := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	src.Set(0, 0, testRed)
+	tests := []struct {
+		orientation int
+		width       int
+		height      int
+		//где красная точка оказалась после поворота
+		red image.Point
+	}{
+		{OrientationNormal, 3, 2, image.Point{X: 0, Y: 0}},
+		{OrientationFlipHorizontal, 3, 2, image.Point{X: 2, Y: 0}},
+		{OrientationRotate180, 3, 2, image.Point{X: 2, Y: 1}},
+		{OrientationFlipVertical, 3, 2, image.Point{X: 0, Y: 1}},
+		{OrientationTranspose, 2, 3, image.Point{X: 0, Y: 0}},
+		{OrientationRotate90, 2, 3, image.Point{X: 1, Y: 0}},
+		{OrientationTransverse, 2, 3, image.Point{X: 1, Y: 2}},
+		{OrientationRotate270, 2, 3, image.Point{X: 0, Y: 2}},
+	}
+	for _, test := range tests {
+		t.Run(strconv.Itoa(test.orientation), func(t *testing.T) {
+			result := Orient(src, test.orientation)
+			bounds := result.Bounds()
+			if bounds.Dx() != test.width || bounds.Dy() != test.height {
+				t.Fatalf("size %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), test.width, test.height)
+			}
+			for y := 0; y < test.height; y++ {
+				for x := 0; x < test.width; x++ {
+					red := sameColor(result.At(x, y), testRed)
+					if red != (image.Point{X: x, Y: y} == test.red) {
+						t.Fatalf("pixel %d,%d is %v, red point must be at %v", x, y, result.At(x, y), test.red)
+					}
+				}
+			}
+		})
+	}
+}
+
+//DecodeFile поворачивает jpeg по exif, ресайз делаеться уже с повернутой картинки
+func TestDecodeOrientedJPEG(t *testing.T) {
+	im := newTestManager(t)
+	defer im.Clear()
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for x := 0; x < 40; x++ {
+		for y := 0; y < 20; y++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	data := testJPEG(t, img, testEXIF(binary.BigEndian, shortTag(exifOrientationTag, OrientationRotate90, binary.BigEndian)))
+	file, err := im.SaveFile("a.jpg", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := im.DecodeFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Bounds().Dx() != 20 || decoded.Bounds().Dy() != 40 {
+		t.Fatalf("decoded %v, want 20x40", decoded.Bounds())
+	}
+}
@@ -0,0 +1,433 @@
+package imagemanager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"math"
+	"strconv"
+	"strings"
+)
+
+//операции конвеера
+//crop - x, y, width, height
+//rotate - angle по часовой стрелке и background для углов
+//flip - direction: horizontal, vertical или both
+//resize - width, height, mode, gravity, background, filter как у обычного ресайза
+//adjust - brightness, contrast, gamma, saturation, grayscale, sepia
+//blur - sigma
+//sharpen - sigma, amount, threshold
+//watermark - image или text, color, gravity, scale, opacity, margin, tile как у водяного знака
+//encode - format, quality, compression, colors, noDither, firstFrame. может быть только последней
+const OpCrop = "crop"
+const OpRotate = "rotate"
+const OpFlip = "flip"
+const OpResize = "resize"
+const OpAdjust = "adjust"
+const OpBlur = "blur"
+const OpSharpen = "sharpen"
+const OpWatermark = "watermark"
+const OpEncode = "encode"
+
+//ограничение что бы одна задача не могла занять воркер надолго
+const MaxPipelineLength = 16
+
+//одна операция конвеера. поля общие для всех операций, каждая использует только свои
+type Operation struct {
+	Op          string  `json:"op"`
+	X           uint    `json:"x,omitempty"`
+	Y           uint    `json:"y,omitempty"`
+	Width       uint    `json:"width,omitempty"`
+	Height      uint    `json:"height,omitempty"`
+	Mode        string  `json:"mode,omitempty"`
+	Gravity     string  `json:"gravity,omitempty"`
+	Background  string  `json:"background,omitempty"`
+	Filter      string  `json:"filter,omitempty"`
+	Angle       float64 `json:"angle,omitempty"`
+	Direction   string  `json:"direction,omitempty"`
+	Format      string  `json:"format,omitempty"`
+	Quality     uint    `json:"quality,omitempty"`
+	Compression string  `json:"compression,omitempty"`
+	Colors      uint    `json:"colors,omitempty"`
+	NoDither    bool    `json:"noDither,omitempty"`
+	FirstFrame  bool    `json:"firstFrame,omitempty"`
+	Brightness  float64 `json:"brightness,omitempty"`
+	Contrast    float64 `json:"contrast,omitempty"`
+	Gamma       float64 `json:"gamma,omitempty"`
+	Saturation  float64 `json:"saturation,omitempty"`
+	Grayscale   bool    `json:"grayscale,omitempty"`
+	Sepia       bool    `json:"sepia,omitempty"`
+	Sigma       float64 `json:"sigma,omitempty"`
+	Amount      float64 `json:"amount,omitempty"`
+	Threshold   uint8   `json:"threshold,omitempty"`
+	Image       string  `json:"image,omitempty"`
+	Text        string  `json:"text,omitempty"`
+	Color       string  `json:"color,omitempty"`
+	Scale       float64 `json:"scale,omitempty"`
+	Opacity     float64 `json:"opacity,omitempty"`
+	Margin      uint    `json:"margin,omitempty"`
+	Tile        bool    `json:"tile,omitempty"`
+}
+
+//операции выполняються по порядку над декодированной картинкой, в конце результат кодируеться
+//если encode нет, формат остаеться как у исходника
+type Pipeline []Operation
+
+//операция в виде ResizeOptions, так для каждой используеться тот же код что и для обычного ресайза
+//поля которые операция не использует не переносяться
+func (op Operation) options() ResizeOptions {
+	switch op.Op {
+	case OpCrop:
+		return ResizeOptions{Crop: &CropRect{X: op.X, Y: op.Y, Width: op.Width, Height: op.Height}}
+	case OpRotate:
+		return ResizeOptions{Rotate: op.Angle, Background: op.Background}
+	case OpFlip:
+		return ResizeOptions{Flip: op.Direction}
+	case OpResize:
+		return ResizeOptions{
+			Width:      op.Width,
+			Height:     op.Height,
+			Mode:       op.Mode,
+			Gravity:    op.Gravity,
+			Background: op.Background,
+			Filter:     op.Filter,
+		}
+	case OpAdjust:
+		return ResizeOptions{
+			Brightness: op.Brightness,
+			Contrast:   op.Contrast,
+			Gamma:      op.Gamma,
+			Saturation: op.Saturation,
+			Grayscale:  op.Grayscale,
+			Sepia:      op.Sepia,
+		}
+	case OpBlur:
+		return ResizeOptions{Blur: op.Sigma}
+	case OpSharpen:
+		return ResizeOptions{Sharpen: &UnsharpMask{Sigma: op.Sigma, Amount: op.Amount, Threshold: op.Threshold}}
+	case OpWatermark:
+		return ResizeOptions{Watermark: &Watermark{
+			Image:   op.Image,
+			Text:    op.Text,
+			Color:   op.Color,
+			Gravity: op.Gravity,
+			Scale:   op.Scale,
+			Opacity: op.Opacity,
+			Margin:  op.Margin,
+			Tile:    op.Tile,
+		}}
+	case OpEncode:
+		return ResizeOptions{
+			Format:      op.Format,
+			Quality:     op.Quality,
+			Compression: op.Compression,
+			Colors:      op.Colors,
+			NoDither:    op.NoDither,
+			FirstFrame:  op.FirstFrame,
+		}
+	default:
+		return ResizeOptions{}
+	}
+}
+
+//операция только с теми полями которые она использует
+func (op Operation) own() Operation {
+	result := Operation{Op: op.Op}
+	switch op.Op {
+	case OpCrop:
+		result.X, result.Y, result.Width, result.Height = op.X, op.Y, op.Width, op.Height
+	case OpRotate:
+		result.Angle, result.Background = op.Angle, op.Background
+	case OpFlip:
+		result.Direction = op.Direction
+	case OpResize:
+		result.Width, result.Height, result.Mode, result.Gravity = op.Width, op.Height, op.Mode, op.Gravity
+		result.Background, result.Filter = op.Background, op.Filter
+	case OpAdjust:
+		result.Brightness, result.Contrast, result.Gamma = op.Brightness, op.Contrast, op.Gamma
+		result.Saturation, result.Grayscale, result.Sepia = op.Saturation, op.Grayscale, op.Sepia
+	case OpBlur:
+		result.Sigma = op.Sigma
+	case OpSharpen:
+		result.Sigma, result.Amount, result.Threshold = op.Sigma, op.Amount, op.Threshold
+	case OpWatermark:
+		result.Image, result.Text, result.Color, result.Gravity = op.Image, op.Text, op.Color, op.Gravity
+		result.Scale, result.Opacity, result.Margin, result.Tile = op.Scale, op.Opacity, op.Margin, op.Tile
+	case OpEncode:
+		result.Format, result.Quality, result.Compression = op.Format, op.Quality, op.Compression
+		result.Colors, result.NoDither, result.FirstFrame = op.Colors, op.NoDither, op.FirstFrame
+	}
+	return result
+}
+
+//проверяет весь конвеер до того как задача попадет в очередь
+func (p Pipeline) Validate() error {
+	if len(p) == 0 {
+		return fmt.Errorf("%w: pipeline is empty", ErrInvalidOptions)
+	}
+	if len(p) > MaxPipelineLength {
+		return fmt.Errorf("%w: pipeline is longer than %d operations", ErrInvalidOptions, MaxPipelineLength)
+	}
+	for i, op := range p {
+		options := op.options()
+		var err error
+		switch op.Op {
+		case OpCrop:
+			err = options.validateTransform()
+		case OpRotate:
+			err = options.validateTransform()
+			if err == nil && options.Background != "" {
+				_, err = parseColor(options.Background)
+			}
+		case OpFlip:
+			if op.Direction == "" {
+				err = fmt.Errorf("%w: flip direction is required", ErrInvalidOptions)
+			} else {
+				err = options.validateTransform()
+			}
+		case OpResize:
+			if op.Width == 0 && op.Height == 0 {
+				err = fmt.Errorf("%w: width or height is required", ErrInvalidOptions)
+			} else {
+				err = options.validateGeometry()
+			}
+		case OpAdjust:
+			if !options.HasAdjust() {
+				err = fmt.Errorf("%w: adjust has nothing to change", ErrInvalidOptions)
+			} else {
+				err = options.validateAdjust()
+			}
+		case OpBlur:
+			if op.Sigma <= 0 {
+				err = fmt.Errorf("%w: blur sigma is required", ErrInvalidOptions)
+			} else {
+				err = options.validateAdjust()
+			}
+		case OpSharpen:
+			err = options.validateAdjust()
+		case OpWatermark:
+			err = options.Watermark.validate()
+		case OpEncode:
+			if i != len(p)-1 {
+				err = fmt.Errorf("%w: encode must be the last operation", ErrInvalidOptions)
+			} else {
+				err = options.validateEncoder()
+			}
+		default:
+			err = fmt.Errorf("%w: unknown operation %q", ErrInvalidOptions, op.Op)
+		}
+		if err == nil && op != op.own() {
+			err = fmt.Errorf("%w: %s has parameters of another operation", ErrInvalidOptions, op.Op)
+		}
+		if err != nil {
+			return fmt.Errorf("operation %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+//настройки кодировщика из последней операции encode
+func (p Pipeline) encoder() ResizeOptions {
+	if len(p) != 0 && p[len(p)-1].Op == OpEncode {
+		return p[len(p)-1].options()
+	}
+	return ResizeOptions{}
+}
+
+//id картинок водяных знаков которые нужны конвееру
+func (p Pipeline) WatermarkImages() []string {
+	var images []string
+	for _, op := range p {
+		if op.Op == OpWatermark && op.Image != "" {
+			images = append(images, op.Image)
+		}
+	}
+	return images
+}
+
+//выполняет все операции кроме encode. картинки водяных знаков берутся из watermarks
+func (p Pipeline) process(src image.Image, watermarks map[string]image.Image) (image.Image, error) {
+	var err error
+	for _, op := range p {
+		options := op.options()
+		switch op.Op {
+		case OpCrop, OpRotate, OpFlip:
+			src, err = options.transform(src)
+			if err != nil {
+				return nil, err
+			}
+			//поворот увеличивает картинку, несколько поворотов подряд могут раздуть ее как угодно
+			err = checkPixels(src.Bounds().Dx(), src.Bounds().Dy())
+			if err != nil {
+				return nil, err
+			}
+		case OpResize:
+			err = options.checkSize(src.Bounds())
+			if err != nil {
+				return nil, err
+			}
+			src = options.apply(src)
+		case OpAdjust, OpBlur, OpSharpen:
+			src = options.adjust(src)
+		case OpWatermark:
+			src, err = options.Watermark.apply(src, watermarks)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return src, nil
+}
+
+//конвеер в каноническом виде, например crop(x=0,y=0,width=10,height=10)|resize(width=200,filter=lanczos3)|encode(format=jpeg,quality=80)
+//одинаковые по смыслу конвееры дают одну и ту же строку, по ней ищуться уже сделанные результаты
+//значения по умолчанию и параметры которые ни на что не влияют в строку не попадают
+func (p Pipeline) Canonical() string {
+	parts := make([]string, 0, len(p))
+	for _, op := range p {
+		options := op.options()
+		var params []string
+		add := func(key string, value string) {
+			params = append(params, key+"="+value)
+		}
+		switch op.Op {
+		case OpCrop:
+			add("x", strconv.Itoa(int(op.X)))
+			add("y", strconv.Itoa(int(op.Y)))
+			add("width", strconv.Itoa(int(op.Width)))
+			add("height", strconv.Itoa(int(op.Height)))
+		case OpRotate:
+			angle := options.rotation()
+			add("angle", strconv.FormatFloat(angle, 'f', -1, 64))
+			if math.Mod(angle, 90) != 0 {
+				add("background", options.backgroundName())
+			}
+		case OpFlip:
+			add("direction", op.Direction)
+		case OpResize:
+			if op.Width != 0 {
+				add("width", strconv.Itoa(int(op.Width)))
+			}
+			if op.Height != 0 {
+				add("height", strconv.Itoa(int(op.Height)))
+			}
+			if op.Width != 0 && op.Height != 0 {
+				if options.mode() != ModeFit {
+					add("mode", options.mode())
+				}
+				if options.mode() == ModeFill || options.mode() == ModePad {
+					add("gravity", options.gravity())
+				}
+				if options.mode() == ModePad {
+					add("background", options.backgroundName())
+				}
+			}
+			if op.Filter != "" {
+				add("filter", op.Filter)
+			}
+		case OpAdjust:
+			number := func(value float64) string {
+				return strconv.FormatFloat(value, 'f', -1, 64)
+			}
+			if op.Brightness != 0 {
+				add("brightness", number(op.Brightness))
+			}
+			if op.Contrast != 0 {
+				add("contrast", number(op.Contrast))
+			}
+			if op.Gamma != 0 && op.Gamma != 1 {
+				add("gamma", number(op.Gamma))
+			}
+			if op.Saturation != 0 {
+				add("saturation", number(op.Saturation))
+			}
+			if op.Grayscale {
+				add("grayscale", "true")
+			}
+			if op.Sepia {
+				add("sepia", "true")
+			}
+		case OpBlur:
+			add("sigma", strconv.FormatFloat(op.Sigma, 'f', -1, 64))
+		case OpSharpen:
+			add("sigma", strconv.FormatFloat(op.Sigma, 'f', -1, 64))
+			add("amount", strconv.FormatFloat(op.Amount, 'f', -1, 64))
+			if op.Threshold != 0 {
+				add("threshold", strconv.Itoa(int(op.Threshold)))
+			}
+		case OpWatermark:
+			//текст может содержать запятые и скобки, поэтому в кавычках
+			watermark := options.Watermark.normalized()
+			if watermark.Image != "" {
+				add("image", watermark.Image)
+			} else {
+				add("text", strconv.Quote(watermark.Text))
+				add("color", strings.ToLower(strings.TrimPrefix(watermark.Color, "#")))
+			}
+			if watermark.Tile {
+				add("tile", "true")
+			} else {
+				add("gravity", watermark.Gravity)
+			}
+			add("scale", strconv.FormatFloat(watermark.Scale, 'f', -1, 64))
+			add("opacity", strconv.FormatFloat(watermark.Opacity, 'f', -1, 64))
+			if watermark.Margin != 0 {
+				add("margin", strconv.Itoa(int(watermark.Margin)))
+			}
+		case OpEncode:
+			if op.Format != "" {
+				add("format", op.Format)
+			}
+			if op.Quality != 0 {
+				add("quality", strconv.Itoa(int(op.Quality)))
+			}
+			if op.Compression != "" && op.Compression != CompressionDefault {
+				add("compression", op.Compression)
+			}
+			if op.Colors != 0 {
+				add("colors", strconv.Itoa(int(op.Colors)))
+			}
+			if op.NoDither {
+				add("dither", "false")
+			}
+			if op.FirstFrame {
+				add("firstFrame", "true")
+			}
+		}
+		parts = append(parts, op.Op+"("+strings.Join(params, ",")+")")
+	}
+	return strings.Join(parts, "|")
+}
+
+//короткий хеш канонического вида, используеться в имени файла
+func (p Pipeline) Hash() string {
+	sum := sha256.Sum256([]byte(p.Canonical()))
+	return hex.EncodeToString(sum[:8])
+}
+
+//подставляет фильтр по умолчанию в ресайзы конвеера, так он попадает в канонический вид
+func (im *ImageManager) PipelineWithDefaults(pipeline Pipeline) Pipeline {
+	result := make(Pipeline, len(pipeline))
+	copy(result, pipeline)
+	for i := range result {
+		if result[i].Op == OpResize && result[i].Filter == "" {
+			result[i].Filter = im.Config.DefaultFilter
+		}
+	}
+	return result
+}
+
+//выполняет конвеер над уже декодированной картинкой. имя результата thumbp<хеш>.<имя исходника>
+func (im *ImageManager) RunPipeline(decodedImage image.Image, file *File, pipeline Pipeline) (*File, error) {
+	pipeline = im.PipelineWithDefaults(pipeline)
+	err := pipeline.Validate()
+	if err != nil {
+		return nil, err
+	}
+	encoder := pipeline.encoder()
+	process := func(src image.Image) (image.Image, error) {
+		return pipeline.process(src, im.watermarks)
+	}
+	return im.render(decodedImage, file, encoder.thumbFileName("p"+pipeline.Hash(), file.Name), process, encoder)
+}
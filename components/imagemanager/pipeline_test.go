@@ -0,0 +1,161 @@
+package imagemanager
+
+import (
+	"errors"
+	"image"
+	"os"
+	"testing"
+)
+
+func TestPipelineValidate(t *testing.T) {
+	long := make(Pipeline, MaxPipelineLength+1)
+	for i := range long {
+		long[i] = Operation{Op: OpFlip, Direction: FlipVertical}
+	}
+	tests := []struct {
+		name     string
+		pipeline Pipeline
+		valid    bool
+	}{
+		{"all operations", Pipeline{
+			{Op: OpCrop, Width: 10, Height: 10},
+			{Op: OpRotate, Angle: 30, Background: "fff"},
+			{Op: OpFlip, Direction: FlipBoth},
+			{Op: OpResize, Width: 100, Height: 50, Mode: ModeFill, Gravity: GravityNorth},
+			{Op: OpAdjust, Contrast: 0.2, Grayscale: true},
+			{Op: OpBlur, Sigma: 1},
+			{Op: OpSharpen, Sigma: 1, Amount: 1},
+			{Op: OpWatermark, Text: "text"},
+			{Op: OpEncode, Format: FormatJPEG, Quality: 80},
+		}, true},
+		{"same operation twice", Pipeline{{Op: OpResize, Width: 100}, {Op: OpResize, Width: 50}}, true},
+		{"empty", Pipeline{}, false},
+		{"too long", long, false},
+		{"unknown operation", Pipeline{{Op: "zoom"}}, false},
+		{"encode not last", Pipeline{{Op: OpEncode, Format: FormatPNG}, {Op: OpResize, Width: 100}}, false},
+		{"resize without size", Pipeline{{Op: OpResize, Mode: ModeFill}}, false},
+		{"flip without direction", Pipeline{{Op: OpFlip}}, false},
+		{"empty crop", Pipeline{{Op: OpCrop, X: 10}}, false},
+		{"bad rotate background", Pipeline{{Op: OpRotate, Angle: 30, Background: "white"}}, false},
+		{"adjust without changes", Pipeline{{Op: OpAdjust}}, false},
+		{"blur without sigma", Pipeline{{Op: OpBlur}}, false},
+		{"unknown format", Pipeline{{Op: OpEncode, Format: "webp"}}, false},
+		//параметр другой операции скорее всего ошибка в запросе, молча его не игнорируем
+		{"parameter of another operation", Pipeline{{Op: OpFlip, Direction: FlipVertical, Width: 100}}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.pipeline.Validate()
+			if (err == nil) != test.valid {
+				t.Fatalf("Validate() = %v, valid %v", err, test.valid)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidOptions) {
+				t.Fatalf("error %v is not %v", err, ErrInvalidOptions)
+			}
+		})
+	}
+}
+
+func TestPipelineCanonical(t *testing.T) {
+	tests := []struct {
+		name      string
+		pipeline  Pipeline
+		canonical string
+	}{
+		{"crop and resize", Pipeline{{Op: OpCrop, Width: 10, Height: 20}, {Op: OpResize, Width: 200, Filter: FilterLanczos3}},
+			"crop(x=0,y=0,width=10,height=20)|resize(width=200,filter=lanczos3)"},
+		//одинаковые по смыслу операции дают одну строку
+		{"negative angle", Pipeline{{Op: OpRotate, Angle: -270}}, "rotate(angle=90)"},
+		{"right angle ignores background", Pipeline{{Op: OpRotate, Angle: 90, Background: "000"}}, "rotate(angle=90)"},
+		{"other angle with default background", Pipeline{{Op: OpRotate, Angle: 45}}, "rotate(angle=45,background=ffffff)"},
+		{"fit is default", Pipeline{{Op: OpResize, Width: 10, Height: 10, Mode: ModeFit}}, "resize(width=10,height=10)"},
+		{"fill with default gravity", Pipeline{{Op: OpResize, Width: 10, Height: 10, Mode: ModeFill}}, "resize(width=10,height=10,mode=fill,gravity=center)"},
+		//без второй стороны режим ни на что не влияет
+		{"mode without height", Pipeline{{Op: OpResize, Width: 10, Mode: ModeFill, Gravity: GravityNorth}}, "resize(width=10)"},
+		{"adjust defaults", Pipeline{{Op: OpAdjust, Gamma: 1, Sepia: true}}, "adjust(sepia=true)"},
+		{"sharpen", Pipeline{{Op: OpSharpen, Sigma: 1.5, Amount: 2}}, "sharpen(sigma=1.5,amount=2)"},
+		{"watermark text", Pipeline{{Op: OpWatermark, Text: "a, (b)", Color: "#FFFFFF"}},
+			"watermark(text=\"a, (b)\",color=ffffff,gravity=" + DefaultWatermarkGravity + ",scale=0.25,opacity=1)"},
+		{"encode defaults", Pipeline{{Op: OpEncode, Format: FormatPNG, Compression: CompressionDefault}}, "encode(format=png)"},
+		{"encode", Pipeline{{Op: OpEncode, Format: FormatGIF, Colors: 16, NoDither: true, FirstFrame: true}}, "encode(format=gif,colors=16,dither=false,firstFrame=true)"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			canonical := test.pipeline.Canonical()
+			if canonical != test.canonical {
+				t.Fatalf("Canonical() = %s, want %s", canonical, test.canonical)
+			}
+		})
+	}
+}
+
+func TestPipelineHash(t *testing.T) {
+	pipeline := Pipeline{{Op: OpRotate, Angle: 90}, {Op: OpResize, Width: 100}}
+	tests := []struct {
+		name     string
+		pipeline Pipeline
+		same     bool
+	}{
+		{"same pipeline", Pipeline{{Op: OpRotate, Angle: 90}, {Op: OpResize, Width: 100}}, true},
+		{"same meaning", Pipeline{{Op: OpRotate, Angle: 450}, {Op: OpResize, Width: 100, Mode: ModeFill}}, true},
+		{"other order", Pipeline{{Op: OpResize, Width: 100}, {Op: OpRotate, Angle: 90}}, false},
+		{"other width", Pipeline{{Op: OpRotate, Angle: 90}, {Op: OpResize, Width: 101}}, false},
+	}
+	hash := pipeline.Hash()
+	if len(hash) != 16 {
+		t.Fatalf("Hash() = %s, want 16 hex digits", hash)
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if (test.pipeline.Hash() == hash) != test.same {
+				t.Fatalf("Hash() = %s and %s, same %v", test.pipeline.Hash(), hash, test.same)
+			}
+		})
+	}
+}
+
+//операции выполняються по порядку, encode задает формат результата
+func TestRunPipeline(t *testing.T) {
+	im := newTestManager(t)
+	defer im.Clear()
+	file, err := im.SaveFile("a.png", testPNG(t, newTestImage()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pipeline := Pipeline{
+		{Op: OpCrop, X: 10, Width: 20, Height: 20},
+		{Op: OpRotate, Angle: 90},
+		{Op: OpResize, Width: 10},
+		{Op: OpEncode, Format: FormatJPEG},
+	}
+	thumbFile, err := im.RunPipeline(newTestImage(), file, pipeline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//в имени хеш конвеера уже с фильтром по умолчанию
+	fileName := "thumbp" + im.PipelineWithDefaults(pipeline).Hash() + ".a.png.jpg"
+	if thumbFile.Name != fileName {
+		t.Fatalf("name %s, want %s", thumbFile.Name, fileName)
+	}
+	thumb, err := os.Open(thumbFile.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer thumb.Close()
+	result, format, err := image.Decode(thumb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//красно-синий квадрат 20x20 из середины, после поворота красная половина сверху
+	if format != FormatJPEG || result.Bounds().Dx() != 10 || result.Bounds().Dy() != 10 {
+		t.Fatalf("result %s %v", format, result.Bounds())
+	}
+	if !sameColor(result.At(5, 1), testRed) || !sameColor(result.At(5, 8), testBlue) {
+		t.Fatalf("colors %v %v", result.At(5, 1), result.At(5, 8))
+	}
+
+	_, err = im.RunPipeline(newTestImage(), file, Pipeline{{Op: OpCrop, X: 30, Width: 20, Height: 20}})
+	if !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("crop outside of the image: %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+package imagemanager
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+)
+
+//сколько пикселей смотреть при построении палитры, у больших картинок берется каждый n-ый по сетке
+const maxQuantizeSamples = 1 << 18
+
+//квантизатор для gif.Options. палитра строиться медианным сечением из цветов самой картинки,
+//стандартная палитра при малом количестве цветов почти вся из темных оттенков
+//если в картинке есть прозрачные пиксели, последний цвет палитры прозрачный
+type medianCutQuantizer struct{}
+
+func (medianCutQuantizer) Quantize(p color.Palette, m image.Image) color.Palette {
+	colors := cap(p) - len(p)
+	histogram, transparent := newColorHistogram([]image.Image{m})
+	if transparent && colors > 1 {
+		colors--
+	}
+	p = append(p, histogram.palette(colors)...)
+	if transparent && len(p) < cap(p) {
+		p = append(p, color.Transparent)
+	}
+	return p
+}
+
+//цвет в гистограмме. похожие цвета (одинаковые старшие 5 бит каждого канала) складываються вместе
+type histogramEntry struct {
+	sum   [3]uint64
+	count uint64
+	//средний цвет, по нему режутся коробки
+	mean [3]uint8
+}
+
+type colorHistogram []*histogramEntry
+
+//гистограмма непрозрачных цветов всех картинок. второе значение - есть ли в картинках прозрачные пиксели
+func newColorHistogram(images []image.Image) (colorHistogram, bool) {
+	total := 0
+	for _, img := range images {
+		size := img.Bounds().Size()
+		total += size.X * size.Y
+	}
+	step := 1
+	if total > maxQuantizeSamples {
+		step = int(math.Ceil(math.Sqrt(float64(total) / maxQuantizeSamples)))
+	}
+
+	entries := make(map[uint16]*histogramEntry)
+	transparent := false
+	for _, img := range images {
+		bounds := img.Bounds()
+		for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+			for x := bounds.Min.X; x < bounds.Max.X; x += step {
+				c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+				//как и при рисовании в палитру, полупрозрачные пиксели станут либо прозрачными либо непрозрачными
+				if c.A < 0x80 {
+					transparent = true
+					continue
+				}
+				key := uint16(c.R>>3)<<10 | uint16(c.G>>3)<<5 | uint16(c.B>>3)
+				entry, ok := entries[key]
+				if !ok {
+					entry = &histogramEntry{}
+					entries[key] = entry
+				}
+				entry.sum[0] += uint64(c.R)
+				entry.sum[1] += uint64(c.G)
+				entry.sum[2] += uint64(c.B)
+				entry.count++
+			}
+		}
+	}
+
+	histogram := make(colorHistogram, 0, len(entries))
+	for _, entry := range entries {
+		for i := range entry.mean {
+			entry.mean[i] = uint8(entry.sum[i] / entry.count)
+		}
+		histogram = append(histogram, entry)
+	}
+	//порядок обхода карты случайный, а палитра должна быть одинаковой для одинаковых картинок
+	sort.Slice(histogram, func(i, j int) bool {
+		a, b := histogram[i].mean, histogram[j].mean
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		if a[1] != b[1] {
+			return a[1] < b[1]
+		}
+		return a[2] < b[2]
+	})
+	return histogram, transparent
+}
+
+//палитра до colors цветов. коробка с самым большим разбросом цвета с учетом количества пикселей
+//режеться пополам по медиане самого широкого канала, пока коробок не станет colors
+func (h colorHistogram) palette(colors int) color.Palette {
+	if len(h) == 0 || colors < 1 {
+		return color.Palette{color.Black}
+	}
+	boxes := []colorHistogram{h}
+	for len(boxes) < colors {
+		best := -1
+		var bestScore uint64
+		for i, box := range boxes {
+			if len(box) < 2 {
+				continue
+			}
+			_, width := box.widestChannel()
+			score := uint64(width) * box.count()
+			if best == -1 || score > bestScore {
+				best = i
+				bestScore = score
+			}
+		}
+		if best == -1 {
+			break
+		}
+		first, second := boxes[best].split()
+		boxes[best] = first
+		boxes = append(boxes, second)
+	}
+
+	result := make(color.Palette, 0, len(boxes))
+	for _, box := range boxes {
+		result = append(result, box.average())
+	}
+	return result
+}
+
+func (h colorHistogram) count() uint64 {
+	var count uint64
+	for _, entry := range h {
+		count += entry.count
+	}
+	return count
+}
+
+func (h colorHistogram) widestChannel() (int, uint8) {
+	channel := 0
+	var width uint8
+	for i := 0; i < 3; i++ {
+		low, high := uint8(255), uint8(0)
+		for _, entry := range h {
+			if entry.mean[i] < low {
+				low = entry.mean[i]
+			}
+			if entry.mean[i] > high {
+				high = entry.mean[i]
+			}
+		}
+		if high-low > width || i == 0 {
+			channel = i
+			width = high - low
+		}
+	}
+	return channel, width
+}
+
+//делит коробку по медиане пикселей, обе половины не пустые
+func (h colorHistogram) split() (colorHistogram, colorHistogram) {
+	channel, _ := h.widestChannel()
+	sort.SliceStable(h, func(i, j int) bool {
+		return h[i].mean[channel] < h[j].mean[channel]
+	})
+	half := h.count() / 2
+	var count uint64
+	index := 1
+	for i, entry := range h[:len(h)-1] {
+		count += entry.count
+		index = i + 1
+		if count >= half {
+			break
+		}
+	}
+	return h[:index:index], h[index:]
+}
+
+func (h colorHistogram) average() color.Color {
+	var sum [3]uint64
+	var count uint64
+	for _, entry := range h {
+		for i := range sum {
+			sum[i] += entry.sum[i]
+		}
+		count += entry.count
+	}
+	return color.RGBA{
+		R: uint8((sum[0] + count/2) / count),
+		G: uint8((sum[1] + count/2) / count),
+		B: uint8((sum[2] + count/2) / count),
+		A: 0xff,
+	}
+}
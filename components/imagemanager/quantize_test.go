@@ -0,0 +1,59 @@
+package imagemanager
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"reflect"
+	"testing"
+)
+
+func TestMedianCutQuantizer(t *testing.T) {
+	transparent := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	draw.Draw(transparent, image.Rect(0, 0, 2, 4), image.NewUniform(testRed), image.Point{}, draw.Src)
+	tests := []struct {
+		name   string
+		image  image.Image
+		colors int
+		//nil значит палитру не сравниваем, только ее длинну. цвета идут по возрастанию каналов
+		palette color.Palette
+		length  int
+	}{
+		//цветов в картинке меньше чем в палитре, палитра из них и состоит
+		{"two colors", newTestImage(), 16, color.Palette{testBlue, testRed}, 2},
+		{"gradient to 8 colors", newTestGradient(), 8, nil, 8},
+		{"gradient to 1 color", newTestGradient(), 1, nil, 1},
+		//последний цвет прозрачный, под него уходит одно место
+		{"transparent", transparent, 16, color.Palette{testRed, color.Transparent}, 2},
+		{"transparent to 2 colors", transparent, 2, color.Palette{testRed, color.Transparent}, 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := medianCutQuantizer{}.Quantize(make(color.Palette, 0, test.colors), test.image)
+			if len(result) != test.length {
+				t.Fatalf("%d colors, want %d: %v", len(result), test.length, result)
+			}
+			for i, c := range test.palette {
+				if !sameColor(result[i], c) {
+					t.Fatalf("palette %v, want %v", result, test.palette)
+				}
+			}
+			//для одной и той же картинки палитра всегда одинаковая
+			again := medianCutQuantizer{}.Quantize(make(color.Palette, 0, test.colors), test.image)
+			if !reflect.DeepEqual(result, again) {
+				t.Fatalf("palette %v, then %v", result, again)
+			}
+		})
+	}
+}
+
+//у большой картинки берется часть пикселей, но редкий цвет большой области все равно попадает в палитру
+func TestQuantizeSampling(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 1024, 1024))
+	draw.Draw(img, img.Bounds(), image.NewUniform(testRed), image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(0, 0, 1024, 128), image.NewUniform(testBlue), image.Point{}, draw.Src)
+	result := medianCutQuantizer{}.Quantize(make(color.Palette, 0, 4), img)
+	if len(result) != 2 || !sameColor(result[0], testBlue) || !sameColor(result[1], testRed) {
+		t.Fatalf("palette %v", result)
+	}
+}
@@ -0,0 +1,173 @@
+package imagemanager
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"strconv"
+)
+
+//как отражать картинку
+const FlipHorizontal = "horizontal"
+const FlipVertical = "vertical"
+const FlipBoth = "both"
+
+var supportedFlips = map[string]bool{FlipHorizontal: true, FlipVertical: true, FlipBoth: true}
+
+//прямоугольник который нужно вырезать из исходника, в пикселях исходника
+type CropRect struct {
+	X      uint `json:"x"`
+	Y      uint `json:"y"`
+	Width  uint `json:"width"`
+	Height uint `json:"height"`
+}
+
+func (o ResizeOptions) hasTransform() bool {
+	return o.Crop != nil || o.rotation() != 0 || o.Flip != ""
+}
+
+//угол поворота по часовой стрелке в диапазоне от 0 до 360
+func (o ResizeOptions) rotation() float64 {
+	angle := math.Mod(o.Rotate, 360)
+	if angle < 0 {
+		angle += 360
+	}
+	return angle
+}
+
+func (o ResizeOptions) validateTransform() error {
+	if math.IsNaN(o.Rotate) || math.IsInf(o.Rotate, 0) {
+		return fmt.Errorf("%w: bad rotate angle", ErrInvalidOptions)
+	}
+	if o.Flip != "" && !supportedFlips[o.Flip] {
+		return fmt.Errorf("%w: unknown flip %q", ErrInvalidOptions, o.Flip)
+	}
+	if o.Crop != nil && (o.Crop.Width == 0 || o.Crop.Height == 0) {
+		return fmt.Errorf("%w: crop width and height are required", ErrInvalidOptions)
+	}
+	return nil
+}
+
+//часть имени файла для обрезки, поворота и отражения
+func (o ResizeOptions) transformName() string {
+	name := ""
+	if o.Crop != nil {
+		name += fmt.Sprintf("_crop%d_%d_%d_%d", o.Crop.X, o.Crop.Y, o.Crop.Width, o.Crop.Height)
+	}
+	angle := o.rotation()
+	if angle != 0 {
+		name += "_r" + strconv.FormatFloat(angle, 'f', -1, 64)
+		//при повороте не на прямой угол углы заливаються фоном
+		if math.Mod(angle, 90) != 0 {
+			name += "_" + o.backgroundName()
+		}
+	}
+	switch o.Flip {
+	case FlipHorizontal:
+		name += "_fliph"
+	case FlipVertical:
+		name += "_flipv"
+	case FlipBoth:
+		name += "_fliphv"
+	}
+	return name
+}
+
+//обрезает, поворачивает и отражает картинку именно в таком порядке, ресайз идет уже после
+//обрезка за пределами картинки это ошибка параметров, повторять такую задачу смысла нет
+func (o ResizeOptions) transform(src image.Image) (image.Image, error) {
+	if o.Crop != nil {
+		bounds := src.Bounds()
+		rect := image.Rect(int(o.Crop.X), int(o.Crop.Y), int(o.Crop.X+o.Crop.Width), int(o.Crop.Y+o.Crop.Height)).
+			Add(bounds.Min)
+		if !rect.In(bounds) {
+			return nil, fmt.Errorf("%w: crop %v is outside of the image %v", ErrInvalidOptions, rect.Sub(bounds.Min), bounds.Size())
+		}
+		dst := image.NewNRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+		draw.Draw(dst, dst.Bounds(), src, rect.Min, draw.Src)
+		src = dst
+	}
+
+	angle := o.rotation()
+	switch angle {
+	case 0:
+	case 90:
+		src = Orient(src, OrientationRotate90)
+	case 180:
+		src = Orient(src, OrientationRotate180)
+	case 270:
+		src = Orient(src, OrientationRotate270)
+	default:
+		src = rotate(src, angle, o.background())
+	}
+
+	switch o.Flip {
+	case FlipHorizontal:
+		src = Orient(src, OrientationFlipHorizontal)
+	case FlipVertical:
+		src = Orient(src, OrientationFlipVertical)
+	case FlipBoth:
+		src = Orient(src, OrientationRotate180)
+	}
+	return src, nil
+}
+
+//все операции над картинкой: сначала transform, потом ресайз и adjust
+func (o ResizeOptions) process(src image.Image) (image.Image, error) {
+	transformed, err := o.transform(src)
+	if err != nil {
+		return nil, err
+	}
+	err = o.checkSize(transformed.Bounds())
+	if err != nil {
+		return nil, err
+	}
+	return o.adjust(o.apply(transformed)), nil
+}
+
+//поворот на любой угол по часовой стрелке. картинка увеличиваеться так что бы поместились все углы,
+//пустые места заливаються background. края сглаживаються билинейной интерполяцией
+func rotate(src image.Image, angle float64, background color.Color) image.Image {
+	bounds := src.Bounds()
+	width, height := float64(bounds.Dx()), float64(bounds.Dy())
+	radians := angle * math.Pi / 180
+	sin, cos := math.Sin(radians), math.Cos(radians)
+	dstWidth := int(math.Ceil(math.Abs(width*cos) + math.Abs(height*sin) - 1e-9))
+	dstHeight := int(math.Ceil(math.Abs(width*sin) + math.Abs(height*cos) - 1e-9))
+	dst := image.NewRGBA64(image.Rect(0, 0, dstWidth, dstHeight))
+
+	fill := color.RGBA64Model.Convert(background).(color.RGBA64)
+	sample := func(x, y int) color.RGBA64 {
+		if x < 0 || y < 0 || x >= bounds.Dx() || y >= bounds.Dy() {
+			return fill
+		}
+		return color.RGBA64Model.Convert(src.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.RGBA64)
+	}
+
+	for y := 0; y < dstHeight; y++ {
+		for x := 0; x < dstWidth; x++ {
+			//центр точки результата поворачиваем обратно и получаем точку исходника
+			dx := float64(x) + 0.5 - float64(dstWidth)/2
+			dy := float64(y) + 0.5 - float64(dstHeight)/2
+			sx := dx*cos + dy*sin + width/2 - 0.5
+			sy := -dx*sin + dy*cos + height/2 - 0.5
+			x0, y0 := int(math.Floor(sx)), int(math.Floor(sy))
+			fx, fy := sx-float64(x0), sy-float64(y0)
+			dst.SetRGBA64(x, y, mix(
+				mix(sample(x0, y0), sample(x0+1, y0), fx),
+				mix(sample(x0, y0+1), sample(x0+1, y0+1), fx),
+				fy,
+			))
+		}
+	}
+	return dst
+}
+
+func mix(a color.RGBA64, b color.RGBA64, t float64) color.RGBA64 {
+	channel := func(a uint16, b uint16) uint16 {
+		return uint16(math.Round(float64(a)*(1-t) + float64(b)*t))
+	}
+	return color.RGBA64{R: channel(a.R, b.R), G: channel(a.G, b.G), B: channel(a.B, b.B), A: channel(a.A, b.A)}
+}
@@ -0,0 +1,134 @@
+package imagemanager
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestValidateTransform(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ResizeOptions
+		valid   bool
+	}{
+		//обрезка и поворот без ресайза тоже задача
+		{"crop only", ResizeOptions{Crop: &CropRect{Width: 10, Height: 10}}, true},
+		{"rotate only", ResizeOptions{Rotate: 90}, true},
+		{"flip only", ResizeOptions{Flip: FlipVertical}, true},
+		{"negative angle", ResizeOptions{Width: 10, Rotate: -30, Background: "000"}, true},
+		{"empty crop", ResizeOptions{Crop: &CropRect{X: 10, Y: 10}}, false},
+		{"unknown flip", ResizeOptions{Flip: "diagonal"}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.options.Validate()
+			if (err == nil) != test.valid {
+				t.Fatalf("Validate() = %v, valid %v", err, test.valid)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidOptions) {
+				t.Fatalf("error %v is not %v", err, ErrInvalidOptions)
+			}
+		})
+	}
+}
+
+func TestTransformName(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  ResizeOptions
+		fileName string
+	}{
+		{"crop", ResizeOptions{Width: 10, Crop: &CropRect{X: 1, Y: 2, Width: 3, Height: 4}}, "thumb10_crop1_2_3_4.a.png"},
+		//углы которые дают один и тот же результат называються одинаково
+		{"rotate", ResizeOptions{Width: 10, Rotate: 90}, "thumb10_r90.a.png"},
+		{"rotate negative", ResizeOptions{Width: 10, Rotate: -270}, "thumb10_r90.a.png"},
+		{"full turn", ResizeOptions{Width: 10, Rotate: 360}, "thumb10.a.png"},
+		//при повороте не на прямой угол в имени есть фон
+		{"rotate with background", ResizeOptions{Width: 10, Rotate: 45, Background: "#FF0000"}, "thumb10_r45_ff0000.a.png"},
+		{"flip both", ResizeOptions{Width: 10, Flip: FlipBoth}, "thumb10_fliphv.a.png"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fileName := test.options.FileName("a.png")
+			if fileName != test.fileName {
+				t.Fatalf("FileName() = %s, want %s", fileName, test.fileName)
+			}
+		})
+	}
+}
+
+func TestTransform(t *testing.T) {
+	green := color.RGBA{G: 255, A: 255}
+	tests := []struct {
+		name    string
+		options ResizeOptions
+		width   int
+		height  int
+		//цвет левого верхнего и правого нижнего угла результата
+		topLeft     color.Color
+		bottomRight color.Color
+	}{
+		{"crop left half", ResizeOptions{Crop: &CropRect{X: 0, Y: 0, Width: 20, Height: 20}}, 20, 20, testRed, testRed},
+		{"crop right part", ResizeOptions{Crop: &CropRect{X: 25, Y: 5, Width: 10, Height: 5}}, 10, 5, testBlue, testBlue},
+		//красная половина после поворота по часовой стрелке оказываеться сверху
+		{"rotate 90", ResizeOptions{Rotate: 90}, 20, 40, testRed, testBlue},
+		{"rotate 180", ResizeOptions{Rotate: 180}, 40, 20, testBlue, testRed},
+		{"rotate -90", ResizeOptions{Rotate: -90}, 20, 40, testBlue, testRed},
+		//картинка увеличиваеться так что бы влезли все углы, пустые места заливаються фоном
+		{"rotate 45", ResizeOptions{Rotate: 45, Background: "0f0"}, 43, 43, green, green},
+		{"flip horizontal", ResizeOptions{Flip: FlipHorizontal}, 40, 20, testBlue, testRed},
+		{"flip vertical", ResizeOptions{Flip: FlipVertical}, 40, 20, testRed, testBlue},
+		//сначала обрезка, потом поворот
+		{"crop and rotate", ResizeOptions{Crop: &CropRect{X: 10, Y: 0, Width: 20, Height: 10}, Rotate: 270}, 10, 20, testBlue, testRed},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.options.transform(newTestImage())
+			if err != nil {
+				t.Fatal(err)
+			}
+			bounds := result.Bounds()
+			if bounds.Dx() != test.width || bounds.Dy() != test.height {
+				t.Fatalf("size %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), test.width, test.height)
+			}
+			topLeft := result.At(bounds.Min.X, bounds.Min.Y)
+			bottomRight := result.At(bounds.Max.X-1, bounds.Max.Y-1)
+			if !sameColor(topLeft, test.topLeft) || !sameColor(bottomRight, test.bottomRight) {
+				t.Fatalf("corners %v %v, want %v %v", topLeft, bottomRight, test.topLeft, test.bottomRight)
+			}
+		})
+	}
+}
+
+func TestTransformErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		crop CropRect
+	}{
+		{"outside", CropRect{X: 40, Y: 0, Width: 10, Height: 10}},
+		{"too wide", CropRect{X: 0, Y: 0, Width: 41, Height: 20}},
+		{"too high", CropRect{X: 30, Y: 15, Width: 10, Height: 10}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			crop := test.crop
+			_, err := ResizeOptions{Crop: &crop}.transform(newTestImage())
+			if !errors.Is(err, ErrInvalidOptions) {
+				t.Fatalf("transform() = %v, want %v", err, ErrInvalidOptions)
+			}
+		})
+	}
+}
+
+//ресайз идет после обрезки и поворота, поэтому ширина считаеться от уже повернутой картинки
+func TestProcessTransformBeforeResize(t *testing.T) {
+	result, err := ResizeOptions{Width: 10, Rotate: 90}.process(newTestImage())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Bounds() != image.Rect(0, 0, 10, 20) {
+		t.Fatalf("bounds %v, want 10x20", result.Bounds())
+	}
+}
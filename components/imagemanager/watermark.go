@@ -0,0 +1,222 @@
+package imagemanager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/nfnt/resize"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"sync"
+	"unicode/utf8"
+)
+
+//по умолчанию водяной знак в правом нижнем углу, шириной в четверть результата и полностью непрозрачный
+const DefaultWatermarkGravity = GravitySouthEast
+const DefaultWatermarkScale = 0.25
+const DefaultWatermarkColor = "ffffff"
+
+//длинный текст все равно не поместиться на картинку
+const MaxWatermarkText = 200
+
+var ErrWatermarkNotLoaded = fmt.Errorf("%w: watermark image is not loaded", ErrInvalidOptions)
+
+//водяной знак поверх результата. либо картинка из хранилища, либо текст
+//нулевые Gravity, Scale и Opacity значат значения по умолчанию
+type Watermark struct {
+	//id картинки, такой же как у обычных загруженных картинок
+	Image string `json:"image,omitempty"`
+	//текст рисуеться встроенным шрифтом Go Bold цветом Color
+	Text  string `json:"text,omitempty"`
+	Color string `json:"color,omitempty"`
+	//где рисовать, как gravity у ресайза
+	Gravity string `json:"gravity,omitempty"`
+	//ширина водяного знака относительно ширины результата, от 0 до 1
+	Scale float64 `json:"scale,omitempty"`
+	//непрозрачность от 0 до 1
+	Opacity float64 `json:"opacity,omitempty"`
+	//отступ от краев в пикселях результата, при замощении это расстояние между копиями
+	Margin uint `json:"margin,omitempty"`
+	//замостить водяным знаком всю картинку, Gravity тогда не используеться
+	Tile bool `json:"tile,omitempty"`
+}
+
+var watermarkFontOnce sync.Once
+var watermarkFont *opentype.Font
+var watermarkFontErr error
+
+func (w *Watermark) validate() error {
+	if (w.Image == "") == (w.Text == "") {
+		return fmt.Errorf("%w: watermark needs either an image or a text", ErrInvalidOptions)
+	}
+	if utf8.RuneCountInString(w.Text) > MaxWatermarkText {
+		return fmt.Errorf("%w: watermark text is longer than %d characters", ErrInvalidOptions, MaxWatermarkText)
+	}
+	if w.Color != "" {
+		if w.Text == "" {
+			return fmt.Errorf("%w: watermark color is only used with a text", ErrInvalidOptions)
+		}
+		_, err := parseColor(w.Color)
+		if err != nil {
+			return err
+		}
+	}
+	if w.Gravity != "" && !supportedGravities[w.Gravity] {
+		return fmt.Errorf("%w: unknown watermark gravity %q", ErrInvalidOptions, w.Gravity)
+	}
+	if !(w.Scale >= 0 && w.Scale <= 1) {
+		return fmt.Errorf("%w: watermark scale must be from 0 to 1", ErrInvalidOptions)
+	}
+	if !(w.Opacity >= 0 && w.Opacity <= 1) {
+		return fmt.Errorf("%w: watermark opacity must be from 0 to 1", ErrInvalidOptions)
+	}
+	return nil
+}
+
+//водяной знак со значениями по умолчанию, так одинаковые знаки дают одинаковые имена файлов
+func (w Watermark) normalized() Watermark {
+	if w.Gravity == "" || w.Tile {
+		w.Gravity = DefaultWatermarkGravity
+	}
+	if w.Scale == 0 {
+		w.Scale = DefaultWatermarkScale
+	}
+	if w.Opacity == 0 {
+		w.Opacity = 1
+	}
+	if w.Text != "" && w.Color == "" {
+		w.Color = DefaultWatermarkColor
+	}
+	return w
+}
+
+//часть имени файла. текст может быть любым, поэтому в имя идет хеш параметров
+func (w *Watermark) name() string {
+	data, _ := json.Marshal(w.normalized())
+	sum := sha256.Sum256(data)
+	return "_wm" + hex.EncodeToString(sum[:4])
+}
+
+//рисует водяной знак поверх src. картинки водяных знаков по id берутся из images
+func (w *Watermark) apply(src image.Image, images map[string]image.Image) (image.Image, error) {
+	normalized := w.normalized()
+	bounds := src.Bounds()
+	targetWidth := uint(math.Max(1, math.Round(float64(bounds.Dx())*normalized.Scale)))
+
+	var mark image.Image
+	if normalized.Image != "" {
+		watermarkImage, ok := images[normalized.Image]
+		if !ok {
+			return nil, ErrWatermarkNotLoaded
+		}
+		mark = resize.Resize(targetWidth, 0, watermarkImage, resize.Lanczos3)
+	} else {
+		var err error
+		mark, err = normalized.renderText(int(targetWidth))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	//рисуем на копии, src может быть исходником или кадром анимации
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	mask := image.NewUniform(color.Alpha16{A: uint16(math.Round(normalized.Opacity * 0xffff))})
+	markSize := mark.Bounds().Size()
+	margin := int(normalized.Margin)
+	if normalized.Tile {
+		for y := margin; y < dst.Rect.Dy(); y += markSize.Y + margin {
+			for x := margin; x < dst.Rect.Dx(); x += markSize.X + margin {
+				target := image.Rectangle{Min: image.Pt(x, y), Max: image.Pt(x, y).Add(markSize)}
+				draw.DrawMask(dst, target, mark, mark.Bounds().Min, mask, image.Point{}, draw.Over)
+			}
+		}
+		return dst, nil
+	}
+
+	//отступ со всех сторон, к какому краю прижат знак решает gravity
+	offset := anchor(normalized.Gravity, dst.Rect.Dx()-markSize.X-2*margin, dst.Rect.Dy()-markSize.Y-2*margin)
+	offset = offset.Add(image.Pt(margin, margin))
+	target := image.Rectangle{Min: offset, Max: offset.Add(markSize)}
+	draw.DrawMask(dst, target, mark, mark.Bounds().Min, mask, image.Point{}, draw.Over)
+	return dst, nil
+}
+
+//текст шириной примерно width пикселей. размер шрифта подбираеться по ширине текста
+func (w Watermark) renderText(width int) (image.Image, error) {
+	watermarkFontOnce.Do(func() {
+		watermarkFont, watermarkFontErr = opentype.Parse(gobold.TTF)
+	})
+	if watermarkFontErr != nil {
+		return nil, watermarkFontErr
+	}
+
+	//сначала меряем текст шрифтом известного размера, потом берем размер пропорционально
+	const measureSize = 100
+	face, err := opentype.NewFace(watermarkFont, &opentype.FaceOptions{Size: measureSize, DPI: 72, Hinting: font.HintingNone})
+	if err != nil {
+		return nil, err
+	}
+	advance := font.MeasureString(face, w.Text)
+	_ = face.Close()
+	if advance <= 0 {
+		return image.NewNRGBA(image.Rect(0, 0, 1, 1)), nil
+	}
+	size := measureSize * float64(width) / (float64(advance) / 64)
+
+	face, err = opentype.NewFace(watermarkFont, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingNone})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = face.Close()
+	}()
+	metrics := face.Metrics()
+	height := (metrics.Ascent + metrics.Descent).Ceil()
+	textColor, _ := parseColor(w.Color)
+	dst := image.NewNRGBA(image.Rect(0, 0, font.MeasureString(face, w.Text).Ceil(), height))
+	drawer := &font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(textColor),
+		Face: face,
+		Dot:  fixed.Point26_6{Y: metrics.Ascent},
+	}
+	drawer.DrawString(w.Text)
+	return dst, nil
+}
+
+//запоминает декодированную картинку водяного знака, ресайзы с Watermark.Image == id будут брать ее
+//картинки хранятся до Clear
+func (im *ImageManager) AddWatermarkImage(id string, watermarkImage image.Image) {
+	if im.watermarks == nil {
+		im.watermarks = make(map[string]image.Image)
+	}
+	if animation, ok := watermarkImage.(*Animation); ok {
+		watermarkImage = animation.Frames[0]
+	}
+	im.watermarks[id] = watermarkImage
+}
+
+//добавляет к process рисование водяного знака, если он задан
+func (im *ImageManager) withWatermark(
+	process func(image.Image) (image.Image, error),
+	watermark *Watermark,
+) func(image.Image) (image.Image, error) {
+	if watermark == nil {
+		return process
+	}
+	return func(src image.Image) (image.Image, error) {
+		processed, err := process(src)
+		if err != nil {
+			return nil, err
+		}
+		return watermark.apply(processed, im.watermarks)
+	}
+}
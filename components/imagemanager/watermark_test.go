@@ -0,0 +1,148 @@
+package imagemanager
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"testing"
+)
+
+func TestWatermarkValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		watermark Watermark
+		valid     bool
+	}{
+		{"image", Watermark{Image: "logo", Gravity: GravityNorthWest, Scale: 0.5, Opacity: 0.3, Margin: 10}, true},
+		{"text", Watermark{Text: "© shop", Color: "#000"}, true},
+		{"tile", Watermark{Image: "logo", Tile: true}, true},
+		{"nothing to draw", Watermark{Gravity: GravityNorth}, false},
+		{"image and text", Watermark{Image: "logo", Text: "text"}, false},
+		{"text too long", Watermark{Text: strings.Repeat("я", MaxWatermarkText+1)}, false},
+		//у картинки свои цвета
+		{"color without text", Watermark{Image: "logo", Color: "fff"}, false},
+		{"bad color", Watermark{Text: "text", Color: "white"}, false},
+		{"unknown gravity", Watermark{Image: "logo", Gravity: "middle"}, false},
+		{"scale above 1", Watermark{Image: "logo", Scale: 1.5}, false},
+		{"negative opacity", Watermark{Image: "logo", Opacity: -0.1}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			watermark := test.watermark
+			err := ResizeOptions{Width: 10, Watermark: &watermark}.Validate()
+			if (err == nil) != test.valid {
+				t.Fatalf("Validate() = %v, valid %v", err, test.valid)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidOptions) {
+				t.Fatalf("error %v is not %v", err, ErrInvalidOptions)
+			}
+		})
+	}
+}
+
+func TestWatermarkName(t *testing.T) {
+	base := Watermark{Text: "text"}
+	tests := []struct {
+		name      string
+		watermark Watermark
+		same      bool
+	}{
+		//значения по умолчанию дают то же имя
+		{"defaults", Watermark{Text: "text", Color: DefaultWatermarkColor, Gravity: DefaultWatermarkGravity, Scale: DefaultWatermarkScale, Opacity: 1}, true},
+		{"other text", Watermark{Text: "other"}, false},
+		{"other color", Watermark{Text: "text", Color: "000"}, false},
+		{"other opacity", Watermark{Text: "text", Opacity: 0.5}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if (test.watermark.name() == base.name()) != test.same {
+				t.Fatalf("name() = %s and %s, same %v", test.watermark.name(), base.name(), test.same)
+			}
+		})
+	}
+	//при замощении gravity не используеться
+	tile := Watermark{Image: "logo", Tile: true}
+	tileWithGravity := Watermark{Image: "logo", Tile: true, Gravity: GravityNorth}
+	if tile.name() != tileWithGravity.name() {
+		t.Fatal("gravity changes the name of a tiled watermark")
+	}
+}
+
+func TestWatermarkApply(t *testing.T) {
+	green := color.RGBA{G: 255, A: 255}
+	logo := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	draw.Draw(logo, logo.Bounds(), image.NewUniform(green), image.Point{}, draw.Src)
+	images := map[string]image.Image{"logo": logo}
+	tests := []struct {
+		name      string
+		watermark Watermark
+		//точки и какого цвета они должны быть после наложения
+		points map[image.Point]color.Color
+	}{
+		//четверть ширины 40 - квадрат 10x10 в правом нижнем углу
+		{"default", Watermark{Image: "logo"}, map[image.Point]color.Color{
+			{X: 39, Y: 19}: green, {X: 30, Y: 10}: green, {X: 29, Y: 19}: testBlue, {X: 0, Y: 0}: testRed,
+		}},
+		{"north west with margin", Watermark{Image: "logo", Gravity: GravityNorthWest, Margin: 2}, map[image.Point]color.Color{
+			{X: 1, Y: 1}: testRed, {X: 2, Y: 2}: green, {X: 11, Y: 11}: green, {X: 12, Y: 12}: testRed,
+		}},
+		{"half opacity", Watermark{Image: "logo", Opacity: 0.5}, map[image.Point]color.Color{
+			{X: 39, Y: 19}: color.RGBA{G: 128, B: 127, A: 255},
+		}},
+		{"tile", Watermark{Image: "logo", Tile: true, Margin: 5}, map[image.Point]color.Color{
+			{X: 5, Y: 5}: green, {X: 4, Y: 4}: testRed, {X: 20, Y: 5}: green, {X: 35, Y: 5}: green, {X: 17, Y: 17}: testRed,
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src := newTestImage()
+			result, err := test.watermark.apply(src, images)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Bounds() != src.Bounds() {
+				t.Fatalf("bounds %v", result.Bounds())
+			}
+			for point, c := range test.points {
+				if !sameColor(result.At(point.X, point.Y), c) {
+					t.Fatalf("point %v is %v, want %v", point, result.At(point.X, point.Y), c)
+				}
+			}
+			//исходник не меняеться
+			if !sameColor(src.At(39, 19), testBlue) {
+				t.Fatal("source image is changed")
+			}
+		})
+	}
+
+	missing := Watermark{Image: "missing"}
+	_, err := missing.apply(newTestImage(), images)
+	if err != ErrWatermarkNotLoaded {
+		t.Fatalf("apply() = %v, want %v", err, ErrWatermarkNotLoaded)
+	}
+}
+
+func TestTextWatermark(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.Black), image.Point{}, draw.Src)
+	watermark := Watermark{Text: "Shop", Scale: 0.5, Gravity: GravityNorthWest}
+	result, err := watermark.apply(src, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//белый текст шириной примерно в половину картинки в левом верхнем углу
+	right := 0
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 200; x++ {
+			r, _, _, _ := result.At(x, y).RGBA()
+			if r > 0x8000 && x > right {
+				right = x
+			}
+		}
+	}
+	if right < 80 || right > 110 {
+		t.Fatalf("text ends at %d, want about 100", right)
+	}
+}
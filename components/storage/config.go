@@ -0,0 +1,35 @@
+package storage
+
+import "github.com/aws/aws-sdk-go/aws/session"
+
+type Config struct {
+	Driver string
+	//для S3
+	S3Session *session.Session
+	Bucket    string
+	//для файловой системы
+	Dir     string
+	BaseURL string
+}
+
+func NewS3Config(s3 *session.Session, bucket string) Config {
+	return Config{
+		Driver:    DriverS3,
+		S3Session: s3,
+		Bucket:    bucket,
+	}
+}
+
+func NewFilesystemConfig(dir string, baseURL string) Config {
+	return Config{
+		Driver:  DriverFilesystem,
+		Dir:     dir,
+		BaseURL: baseURL,
+	}
+}
+
+func NewMemoryConfig() Config {
+	return Config{
+		Driver: DriverMemory,
+	}
+}
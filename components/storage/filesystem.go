@@ -0,0 +1,209 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//рядом с каждым файлом лежит скрытый файл с хешем содержимого, который посчитан при загрузке
+const etagFilePrefix = ".etag."
+
+//хранилище в папке на диске. подходит для локального запуска без AWS
+type FilesystemStore struct {
+	dir     string
+	baseURL string
+}
+
+func NewFilesystemStore(dir string, baseURL string) (*FilesystemStore, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	return &FilesystemStore{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+func (s *FilesystemStore) Put(key string, body io.Reader) (string, error) {
+	filePath := s.path(key)
+	err := os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err != nil {
+		return "", err
+	}
+
+	//пишем во временный файл и переименовываем, что бы никто не прочитал недописанный файл
+	tmpFile, err := ioutil.TempFile(filepath.Dir(filePath), ".upload")
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmpFile, hash), body)
+	if err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+		return "", err
+	}
+	err = tmpFile.Close()
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return "", err
+	}
+	err = os.Rename(tmpFile.Name(), filePath)
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return "", err
+	}
+
+	//хеш привязан к размеру и времени изменения файла, если его не удалось сохранить,
+	//метка собираеться из них же
+	info, err := os.Stat(filePath)
+	if err == nil {
+		etag := fmt.Sprintf("%d %d %s", info.Size(), info.ModTime().UnixNano(), etagOf(hash))
+		_ = ioutil.WriteFile(s.etagPath(key), []byte(etag), 0644)
+	}
+
+	return s.URL(key), nil
+}
+
+func (s *FilesystemStore) Get(key string) (io.ReadCloser, error) {
+	file, err := os.Open(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (s *FilesystemStore) GetRange(key string, offset int64, length int64) (io.ReadCloser, error) {
+	file, err := os.Open(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	_, err = file.Seek(offset, io.SeekStart)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	if length <= 0 {
+		return file, nil
+	}
+	return &limitedFile{Reader: io.LimitReader(file, length), file: file}, nil
+}
+
+func (s *FilesystemStore) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	_ = os.Remove(s.etagPath(key))
+	return nil
+}
+
+func (s *FilesystemStore) Stat(key string) (ObjectInfo, error) {
+	info, err := os.Stat(s.path(key))
+	if os.IsNotExist(err) {
+		return ObjectInfo{}, ErrNotFound
+	}
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	etag, weak := s.etag(key, info)
+	return ObjectInfo{
+		Key:          key,
+		Size:         info.Size(),
+		LastModified: info.ModTime(),
+		ETag:         etag,
+		WeakETag:     weak,
+	}, nil
+}
+
+func (s *FilesystemStore) List(prefix string) ([]ObjectInfo, error) {
+	var result []ObjectInfo
+	err := filepath.Walk(s.dir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".upload") || strings.HasPrefix(info.Name(), etagFilePrefix) {
+			return nil
+		}
+		key, err := filepath.Rel(s.dir, filePath)
+		if err != nil {
+			return err
+		}
+		key = filepath.ToSlash(key)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		etag, weak := s.etag(key, info)
+		result = append(result, ObjectInfo{
+			Key:          key,
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+			ETag:         etag,
+			WeakETag:     weak,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *FilesystemStore) URL(key string) string {
+	if s.baseURL == "" {
+		return s.path(key)
+	}
+	return s.baseURL + "/" + key
+}
+
+//ключ чистится что бы через ../ нельзя было выйти за пределы папки хранилища
+func (s *FilesystemStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(filepath.Clean("/"+key)))
+}
+
+func (s *FilesystemStore) etagPath(key string) string {
+	filePath := s.path(key)
+	return filepath.Join(filepath.Dir(filePath), etagFilePrefix+filepath.Base(filePath))
+}
+
+//хеш из загрузки подходит только пока файл не меняли в обход хранилища,
+//иначе метка собираеться из времени изменения и размера, так она все равно меняеться вместе с файлом.
+//такая метка слабая, одинаковая метка не значит одинаковые байты
+func (s *FilesystemStore) etag(key string, info os.FileInfo) (string, bool) {
+	data, err := ioutil.ReadFile(s.etagPath(key))
+	if err == nil {
+		var size, modified int64
+		var hash string
+		_, err = fmt.Sscanf(string(data), "%d %d %s", &size, &modified, &hash)
+		if err == nil && size == info.Size() && modified == info.ModTime().UnixNano() {
+			return hash, false
+		}
+	}
+	return strconv.FormatInt(info.ModTime().UnixNano(), 16) + "-" + strconv.FormatInt(info.Size(), 16), true
+}
+
+//кусок файла, закрываеться сам файл
+type limitedFile struct {
+	io.Reader
+	file *os.File
+}
+
+func (f *limitedFile) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+//хранилище в памяти. для тестов и запуска без внешних зависимостей
+//все файлы пропадают при остановке сервиса
+type MemoryStore struct {
+	mx      sync.RWMutex
+	objects map[string]memoryObject
+}
+
+type memoryObject struct {
+	data         []byte
+	lastModified time.Time
+	etag         string
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		objects: make(map[string]memoryObject),
+	}
+}
+
+func (s *MemoryStore) Put(key string, body io.Reader) (string, error) {
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	_, _ = hash.Write(data)
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.objects[key] = memoryObject{
+		data:         data,
+		lastModified: time.Now(),
+		etag:         etagOf(hash),
+	}
+	return s.URL(key), nil
+}
+
+func (s *MemoryStore) Get(key string) (io.ReadCloser, error) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	object, ok := s.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	//данные никто не изменяет, Put всегда кладет новый слайс, так что копировать не нужно
+	return ioutil.NopCloser(bytes.NewReader(object.data)), nil
+}
+
+func (s *MemoryStore) GetRange(key string, offset int64, length int64) (io.ReadCloser, error) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	object, ok := s.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	size := int64(len(object.data))
+	if offset > size {
+		offset = size
+	}
+	end := size
+	if length > 0 && offset+length < size {
+		end = offset + length
+	}
+	return ioutil.NopCloser(bytes.NewReader(object.data[offset:end])), nil
+}
+
+func (s *MemoryStore) Delete(key string) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if _, ok := s.objects[key]; !ok {
+		return ErrNotFound
+	}
+	delete(s.objects, key)
+	return nil
+}
+
+func (s *MemoryStore) Stat(key string) (ObjectInfo, error) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	object, ok := s.objects[key]
+	if !ok {
+		return ObjectInfo{}, ErrNotFound
+	}
+	return ObjectInfo{
+		Key:          key,
+		Size:         int64(len(object.data)),
+		LastModified: object.lastModified,
+		ETag:         object.etag,
+	}, nil
+}
+
+func (s *MemoryStore) List(prefix string) ([]ObjectInfo, error) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	var result []ObjectInfo
+	for key, object := range s.objects {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		result = append(result, ObjectInfo{
+			Key:          key,
+			Size:         int64(len(object.data)),
+			LastModified: object.lastModified,
+			ETag:         object.etag,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result, nil
+}
+
+func (s *MemoryStore) URL(key string) string {
+	return "memory://" + key
+}
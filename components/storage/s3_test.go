@@ -0,0 +1,172 @@
+package storage
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+//бакет S3 в памяти, отвечает как настоящий S3 на те запросы которые делает S3Store
+//удаление отсутствующего ключа успешно, кусок за концом файла дает 416 InvalidRange
+type fakeS3 struct {
+	mx      sync.Mutex
+	bucket  string
+	objects map[string]fakeS3Object
+}
+
+type fakeS3Object struct {
+	data         []byte
+	etag         string
+	lastModified time.Time
+}
+
+type fakeS3Error struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    string
+	Message string
+}
+
+type fakeS3List struct {
+	XMLName     xml.Name `xml:"ListBucketResult"`
+	Name        string
+	Prefix      string
+	KeyCount    int
+	IsTruncated bool
+	Contents    []fakeS3ListObject
+}
+
+type fakeS3ListObject struct {
+	Key          string
+	Size         int64
+	LastModified string
+	ETag         string
+}
+
+func newTestS3Store(t *testing.T) *S3Store {
+	fake := &fakeS3{bucket: "bucket", objects: make(map[string]fakeS3Object)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(server.URL),
+		Region:           aws.String("us-east-1"),
+		Credentials:      credentials.NewStaticCredentials("id", "secret", ""),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewS3Store(sess, fake.bucket)
+}
+
+func (s *fakeS3) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	if path == s.bucket && r.Method == http.MethodGet {
+		s.list(rw, r.URL.Query().Get("prefix"))
+		return
+	}
+	if !strings.HasPrefix(path, s.bucket+"/") {
+		writeFakeS3Error(rw, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	key := strings.TrimPrefix(path, s.bucket+"/")
+	object, ok := s.objects[key]
+	switch r.Method {
+	case http.MethodPut:
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeFakeS3Error(rw, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		sum := md5.Sum(data)
+		object = fakeS3Object{data: data, etag: hex.EncodeToString(sum[:]), lastModified: time.Now().UTC().Truncate(time.Second)}
+		s.objects[key] = object
+		rw.Header().Set("ETag", `"`+object.etag+`"`)
+	case http.MethodDelete:
+		delete(s.objects, key)
+		rw.WriteHeader(http.StatusNoContent)
+	case http.MethodHead:
+		if !ok {
+			//на HEAD S3 отвечает без тела, sdk делает из этого код NotFound
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		s.writeHeaders(rw, object, len(object.data))
+		rw.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		if !ok {
+			writeFakeS3Error(rw, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		data := object.data
+		status := http.StatusOK
+		if header := r.Header.Get("Range"); header != "" {
+			bounds := strings.SplitN(strings.TrimPrefix(header, "bytes="), "-", 2)
+			start, _ := strconv.Atoi(bounds[0])
+			end := len(data) - 1
+			if bounds[1] != "" {
+				end, _ = strconv.Atoi(bounds[1])
+			}
+			if start >= len(data) {
+				writeFakeS3Error(rw, http.StatusRequestedRangeNotSatisfiable, "InvalidRange")
+				return
+			}
+			if end >= len(data) {
+				end = len(data) - 1
+			}
+			data = data[start : end+1]
+			status = http.StatusPartialContent
+		}
+		s.writeHeaders(rw, object, len(data))
+		rw.WriteHeader(status)
+		_, _ = rw.Write(data)
+	default:
+		writeFakeS3Error(rw, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func (s *fakeS3) writeHeaders(rw http.ResponseWriter, object fakeS3Object, length int) {
+	rw.Header().Set("Content-Length", strconv.Itoa(length))
+	rw.Header().Set("ETag", `"`+object.etag+`"`)
+	rw.Header().Set("Last-Modified", object.lastModified.Format(http.TimeFormat))
+}
+
+func (s *fakeS3) list(rw http.ResponseWriter, prefix string) {
+	result := fakeS3List{Name: s.bucket, Prefix: prefix}
+	for key, object := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			result.Contents = append(result.Contents, fakeS3ListObject{
+				Key:          key,
+				Size:         int64(len(object.data)),
+				LastModified: object.lastModified.Format(time.RFC3339),
+				ETag:         `"` + object.etag + `"`,
+			})
+		}
+	}
+	//S3 отдает ключи по алфавиту
+	sort.Slice(result.Contents, func(i, j int) bool {
+		return result.Contents[i].Key < result.Contents[j].Key
+	})
+	result.KeyCount = len(result.Contents)
+	rw.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(rw).Encode(result)
+}
+
+func writeFakeS3Error(rw http.ResponseWriter, status int, code string) {
+	rw.Header().Set("Content-Type", "application/xml")
+	rw.WriteHeader(status)
+	_ = xml.NewEncoder(rw).Encode(fakeS3Error{Code: code, Message: code})
+}
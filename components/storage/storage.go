@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"strings"
+	"time"
+)
+
+const DriverS3 = "s3"
+const DriverFilesystem = "filesystem"
+const DriverMemory = "memory"
+
+var ErrNotFound = errors.New("object not found")
+
+//интерфейс хранилища файлов. хендлеры и процессор работают только с ним
+//и не знают куда на самом деле попадают файлы. это может быть S3, папка на диске или память
+//новое хранилище добавляеться реализацией этого интерфейса и веткой в NewBlobStore
+type BlobStore interface {
+	//сохраняет файл по ключу и возвращает ссылку на него
+	Put(key string, body io.Reader) (string, error)
+	//возвращает содержимое файла. закрывать ридер должен тот кто его получил
+	Get(key string) (io.ReadCloser, error)
+	//часть файла длиной length начиная с offset, length <= 0 значит до конца файла
+	GetRange(key string, offset int64, length int64) (io.ReadCloser, error)
+	Delete(key string) error
+	Stat(key string) (ObjectInfo, error)
+	List(prefix string) ([]ObjectInfo, error)
+	//ссылка по которой файл доступен снаружи
+	URL(key string) string
+}
+
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+	//метка содержимого без кавычек, меняеться вместе с файлом. у S3 это его ETag,
+	//остальные хранилища считают хеш при загрузке, так для проверки кеша файл читать не нужно
+	ETag string
+	//метка не по содержимому, например по времени изменения и размеру. отдаеться как W/"...",
+	//такой метке нельзя верить при склейке кусков файла
+	WeakETag bool
+}
+
+//служебные файлы хранилищ, например ETag и недописанные файлы файловой системы, начинаються с точки
+//они лежат рядом с обычными, но наружу отдаваться не должны
+func IsInternalKey(key string) bool {
+	for _, part := range strings.Split(key, "/") {
+		if strings.HasPrefix(part, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+//метка из хеша содержимого, одинаковые байты дают одинаковую метку
+func etagOf(h hash.Hash) string {
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+//выбирает хранилище по Config.Driver
+func NewBlobStore(config Config) (BlobStore, error) {
+	switch config.Driver {
+	case DriverS3:
+		if config.S3Session == nil {
+			return nil, errors.New("s3 session is not configured")
+		}
+		return NewS3Store(config.S3Session, config.Bucket), nil
+	case DriverFilesystem:
+		return NewFilesystemStore(config.Dir, config.BaseURL)
+	case DriverMemory:
+		return NewMemoryStore(), nil
+	default:
+		return nil, errors.New(config.Driver + " storage driver is not supported")
+	}
+}
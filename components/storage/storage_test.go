@@ -0,0 +1,243 @@
+package storage
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+//каждый тест прогоняеться на всех хранилищах, S3 заменяеться бакетом в памяти за http сервером
+func testStores(t *testing.T) map[string]BlobStore {
+	dir, err := ioutil.TempDir("", "storage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+	})
+	filesystem, err := NewFilesystemStore(dir, "http://localhost/files/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]BlobStore{
+		DriverMemory:     NewMemoryStore(),
+		DriverFilesystem: filesystem,
+		DriverS3:         newTestS3Store(t),
+	}
+}
+
+func readObject(t *testing.T, store BlobStore, key string) []byte {
+	object, err := store.Get(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = object.Close()
+	}()
+	data, err := ioutil.ReadAll(object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestPutGetStatDelete(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		data []byte
+	}{
+		{"file", "image.png", []byte("image data")},
+		{"nested key", "thumbs/200/image.png", []byte("thumb data")},
+		{"empty file", "empty.bin", []byte{}},
+	}
+	for driver, store := range testStores(t) {
+		for _, test := range tests {
+			t.Run(driver+"/"+test.name, func(t *testing.T) {
+				location, err := store.Put(test.key, bytes.NewReader(test.data))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if location != store.URL(test.key) {
+					t.Fatalf("location %q, want %q", location, store.URL(test.key))
+				}
+				if data := readObject(t, store, test.key); !bytes.Equal(data, test.data) {
+					t.Fatalf("data %q, want %q", data, test.data)
+				}
+
+				info, err := store.Stat(test.key)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if info.Key != test.key || info.Size != int64(len(test.data)) || info.LastModified.IsZero() || info.ETag == "" {
+					t.Fatalf("info %+v", info)
+				}
+
+				err = store.Delete(test.key)
+				if err != nil {
+					t.Fatal(err)
+				}
+				_, err = store.Stat(test.key)
+				if err != ErrNotFound {
+					t.Fatalf("Stat after Delete: %v, want %v", err, ErrNotFound)
+				}
+			})
+		}
+	}
+}
+
+func TestOverwrite(t *testing.T) {
+	for driver, store := range testStores(t) {
+		t.Run(driver, func(t *testing.T) {
+			_, err := store.Put("image.png", bytes.NewReader([]byte("first")))
+			if err != nil {
+				t.Fatal(err)
+			}
+			first, err := store.Stat("image.png")
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = store.Put("image.png", bytes.NewReader([]byte("second version")))
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := store.Stat("image.png")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data := readObject(t, store, "image.png"); string(data) != "second version" {
+				t.Fatalf("data %q", data)
+			}
+			if second.Size != int64(len("second version")) || second.ETag == first.ETag {
+				t.Fatalf("info after overwrite %+v, before %+v", second, first)
+			}
+		})
+	}
+}
+
+func TestMissingKey(t *testing.T) {
+	for driver, store := range testStores(t) {
+		t.Run(driver, func(t *testing.T) {
+			_, err := store.Get("missing.png")
+			if err != ErrNotFound {
+				t.Fatalf("Get: %v, want %v", err, ErrNotFound)
+			}
+			_, err = store.GetRange("missing.png", 0, 10)
+			if err != ErrNotFound {
+				t.Fatalf("GetRange: %v, want %v", err, ErrNotFound)
+			}
+			_, err = store.Stat("missing.png")
+			if err != ErrNotFound {
+				t.Fatalf("Stat: %v, want %v", err, ErrNotFound)
+			}
+			err = store.Delete("missing.png")
+			if err != ErrNotFound {
+				t.Fatalf("Delete: %v, want %v", err, ErrNotFound)
+			}
+		})
+	}
+}
+
+func TestGetRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset int64
+		length int64
+		want   string
+	}{
+		{"from start", 0, 4, "0123"},
+		{"middle", 3, 4, "3456"},
+		{"to the end", 6, 0, "6789"},
+		{"longer than file", 8, 10, "89"},
+		{"after the end", 10, 5, ""},
+	}
+	for driver, store := range testStores(t) {
+		_, err := store.Put("digits.txt", bytes.NewReader([]byte("0123456789")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, test := range tests {
+			t.Run(driver+"/"+test.name, func(t *testing.T) {
+				object, err := store.GetRange("digits.txt", test.offset, test.length)
+				if err != nil {
+					t.Fatal(err)
+				}
+				data, err := ioutil.ReadAll(object)
+				_ = object.Close()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(data) != test.want {
+					t.Fatalf("data %q, want %q", data, test.want)
+				}
+			})
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	keys := []string{"a.png", "thumbs/a_200.png", "thumbs/a_400.png", "thumbs2/b.png", "watermark.png"}
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"", keys},
+		{"thumbs/", []string{"thumbs/a_200.png", "thumbs/a_400.png"}},
+		{"thumbs", []string{"thumbs/a_200.png", "thumbs/a_400.png", "thumbs2/b.png"}},
+		{"w", []string{"watermark.png"}},
+		{"missing", nil},
+	}
+	for driver, store := range testStores(t) {
+		for _, key := range keys {
+			_, err := store.Put(key, bytes.NewReader([]byte(key)))
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, test := range tests {
+			t.Run(driver+"/"+test.prefix, func(t *testing.T) {
+				objects, err := store.List(test.prefix)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var got []string
+				for _, object := range objects {
+					if object.Size != int64(len(object.Key)) {
+						t.Fatalf("object %+v has wrong size", object)
+					}
+					got = append(got, object.Key)
+				}
+				if !reflect.DeepEqual(got, test.want) {
+					t.Fatalf("keys %v, want %v", got, test.want)
+				}
+			})
+		}
+	}
+}
+
+func TestFilesystemKeyCannotLeaveDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	store, err := NewFilesystemStore(dir+"/store", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.Put("../outside.png", bytes.NewReader([]byte("data")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = os.Stat(dir + "/outside.png")
+	if !os.IsNotExist(err) {
+		t.Fatalf("file is written outside of the store dir: %v", err)
+	}
+	if data := readObject(t, store, "outside.png"); string(data) != "data" {
+		t.Fatalf("data %q", data)
+	}
+}
@@ -0,0 +1,94 @@
+package urlsign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"strconv"
+	"time"
+)
+
+var ErrMissingSignature = errors.New("signature is required")
+var ErrBadSignature = errors.New("signature is invalid")
+var ErrExpired = errors.New("url has expired")
+
+//подпись ссылок на ресайзы на лету. без нее любой может заказать тысячи размеров и занять весь процессор
+type Config struct {
+	//ключ подписи. пустой ключ отключает проверку
+	Key string
+	//соль дописываеться перед подписываемой строкой, ее можно менять не меняя ключ
+	Salt string
+}
+
+func NewConfig(key string, salt string) Config {
+	return Config{
+		Key:  key,
+		Salt: salt,
+	}
+}
+
+func (c Config) Enabled() bool {
+	return c.Key != ""
+}
+
+//подпись ссылки /img/{options}/{image}. expires - unix время после которого ссылка не работает, 0 если ссылка вечная
+//подписываються соль, options, image и expires, каждое поле с длиной впереди. если просто склеить их через /,
+//то options "a/b" с image "c" и options "a" с image "b/c" дали бы одну подпись
+//подпись в base64 без паддинга, так ее можно класть в ссылку без экранирования
+func Sign(config Config, options string, image string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(config.Key))
+	writeField(mac, config.Salt)
+	writeField(mac, options)
+	writeField(mac, image)
+	writeField(mac, strconv.FormatInt(expires, 10))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func writeField(mac hash.Hash, field string) {
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], uint64(len(field)))
+	_, _ = mac.Write(size[:])
+	_, _ = mac.Write([]byte(field))
+}
+
+//проверяет подпись и срок ссылки. если ключа нет, проверка отключена и подходит любая ссылка
+func Verify(config Config, options string, image string, expires int64, signature string, now time.Time) error {
+	if !config.Enabled() {
+		return nil
+	}
+	if signature == "" {
+		return ErrMissingSignature
+	}
+	if !hmac.Equal([]byte(Sign(config, options, image, expires)), []byte(signature)) {
+		return ErrBadSignature
+	}
+	//срок проверяеться после подписи, иначе можно было бы узнать про истекшую ссылку подставив свой срок
+	if expires != 0 && now.Unix() > expires {
+		return ErrExpired
+	}
+	return nil
+}
+
+//подпись ссылки /v2/download/{key}, по ней файл можно скачать без токена владельца
+func SignDownload(config Config, key string, expires int64) string {
+	return Sign(downloadConfig(config), "", key, expires)
+}
+
+func VerifyDownload(config Config, key string, expires int64, signature string, now time.Time) error {
+	return Verify(downloadConfig(config), "", key, expires, signature, now)
+}
+
+//ссылки на скачивание подписываються своим ключом, выведенным из основного
+//так подпись ресайза никак не подходит к скачиванию, даже если параметры и ключ совпадут как строки
+func downloadConfig(config Config) Config {
+	if !config.Enabled() {
+		return config
+	}
+	mac := hmac.New(sha256.New, []byte(config.Key))
+	_, _ = mac.Write([]byte("download"))
+	config.Key = string(mac.Sum(nil))
+	return config
+}
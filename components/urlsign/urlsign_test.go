@@ -0,0 +1,78 @@
+package urlsign
+
+import (
+	"testing"
+	"time"
+)
+
+var testConfig = NewConfig("key", "salt")
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	expires := now.Add(time.Hour).Unix()
+	signature := Sign(testConfig, "w:100,h:50", "images/a.png", 0)
+	expiring := Sign(testConfig, "w:100,h:50", "images/a.png", expires)
+
+	tests := []struct {
+		name      string
+		config    Config
+		options   string
+		image     string
+		expires   int64
+		signature string
+		now       time.Time
+		err       error
+	}{
+		{"round trip", testConfig, "w:100,h:50", "images/a.png", 0, signature, now, nil},
+		{"round trip with expiry", testConfig, "w:100,h:50", "images/a.png", expires, expiring, now, nil},
+		{"expired", testConfig, "w:100,h:50", "images/a.png", expires, expiring, now.Add(2 * time.Hour), ErrExpired},
+		{"expiry removed", testConfig, "w:100,h:50", "images/a.png", 0, expiring, now, ErrBadSignature},
+		{"expiry extended", testConfig, "w:100,h:50", "images/a.png", expires + 3600, expiring, now, ErrBadSignature},
+		{"tampered options", testConfig, "w:1000,h:50", "images/a.png", 0, signature, now, ErrBadSignature},
+		{"tampered image", testConfig, "w:100,h:50", "images/b.png", 0, signature, now, ErrBadSignature},
+		//без длины полей склейка через / давала ту же строку
+		{"slash moved from image to options", testConfig, "w:100,h:50/images", "a.png", 0, signature, now, ErrBadSignature},
+		{"other salt", NewConfig("key", "other"), "w:100,h:50", "images/a.png", 0, signature, now, ErrBadSignature},
+		{"other key", NewConfig("other", "salt"), "w:100,h:50", "images/a.png", 0, signature, now, ErrBadSignature},
+		{"missing signature", testConfig, "w:100,h:50", "images/a.png", 0, "", now, ErrMissingSignature},
+		{"signing disabled", Config{}, "w:100,h:50", "images/a.png", 0, "", now, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Verify(test.config, test.options, test.image, test.expires, test.signature, test.now)
+			if err != test.err {
+				t.Fatalf("Verify() = %v, want %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestVerifyDownload(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	expires := now.Add(time.Hour).Unix()
+	signature := SignDownload(testConfig, "images/a.png", expires)
+
+	tests := []struct {
+		name      string
+		key       string
+		expires   int64
+		signature string
+		now       time.Time
+		err       error
+	}{
+		{"round trip", "images/a.png", expires, signature, now, nil},
+		{"expired", "images/a.png", expires, signature, now.Add(2 * time.Hour), ErrExpired},
+		{"tampered key", "images/b.png", expires, signature, now, ErrBadSignature},
+		{"tampered expiry", "images/a.png", expires + 1, signature, now, ErrBadSignature},
+		//подпись ресайза с теми же строками не открывает скачивание
+		{"resize signature", "images/a.png", expires, Sign(testConfig, "", "images/a.png", expires), now, ErrBadSignature},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyDownload(testConfig, test.key, test.expires, test.signature, test.now)
+			if err != test.err {
+				t.Fatalf("VerifyDownload() = %v, want %v", err, test.err)
+			}
+		})
+	}
+}
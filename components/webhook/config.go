@@ -0,0 +1,21 @@
+package webhook
+
+import "time"
+
+type Config struct {
+	//ключ которым подписываеться тело запроса. получатель проверяет подпись этим же ключом
+	Secret string
+	//сколько ждать ответа получателя
+	Timeout time.Duration
+	//разрешить адреса внутренних сетей и localhost. только для локального запуска и тестов,
+	//иначе через callbackUrl можно достучаться до внутренних сервисов и метаданных облака
+	AllowPrivateNetworks bool
+}
+
+func NewConfig(secret string, timeout time.Duration, allowPrivateNetworks bool) Config {
+	return Config{
+		Secret:               secret,
+		Timeout:              timeout,
+		AllowPrivateNetworks: allowPrivateNetworks,
+	}
+}
@@ -0,0 +1,213 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const DefaultTimeout = 10 * time.Second
+
+//заголовок с подписью тела запроса в виде sha256=<hex hmac>
+const SignatureHeader = "X-Signature"
+
+//заголовок с идентификатором задачи, что бы получатель мог отличить повторную доставку
+const ExecutionHeader = "X-Execution"
+
+//заголовок с номером попытки доставки
+const AttemptHeader = "X-Delivery-Attempt"
+
+const signaturePrefix = "sha256="
+
+var ErrForbiddenAddress = errors.New("callback address is in a private network")
+
+//сети в которые уведомления не отправляються: localhost, внутренние сети, link-local (в том числе
+//169.254.169.254 с метаданными облака) и адреса которые означают "любой" или "этот" хост
+var forbiddenNetworks = parseNetworks(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+//отправляет подписанные уведомления о задачах
+type Client struct {
+	config     Config
+	httpClient *http.Client
+}
+
+func NewClient(config Config) *Client {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	//адрес проверяеться еще раз при соединении, уже после того как имя разрешено в ip,
+	//так не помогут ни редиректы, ни dns который при проверке отдал один адрес, а при отправке другой
+	dialer := &net.Dialer{Timeout: config.Timeout}
+	if !config.AllowPrivateNetworks {
+		dialer.Control = func(network string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if isForbidden(net.ParseIP(host)) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+			}
+			return nil
+		}
+	}
+	return &Client{
+		config: config,
+		httpClient: &http.Client{
+			Timeout: config.Timeout,
+			//без прокси, иначе проверялся бы адрес прокси, а не получателя
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, network string, address string) (net.Conn, error) {
+					return dialer.DialContext(ctx, network, address)
+				},
+				TLSHandshakeTimeout: config.Timeout,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+			},
+		},
+	}
+}
+
+//ошибка доставки. StatusCode равен 0 если получатель не ответил
+type Error struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *Error) Error() string {
+	if e.StatusCode == 0 {
+		return e.Err.Error()
+	}
+	return "webhook receiver responded with " + strconv.Itoa(e.StatusCode)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+//получатель отверг запрос и повтор ничего не изменит
+//429 и 408 означают что получатель временно не может принять запрос
+func (e *Error) Permanent() bool {
+	if errors.Is(e.Err, ErrForbiddenAddress) {
+		return true
+	}
+	if e.StatusCode == http.StatusTooManyRequests || e.StatusCode == http.StatusRequestTimeout {
+		return false
+	}
+	return e.StatusCode >= 400 && e.StatusCode < 500
+}
+
+//отправляет body методом POST. успешным считаеться любой ответ 2xx
+//возвращает код ответа, 0 если ответа не было
+func (c *Client) Post(callbackURL string, executionId string, attempt int, body []byte) (int, error) {
+	request, err := http.NewRequest(http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, &Error{Err: err}
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(SignatureHeader, Sign(c.config.Secret, body))
+	request.Header.Set(ExecutionHeader, executionId)
+	request.Header.Set(AttemptHeader, strconv.Itoa(attempt))
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return 0, &Error{Err: err}
+	}
+	//тело ответа дочитываем что бы соединение можно было переиспользовать
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(response.Body, 64*1024))
+	_ = response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, &Error{StatusCode: response.StatusCode, Err: fmt.Errorf("unexpected status %d", response.StatusCode)}
+	}
+	return response.StatusCode, nil
+}
+
+//подпись тела запроса для заголовка SignatureHeader
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+//проверяет подпись из заголовка SignatureHeader. пригодиться получателям написанным на go
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+//принимаються только абсолютные http и https адреса, которые не ведут во внутренние сети
+//имя разрешаеться сразу, адрес не примется если хотя бы один из его ip запрещен
+func ValidateURL(callbackURL string, allowPrivateNetworks bool) error {
+	parsed, err := url.Parse(callbackURL)
+	if err != nil {
+		return err
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return fmt.Errorf("callback url %q must be an absolute http or https url", callbackURL)
+	}
+	if allowPrivateNetworks {
+		return nil
+	}
+	ips, err := net.LookupIP(parsed.Hostname())
+	if err != nil {
+		return fmt.Errorf("callback url %q: %w", callbackURL, err)
+	}
+	for _, ip := range ips {
+		if isForbidden(ip) {
+			return fmt.Errorf("callback url %q: %w: %s", callbackURL, ErrForbiddenAddress, ip)
+		}
+	}
+	return nil
+}
+
+//ValidateURL с настройками клиента
+func (c *Client) ValidateURL(callbackURL string) error {
+	return ValidateURL(callbackURL, c.config.AllowPrivateNetworks)
+}
+
+func isForbidden(ip net.IP) bool {
+	if ip == nil {
+		return true
+	}
+	for _, network := range forbiddenNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	result := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		result = append(result, network)
+	}
+	return result
+}
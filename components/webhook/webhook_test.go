@@ -0,0 +1,163 @@
+package webhook
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSignVerify(t *testing.T) {
+	body := []byte(`{"uuid":"task"}`)
+	signature := Sign("secret", body)
+	if signature[:len(signaturePrefix)] != signaturePrefix {
+		t.Fatalf("signature %q has no %q prefix", signature, signaturePrefix)
+	}
+	if signature != Sign("secret", body) {
+		t.Fatal("signature of the same body is not stable")
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		body      []byte
+		signature string
+		valid     bool
+	}{
+		{"same secret and body", "secret", body, signature, true},
+		{"other secret", "other", body, signature, false},
+		{"changed body", "secret", []byte(`{"uuid":"other"}`), signature, false},
+		{"without prefix", "secret", body, signature[len(signaturePrefix):], false},
+		{"empty signature", "secret", body, "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if Verify(test.secret, test.body, test.signature) != test.valid {
+				t.Fatalf("Verify() = %v, want %v", !test.valid, test.valid)
+			}
+		})
+	}
+}
+
+func TestPost(t *testing.T) {
+	body := []byte(`{"uuid":"task","Status":"done"}`)
+	var received *http.Request
+	var receivedBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	client := NewClient(NewConfig("secret", 0, true))
+	statusCode, err := client.Post(server.URL, "task", 2, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statusCode != http.StatusOK {
+		t.Fatalf("status code %d, want 200", statusCode)
+	}
+	if received.Method != http.MethodPost {
+		t.Fatalf("method %s, want POST", received.Method)
+	}
+	if string(receivedBody) != string(body) {
+		t.Fatalf("body %s, want %s", receivedBody, body)
+	}
+	if !Verify("secret", receivedBody, received.Header.Get(SignatureHeader)) {
+		t.Fatalf("signature %q is not valid", received.Header.Get(SignatureHeader))
+	}
+	if received.Header.Get(ExecutionHeader) != "task" || received.Header.Get(AttemptHeader) != "2" {
+		t.Fatalf("execution %q attempt %q", received.Header.Get(ExecutionHeader), received.Header.Get(AttemptHeader))
+	}
+}
+
+func TestPostErrors(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		permanent  bool
+	}{
+		{http.StatusBadRequest, true},
+		{http.StatusNotFound, true},
+		{http.StatusRequestTimeout, false},
+		{http.StatusTooManyRequests, false},
+		{http.StatusInternalServerError, false},
+		{http.StatusBadGateway, false},
+	}
+	for _, test := range tests {
+		t.Run(http.StatusText(test.statusCode), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.statusCode)
+			}))
+			defer server.Close()
+
+			statusCode, err := NewClient(NewConfig("secret", 0, true)).Post(server.URL, "task", 1, []byte("{}"))
+			if statusCode != test.statusCode {
+				t.Fatalf("status code %d, want %d", statusCode, test.statusCode)
+			}
+			var webhookErr *Error
+			if !errors.As(err, &webhookErr) {
+				t.Fatalf("error %v is not *Error", err)
+			}
+			if webhookErr.Permanent() != test.permanent {
+				t.Fatalf("Permanent() = %v, want %v", webhookErr.Permanent(), test.permanent)
+			}
+		})
+	}
+}
+
+func TestPostPrivateNetwork(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	statusCode, err := NewClient(NewConfig("secret", 0, false)).Post(server.URL, "task", 1, []byte("{}"))
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Fatalf("error %v, want %v", err, ErrForbiddenAddress)
+	}
+	var webhookErr *Error
+	if !errors.As(err, &webhookErr) || !webhookErr.Permanent() {
+		t.Fatalf("error %v must be permanent", err)
+	}
+	if statusCode != 0 || requests != 0 {
+		t.Fatalf("request reached the server: status code %d, requests %d", statusCode, requests)
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url   string
+		valid bool
+	}{
+		{"http://93.184.216.34/hook", true},
+		{"https://93.184.216.34:8443/hook", true},
+		{"ftp://93.184.216.34/hook", false},
+		{"/hook", false},
+		{"http://127.0.0.1/hook", false},
+		{"http://localhost/hook", false},
+		{"http://[::1]/hook", false},
+		{"http://[::ffff:127.0.0.1]/hook", false},
+		{"http://10.0.0.1/hook", false},
+		{"http://172.16.5.4/hook", false},
+		{"http://192.168.1.1/hook", false},
+		{"http://169.254.169.254/latest/meta-data", false},
+		{"http://[fe80::1]/hook", false},
+		{"http://0.0.0.0/hook", false},
+		{"http://[::]/hook", false},
+	}
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			err := ValidateURL(test.url, false)
+			if (err == nil) != test.valid {
+				t.Fatalf("ValidateURL() error = %v, want valid %v", err, test.valid)
+			}
+		})
+	}
+
+	err := ValidateURL("http://127.0.0.1/hook", true)
+	if err != nil {
+		t.Fatalf("private networks are allowed, got %v", err)
+	}
+}
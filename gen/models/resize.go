@@ -0,0 +1,154 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// Resize Error
+//
+// swagger:model Resize
+type Resize struct {
+
+	// how many times the task was started
+	Attempts int64 `json:"attempts,omitempty"`
+
+	// error of the last failed attempt
+	Error string `json:"error,omitempty"`
+
+	// original
+	Original string `json:"original,omitempty"`
+
+	// resized
+	Resized string `json:"resized,omitempty"`
+
+	// sizes
+	Sizes []*ResizeSize `json:"sizes,omitempty"`
+
+	// in_progress while the task is queued, running or waiting for a retry
+	// Enum: [in_progress done error dead cancelled]
+	Status string `json:"status,omitempty"`
+}
+
+// Validate validates this resize
+func (m *Resize) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateSizes(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Resize) validateSizes(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Sizes) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Sizes); i++ {
+		if swag.IsZero(m.Sizes[i]) { // not required
+			continue
+		}
+
+		if m.Sizes[i] != nil {
+			if err := m.Sizes[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("sizes" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+var resizeTypeStatusPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["in_progress","done","error","dead","cancelled"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		resizeTypeStatusPropEnum = append(resizeTypeStatusPropEnum, v)
+	}
+}
+
+const (
+
+	// ResizeStatusInProgress captures enum value "in_progress"
+	ResizeStatusInProgress string = "in_progress"
+
+	// ResizeStatusDone captures enum value "done"
+	ResizeStatusDone string = "done"
+
+	// ResizeStatusError captures enum value "error"
+	ResizeStatusError string = "error"
+
+	// ResizeStatusDead captures enum value "dead"
+	ResizeStatusDead string = "dead"
+
+	// ResizeStatusCancelled captures enum value "cancelled"
+	ResizeStatusCancelled string = "cancelled"
+)
+
+// prop value enum
+func (m *Resize) validateStatusEnum(path, location string, value string) error {
+	if err := validate.Enum(path, location, value, resizeTypeStatusPropEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *Resize) validateStatus(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Status) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateStatusEnum("status", "body", m.Status); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *Resize) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *Resize) UnmarshalBinary(b []byte) error {
+	var res Resize
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,49 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// ResizeSize Result of one width of a batch resize
+//
+// swagger:model ResizeSize
+type ResizeSize struct {
+
+	// height
+	Height int64 `json:"height,omitempty"`
+
+	// resize
+	Resize int64 `json:"resize,omitempty"`
+
+	// resized
+	Resized string `json:"resized,omitempty"`
+}
+
+// Validate validates this resize size
+func (m *ResizeSize) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ResizeSize) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ResizeSize) UnmarshalBinary(b []byte) error {
+	var res ResizeSize
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -28,6 +28,74 @@ func init() {
     "version": "1.0.0"
   },
   "paths": {
+    "/img/{options}/{image}": {
+      "get": {
+        "produces": [
+          "application/json",
+          "image/jpeg",
+          "image/png",
+          "image/gif",
+          "image/bmp",
+          "image/tiff"
+        ],
+        "operationId": "transform",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Transformation options: comma separated key:value pairs, e.g. w:200,h:100,m:fill,f:jpeg,q:80. Width and height are at most 8192 and the result and the source image are at most 50000000 pixels",
+            "name": "options",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Id of an uploaded image",
+            "name": "image",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "HMAC-SHA256 signature of the url. Required when url signing is enabled",
+            "name": "signature",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Unix time after which a signed url stops working",
+            "name": "expires",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "transformed image",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
     "/token": {
       "get": {
         "produces": [
@@ -111,11 +179,126 @@ func init() {
           },
           {
             "type": "integer",
-            "description": "Param of file resize.",
+            "description": "Param of file resize. Width of the result.",
             "name": "resize",
             "in": "formData",
             "required": true
           },
+          {
+            "type": "integer",
+            "description": "Height of the result. With only one of width and height the other side is proportional.",
+            "name": "height",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "fit",
+              "fill",
+              "stretch",
+              "pad"
+            ],
+            "type": "string",
+            "default": "fit",
+            "description": "How to fit the image when both width and height are set.",
+            "name": "mode",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "center",
+              "north",
+              "south",
+              "east",
+              "west",
+              "northeast",
+              "northwest",
+              "southeast",
+              "southwest"
+            ],
+            "type": "string",
+            "default": "center",
+            "description": "Which part of the image to keep in fill mode and where to place it in pad mode.",
+            "name": "gravity",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Padding color in pad mode, rgb, rrggbb or rrggbbaa hex.",
+            "name": "background",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "jpeg",
+              "png",
+              "gif",
+              "bmp",
+              "tiff"
+            ],
+            "type": "string",
+            "description": "Output format, the input's format by default.",
+            "name": "format",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "nearest",
+              "bilinear",
+              "bicubic",
+              "mitchell",
+              "lanczos2",
+              "lanczos3"
+            ],
+            "type": "string",
+            "description": "Resampling filter, the server default if not set.",
+            "name": "filter",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "JPEG quality from 1 to 100.",
+            "name": "quality",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "default",
+              "none",
+              "speed",
+              "best"
+            ],
+            "type": "string",
+            "default": "default",
+            "description": "PNG compression level.",
+            "name": "compression",
+            "in": "formData"
+          },
+          {
+            "maximum": 256,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "GIF palette size from 1 to 256.",
+            "name": "colors",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Use Floyd-Steinberg dithering for GIF.",
+            "name": "dither",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Keep only the first frame of an animated GIF.",
+            "name": "first_frame",
+            "in": "formData"
+          },
           {
             "type": "string",
             "description": "User's token",
@@ -167,10 +350,189 @@ func init() {
           },
           {
             "type": "integer",
-            "description": "Param of file resize.",
+            "description": "Param of file resize. Width of the result. Required when preset is not set.",
             "name": "resize",
-            "in": "formData",
-            "required": true
+            "in": "formData"
+          },
+          {
+            "type": "integer",
+            "description": "Height of the result. With only one of width and height the other side is proportional.",
+            "name": "height",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "fit",
+              "fill",
+              "stretch",
+              "pad"
+            ],
+            "type": "string",
+            "default": "fit",
+            "description": "How to fit the image when both width and height are set.",
+            "name": "mode",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "center",
+              "north",
+              "south",
+              "east",
+              "west",
+              "northeast",
+              "northwest",
+              "southeast",
+              "southwest"
+            ],
+            "type": "string",
+            "default": "center",
+            "description": "Which part of the image to keep in fill mode and where to place it in pad mode.",
+            "name": "gravity",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Padding color in pad mode, rgb, rrggbb or rrggbbaa hex.",
+            "name": "background",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "jpeg",
+              "png",
+              "gif",
+              "bmp",
+              "tiff"
+            ],
+            "type": "string",
+            "description": "Output format, the input's format by default.",
+            "name": "format",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "nearest",
+              "bilinear",
+              "bicubic",
+              "mitchell",
+              "lanczos2",
+              "lanczos3"
+            ],
+            "type": "string",
+            "description": "Resampling filter, the server default if not set.",
+            "name": "filter",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "JPEG quality from 1 to 100.",
+            "name": "quality",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "default",
+              "none",
+              "speed",
+              "best"
+            ],
+            "type": "string",
+            "default": "default",
+            "description": "PNG compression level.",
+            "name": "compression",
+            "in": "formData"
+          },
+          {
+            "maximum": 256,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "GIF palette size from 1 to 256.",
+            "name": "colors",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Use Floyd-Steinberg dithering for GIF.",
+            "name": "dither",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Keep only the first frame of an animated GIF.",
+            "name": "first_frame",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Brightness change after resizing, from -100 to 100 percent.",
+            "name": "brightness",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Contrast change after resizing, from -100 to 100 percent.",
+            "name": "contrast",
+            "in": "formData"
+          },
+          {
+            "maximum": 10,
+            "minimum": 0.1,
+            "type": "number",
+            "description": "Gamma correction after resizing, from 0.1 to 10. Values above 1 brighten midtones.",
+            "name": "gamma",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Saturation change after resizing, from -100 to 100 percent.",
+            "name": "saturation",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Convert the result to grayscale.",
+            "name": "grayscale",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Apply a sepia tone to the result.",
+            "name": "sepia",
+            "in": "formData"
+          },
+          {
+            "maximum": 50,
+            "type": "number",
+            "description": "Gaussian blur sigma in pixels of the result, up to 50.",
+            "name": "blur",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Unsharp mask after resizing: sigma[,amount[,threshold]], e.g. 1,1.5,2. Amount is 1 by default.",
+            "name": "sharpen",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Name of a preset from /v2/presets to use instead of the resize parameters",
+            "name": "preset",
+            "in": "formData"
           }
         ],
         "responses": {
@@ -195,12 +557,12 @@ func init() {
         }
       }
     },
-    "/v2/files": {
+    "/v2/dead": {
       "get": {
         "produces": [
           "application/json"
         ],
-        "operationId": "v2files",
+        "operationId": "dead",
         "parameters": [
           {
             "type": "string",
@@ -212,7 +574,7 @@ func init() {
         ],
         "responses": {
           "200": {
-            "description": "file list",
+            "description": "dead task list",
             "schema": {
               "type": "object"
             }
@@ -232,41 +594,33 @@ func init() {
         }
       }
     },
-    "/v2/resize": {
-      "post": {
-        "consumes": [
-          "multipart/form-data"
-        ],
+    "/v2/deliveries": {
+      "get": {
         "produces": [
           "application/json"
         ],
-        "operationId": "v2resize",
+        "operationId": "deliveries",
         "parameters": [
           {
             "type": "string",
+            "description": "User's token",
             "name": "token",
-            "in": "formData",
+            "in": "query",
             "required": true
           },
           {
             "type": "string",
-            "name": "file",
-            "in": "formData",
-            "required": true
-          },
-          {
-            "type": "integer",
-            "description": "Param of file resize.",
-            "name": "resize",
-            "in": "formData",
+            "description": "Execution id",
+            "name": "execution",
+            "in": "query",
             "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "resize result. execution id",
+            "description": "webhook delivery log",
             "schema": {
-              "type": "string"
+              "type": "object"
             }
           },
           "400": {
@@ -284,41 +638,80 @@ func init() {
         }
       }
     },
-    "/v2/result": {
+    "/v2/download/{key}": {
       "get": {
         "produces": [
-          "application/json"
+          "application/json",
+          "image/jpeg",
+          "image/png",
+          "image/gif",
+          "image/bmp",
+          "image/tiff",
+          "application/octet-stream"
         ],
-        "operationId": "result",
+        "operationId": "download",
         "parameters": [
           {
             "type": "string",
-            "description": "User's token",
-            "name": "token",
-            "in": "query",
+            "description": "Object key: an image id or a resized file name",
+            "name": "key",
+            "in": "path",
             "required": true
           },
           {
             "type": "string",
-            "description": "Uexecution id",
-            "name": "execution",
-            "in": "query",
-            "required": true
+            "description": "User's token. The key must be one of the user's images or their resizes. Not needed for a signed url",
+            "name": "token",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "HMAC-SHA256 signature of the url. Used instead of the token when url signing is enabled",
+            "name": "signature",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Unix time after which a signed url stops working",
+            "name": "expires",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "file resize result",
+            "description": "object content",
             "schema": {
-              "$ref": "#/definitions/Resize"
+              "type": "file"
             }
           },
+          "206": {
+            "description": "requested range of the object",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "304": {
+            "description": "object has not changed"
+          },
           "400": {
             "description": "Bad Request",
             "schema": {
               "$ref": "#/definitions/Error"
             }
           },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
           "500": {
             "description": "Fatal",
             "schema": {
@@ -328,33 +721,31 @@ func init() {
         }
       }
     },
-    "/v2/upload": {
-      "post": {
-        "consumes": [
-          "multipart/form-data"
-        ],
+    "/v2/events": {
+      "get": {
         "produces": [
+          "text/event-stream",
           "application/json"
         ],
-        "operationId": "upload",
+        "operationId": "events",
         "parameters": [
-          {
-            "type": "file",
-            "description": "The file to upload.",
-            "name": "upfile",
-            "in": "formData"
-          },
           {
             "type": "string",
             "description": "User's token",
             "name": "token",
             "in": "query",
             "required": true
+          },
+          {
+            "type": "string",
+            "description": "Execution id. Without it events of all executions of the token are streamed",
+            "name": "execution",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "upload result",
+            "description": "stream of task events",
             "schema": {
               "type": "string"
             }
@@ -373,67 +764,2307 @@ func init() {
           }
         }
       }
-    }
-  },
-  "definitions": {
-    "Error": {
-      "description": "Error",
-      "type": "object",
-      "properties": {
-        "attributes": {
-          "description": "values for error code placeholders",
-          "type": "object",
-          "additionalProperties": {
-            "type": "string"
-          }
-        },
-        "code": {
-          "description": "error code",
-          "type": "string"
-        },
-        "detail": {
-          "description": "a human-readable explanation specific to this occurrence of the problem.",
-          "type": "string",
-          "example": "Value of ID must be an integer"
+    },
+    "/v2/files": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "v2files",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "User's token",
+            "name": "token",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "file list",
+            "schema": {
+              "type": "object"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/images/{id}/metadata": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "imageMetadata",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Image id, as returned by /v2/upload or listed in /v2/files",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "image metadata",
+            "schema": {
+              "type": "object"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/presets": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "presets",
+        "responses": {
+          "200": {
+            "description": "preset list",
+            "schema": {
+              "type": "object"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/presets/{name}": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "preset",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Preset name: lowercase letters, digits, \"-\" and \"_\", up to 64 characters",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "preset",
+            "schema": {
+              "type": "object"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "put": {
+        "consumes": [
+          "multipart/form-data"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "putPreset",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Preset name: lowercase letters, digits, \"-\" and \"_\", up to 64 characters",
+            "name": "name",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "description": "Width of the result.",
+            "name": "resize",
+            "in": "formData"
+          },
+          {
+            "type": "integer",
+            "description": "Height of the result. With only one of width and height the other side is proportional.",
+            "name": "height",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "fit",
+              "fill",
+              "stretch",
+              "pad"
+            ],
+            "type": "string",
+            "default": "fit",
+            "description": "How to fit the image when both width and height are set.",
+            "name": "mode",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "center",
+              "north",
+              "south",
+              "east",
+              "west",
+              "northeast",
+              "northwest",
+              "southeast",
+              "southwest"
+            ],
+            "type": "string",
+            "default": "center",
+            "description": "Which part of the image to keep in fill mode and where to place it in pad mode.",
+            "name": "gravity",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Padding color in pad mode and for the corners of a rotated image, rgb, rrggbb or rrggbbaa hex.",
+            "name": "background",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "jpeg",
+              "png",
+              "gif",
+              "bmp",
+              "tiff"
+            ],
+            "type": "string",
+            "description": "Output format, the input's format by default.",
+            "name": "format",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "nearest",
+              "bilinear",
+              "bicubic",
+              "mitchell",
+              "lanczos2",
+              "lanczos3"
+            ],
+            "type": "string",
+            "description": "Resampling filter, the server default if not set.",
+            "name": "filter",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "JPEG quality from 1 to 100.",
+            "name": "quality",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "default",
+              "none",
+              "speed",
+              "best"
+            ],
+            "type": "string",
+            "default": "default",
+            "description": "PNG compression level.",
+            "name": "compression",
+            "in": "formData"
+          },
+          {
+            "maximum": 256,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "GIF palette size from 1 to 256.",
+            "name": "colors",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Use Floyd-Steinberg dithering for GIF.",
+            "name": "dither",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Keep only the first frame of an animated GIF.",
+            "name": "first_frame",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Rectangle to cut out before resizing: x,y,width,height in pixels of the original.",
+            "name": "crop",
+            "in": "formData"
+          },
+          {
+            "type": "number",
+            "format": "double",
+            "description": "Clockwise rotation in degrees before resizing. Corners are filled with background unless the angle is a multiple of 90.",
+            "name": "rotate",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "horizontal",
+              "vertical",
+              "both"
+            ],
+            "type": "string",
+            "description": "Flip after rotation.",
+            "name": "flip",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Brightness change after resizing, from -100 to 100 percent.",
+            "name": "brightness",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Contrast change after resizing, from -100 to 100 percent.",
+            "name": "contrast",
+            "in": "formData"
+          },
+          {
+            "maximum": 10,
+            "minimum": 0.1,
+            "type": "number",
+            "description": "Gamma correction after resizing, from 0.1 to 10. Values above 1 brighten midtones.",
+            "name": "gamma",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Saturation change after resizing, from -100 to 100 percent.",
+            "name": "saturation",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Convert the result to grayscale.",
+            "name": "grayscale",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Apply a sepia tone to the result.",
+            "name": "sepia",
+            "in": "formData"
+          },
+          {
+            "maximum": 50,
+            "type": "number",
+            "description": "Gaussian blur sigma in pixels of the result, up to 50.",
+            "name": "blur",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Unsharp mask after resizing: sigma[,amount[,threshold]], e.g. 1,1.5,2. Amount is 1 by default.",
+            "name": "sharpen",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Id of an uploaded image drawn over the result. Use either watermark or watermark_text.",
+            "name": "watermark",
+            "in": "formData"
+          },
+          {
+            "maxLength": 200,
+            "type": "string",
+            "description": "Text drawn over the result with the bundled Go Bold font.",
+            "name": "watermark_text",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "default": "ffffff",
+            "description": "Text watermark color as rgb, rrggbb or rrggbbaa.",
+            "name": "watermark_color",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "center",
+              "north",
+              "south",
+              "east",
+              "west",
+              "northeast",
+              "northwest",
+              "southeast",
+              "southwest"
+            ],
+            "type": "string",
+            "default": "southeast",
+            "description": "Where to place the watermark.",
+            "name": "watermark_gravity",
+            "in": "formData"
+          },
+          {
+            "maximum": 1,
+            "exclusiveMinimum": true,
+            "type": "number",
+            "default": 0.25,
+            "description": "Watermark width relative to the result width.",
+            "name": "watermark_scale",
+            "in": "formData"
+          },
+          {
+            "maximum": 1,
+            "exclusiveMinimum": true,
+            "type": "number",
+            "default": 1,
+            "description": "Watermark opacity from 0 to 1.",
+            "name": "watermark_opacity",
+            "in": "formData"
+          },
+          {
+            "type": "integer",
+            "description": "Distance from the edges in pixels of the result. When tiling, the gap between copies.",
+            "name": "watermark_margin",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Repeat the watermark over the whole result. Gravity is ignored.",
+            "name": "watermark_tile",
+            "in": "formData"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "preset",
+            "schema": {
+              "type": "object"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "deletePreset",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Preset name: lowercase letters, digits, \"-\" and \"_\", up to 64 characters",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "deleted preset name",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/requeue": {
+      "post": {
+        "consumes": [
+          "multipart/form-data"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "requeue",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "User's token",
+            "name": "token",
+            "in": "formData",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Execution id",
+            "name": "execution",
+            "in": "formData",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "requeued execution id",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/resize": {
+      "post": {
+        "consumes": [
+          "multipart/form-data"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "v2resize",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "token",
+            "in": "formData",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "file",
+            "in": "formData",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "description": "Param of file resize. Width of the result. Required when sizes is not set.",
+            "name": "resize",
+            "in": "formData"
+          },
+          {
+            "maxItems": 20,
+            "type": "array",
+            "items": {
+              "minimum": 1,
+              "type": "integer"
+            },
+            "collectionFormat": "csv",
+            "description": "Several widths to make from one download of the file, comma separated.",
+            "name": "sizes",
+            "in": "formData"
+          },
+          {
+            "type": "integer",
+            "description": "Height of the result. With only one of width and height the other side is proportional.",
+            "name": "height",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "fit",
+              "fill",
+              "stretch",
+              "pad"
+            ],
+            "type": "string",
+            "default": "fit",
+            "description": "How to fit the image when both width and height are set.",
+            "name": "mode",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "center",
+              "north",
+              "south",
+              "east",
+              "west",
+              "northeast",
+              "northwest",
+              "southeast",
+              "southwest"
+            ],
+            "type": "string",
+            "default": "center",
+            "description": "Which part of the image to keep in fill mode and where to place it in pad mode.",
+            "name": "gravity",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Padding color in pad mode and for the corners of a rotated image, rgb, rrggbb or rrggbbaa hex.",
+            "name": "background",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "jpeg",
+              "png",
+              "gif",
+              "bmp",
+              "tiff"
+            ],
+            "type": "string",
+            "description": "Output format, the input's format by default.",
+            "name": "format",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "nearest",
+              "bilinear",
+              "bicubic",
+              "mitchell",
+              "lanczos2",
+              "lanczos3"
+            ],
+            "type": "string",
+            "description": "Resampling filter, the server default if not set.",
+            "name": "filter",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "JPEG quality from 1 to 100.",
+            "name": "quality",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "default",
+              "none",
+              "speed",
+              "best"
+            ],
+            "type": "string",
+            "default": "default",
+            "description": "PNG compression level.",
+            "name": "compression",
+            "in": "formData"
+          },
+          {
+            "maximum": 256,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "GIF palette size from 1 to 256.",
+            "name": "colors",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Use Floyd-Steinberg dithering for GIF.",
+            "name": "dither",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Keep only the first frame of an animated GIF.",
+            "name": "first_frame",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Rectangle to cut out before resizing: x,y,width,height in pixels of the original.",
+            "name": "crop",
+            "in": "formData"
+          },
+          {
+            "type": "number",
+            "format": "double",
+            "description": "Clockwise rotation in degrees before resizing. Corners are filled with background unless the angle is a multiple of 90.",
+            "name": "rotate",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "horizontal",
+              "vertical",
+              "both"
+            ],
+            "type": "string",
+            "description": "Flip after rotation.",
+            "name": "flip",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Brightness change after resizing, from -100 to 100 percent.",
+            "name": "brightness",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Contrast change after resizing, from -100 to 100 percent.",
+            "name": "contrast",
+            "in": "formData"
+          },
+          {
+            "maximum": 10,
+            "minimum": 0.1,
+            "type": "number",
+            "description": "Gamma correction after resizing, from 0.1 to 10. Values above 1 brighten midtones.",
+            "name": "gamma",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Saturation change after resizing, from -100 to 100 percent.",
+            "name": "saturation",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Convert the result to grayscale.",
+            "name": "grayscale",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Apply a sepia tone to the result.",
+            "name": "sepia",
+            "in": "formData"
+          },
+          {
+            "maximum": 50,
+            "type": "number",
+            "description": "Gaussian blur sigma in pixels of the result, up to 50.",
+            "name": "blur",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Unsharp mask after resizing: sigma[,amount[,threshold]], e.g. 1,1.5,2. Amount is 1 by default.",
+            "name": "sharpen",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Id of an uploaded image drawn over the result. Use either watermark or watermark_text.",
+            "name": "watermark",
+            "in": "formData"
+          },
+          {
+            "maxLength": 200,
+            "type": "string",
+            "description": "Text drawn over the result with the bundled Go Bold font.",
+            "name": "watermark_text",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "default": "ffffff",
+            "description": "Text watermark color as rgb, rrggbb or rrggbbaa.",
+            "name": "watermark_color",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "center",
+              "north",
+              "south",
+              "east",
+              "west",
+              "northeast",
+              "northwest",
+              "southeast",
+              "southwest"
+            ],
+            "type": "string",
+            "default": "southeast",
+            "description": "Where to place the watermark.",
+            "name": "watermark_gravity",
+            "in": "formData"
+          },
+          {
+            "maximum": 1,
+            "exclusiveMinimum": true,
+            "type": "number",
+            "default": 0.25,
+            "description": "Watermark width relative to the result width.",
+            "name": "watermark_scale",
+            "in": "formData"
+          },
+          {
+            "maximum": 1,
+            "exclusiveMinimum": true,
+            "type": "number",
+            "default": 1,
+            "description": "Watermark opacity from 0 to 1.",
+            "name": "watermark_opacity",
+            "in": "formData"
+          },
+          {
+            "type": "integer",
+            "description": "Distance from the edges in pixels of the result. When tiling, the gap between copies.",
+            "name": "watermark_margin",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Repeat the watermark over the whole result. Gravity is ignored.",
+            "name": "watermark_tile",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "JSON array of operations applied in order, e.g. [{\"op\":\"crop\",\"x\":0,\"y\":0,\"width\":100,\"height\":100},{\"op\":\"resize\",\"width\":50},{\"op\":\"encode\",\"format\":\"jpeg\",\"quality\":80}]. Cannot be combined with the other resize parameters",
+            "name": "pipeline",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Name of a preset from /v2/presets to use instead of the resize parameters",
+            "name": "preset",
+            "in": "formData"
+          },
+          {
+            "maximum": 20,
+            "minimum": 1,
+            "type": "integer",
+            "description": "How many times the task is retried on transient errors.",
+            "name": "max_attempts",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Where to POST the signed task result when the task is finished. The body has id, status, error, attempts, original, resized and sizes like /v2/result",
+            "name": "callback_url",
+            "in": "formData"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "resize result. execution id",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "503": {
+            "description": "Task queue is full, the task is not created. Retry after Retry-After seconds",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "headers": {
+              "Retry-After": {
+                "type": "integer",
+                "description": "Seconds to wait before retrying"
+              }
+            }
+          }
+        }
+      }
+    },
+    "/v2/result": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "result",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "User's token",
+            "name": "token",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Uexecution id",
+            "name": "execution",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "file resize result",
+            "schema": {
+              "$ref": "#/definitions/Resize"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "cancel",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "User's token",
+            "name": "token",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Execution id",
+            "name": "execution",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "cancelled execution id",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/upload": {
+      "post": {
+        "consumes": [
+          "multipart/form-data"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "upload",
+        "parameters": [
+          {
+            "type": "file",
+            "description": "The file to upload.",
+            "name": "upfile",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "User's token",
+            "name": "token",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "upload result",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "Error": {
+      "description": "Error",
+      "type": "object",
+      "properties": {
+        "attributes": {
+          "description": "values for error code placeholders",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "code": {
+          "description": "error code",
+          "type": "string"
+        },
+        "detail": {
+          "description": "a human-readable explanation specific to this occurrence of the problem.",
+          "type": "string",
+          "example": "Value of ID must be an integer"
+        }
+      }
+    },
+    "Resize": {
+      "description": "Error",
+      "type": "object",
+      "properties": {
+        "attempts": {
+          "description": "how many times the task was started",
+          "type": "integer"
+        },
+        "error": {
+          "description": "error of the last failed attempt",
+          "type": "string"
+        },
+        "original": {
+          "type": "string"
+        },
+        "resized": {
+          "type": "string"
+        },
+        "sizes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ResizeSize"
+          },
+          "x-omitempty": true
+        },
+        "status": {
+          "description": "in_progress while the task is queued, running or waiting for a retry",
+          "type": "string",
+          "enum": [
+            "in_progress",
+            "done",
+            "error",
+            "dead",
+            "cancelled"
+          ]
+        }
+      }
+    },
+    "ResizeSize": {
+      "description": "Result of one width of a batch resize",
+      "type": "object",
+      "properties": {
+        "height": {
+          "type": "integer"
+        },
+        "resize": {
+          "type": "integer"
+        },
+        "resized": {
+          "type": "string"
+        }
+      }
+    }
+  }
+}`))
+	FlatSwaggerJSON = json.RawMessage([]byte(`{
+  "consumes": [
+    "application/json",
+    "multipart/form-data"
+  ],
+  "swagger": "2.0",
+  "info": {
+    "title": "apimediaservice",
+    "version": "1.0.0"
+  },
+  "paths": {
+    "/img/{options}/{image}": {
+      "get": {
+        "produces": [
+          "application/json",
+          "image/bmp",
+          "image/gif",
+          "image/jpeg",
+          "image/png",
+          "image/tiff"
+        ],
+        "operationId": "transform",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Transformation options: comma separated key:value pairs, e.g. w:200,h:100,m:fill,f:jpeg,q:80. Width and height are at most 8192 and the result and the source image are at most 50000000 pixels",
+            "name": "options",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Id of an uploaded image",
+            "name": "image",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "HMAC-SHA256 signature of the url. Required when url signing is enabled",
+            "name": "signature",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Unix time after which a signed url stops working",
+            "name": "expires",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "transformed image",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/token": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "token",
+        "responses": {
+          "200": {
+            "description": "get token",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/v1/files": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "files",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "User's token",
+            "name": "token",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "file list",
+            "schema": {
+              "type": "object"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/v1/resize": {
+      "post": {
+        "consumes": [
+          "multipart/form-data"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "resize",
+        "parameters": [
+          {
+            "type": "file",
+            "description": "The file to upload.",
+            "name": "upfile",
+            "in": "formData"
+          },
+          {
+            "type": "integer",
+            "description": "Param of file resize. Width of the result.",
+            "name": "resize",
+            "in": "formData",
+            "required": true
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "Height of the result. With only one of width and height the other side is proportional.",
+            "name": "height",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "fit",
+              "fill",
+              "stretch",
+              "pad"
+            ],
+            "type": "string",
+            "default": "fit",
+            "description": "How to fit the image when both width and height are set.",
+            "name": "mode",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "center",
+              "north",
+              "south",
+              "east",
+              "west",
+              "northeast",
+              "northwest",
+              "southeast",
+              "southwest"
+            ],
+            "type": "string",
+            "default": "center",
+            "description": "Which part of the image to keep in fill mode and where to place it in pad mode.",
+            "name": "gravity",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Padding color in pad mode, rgb, rrggbb or rrggbbaa hex.",
+            "name": "background",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "jpeg",
+              "png",
+              "gif",
+              "bmp",
+              "tiff"
+            ],
+            "type": "string",
+            "description": "Output format, the input's format by default.",
+            "name": "format",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "nearest",
+              "bilinear",
+              "bicubic",
+              "mitchell",
+              "lanczos2",
+              "lanczos3"
+            ],
+            "type": "string",
+            "description": "Resampling filter, the server default if not set.",
+            "name": "filter",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "JPEG quality from 1 to 100.",
+            "name": "quality",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "default",
+              "none",
+              "speed",
+              "best"
+            ],
+            "type": "string",
+            "default": "default",
+            "description": "PNG compression level.",
+            "name": "compression",
+            "in": "formData"
+          },
+          {
+            "maximum": 256,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "GIF palette size from 1 to 256.",
+            "name": "colors",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Use Floyd-Steinberg dithering for GIF.",
+            "name": "dither",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Keep only the first frame of an animated GIF.",
+            "name": "first_frame",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "User's token",
+            "name": "token",
+            "in": "formData",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "resize result",
+            "schema": {
+              "$ref": "#/definitions/Resize"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/v1/resize_exists": {
+      "post": {
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "resize_exists",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "token",
+            "in": "formData",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "file",
+            "in": "formData",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "description": "Param of file resize. Width of the result. Required when preset is not set.",
+            "name": "resize",
+            "in": "formData"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "Height of the result. With only one of width and height the other side is proportional.",
+            "name": "height",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "fit",
+              "fill",
+              "stretch",
+              "pad"
+            ],
+            "type": "string",
+            "default": "fit",
+            "description": "How to fit the image when both width and height are set.",
+            "name": "mode",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "center",
+              "north",
+              "south",
+              "east",
+              "west",
+              "northeast",
+              "northwest",
+              "southeast",
+              "southwest"
+            ],
+            "type": "string",
+            "default": "center",
+            "description": "Which part of the image to keep in fill mode and where to place it in pad mode.",
+            "name": "gravity",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Padding color in pad mode, rgb, rrggbb or rrggbbaa hex.",
+            "name": "background",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "jpeg",
+              "png",
+              "gif",
+              "bmp",
+              "tiff"
+            ],
+            "type": "string",
+            "description": "Output format, the input's format by default.",
+            "name": "format",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "nearest",
+              "bilinear",
+              "bicubic",
+              "mitchell",
+              "lanczos2",
+              "lanczos3"
+            ],
+            "type": "string",
+            "description": "Resampling filter, the server default if not set.",
+            "name": "filter",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "JPEG quality from 1 to 100.",
+            "name": "quality",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "default",
+              "none",
+              "speed",
+              "best"
+            ],
+            "type": "string",
+            "default": "default",
+            "description": "PNG compression level.",
+            "name": "compression",
+            "in": "formData"
+          },
+          {
+            "maximum": 256,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "GIF palette size from 1 to 256.",
+            "name": "colors",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Use Floyd-Steinberg dithering for GIF.",
+            "name": "dither",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Keep only the first frame of an animated GIF.",
+            "name": "first_frame",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Brightness change after resizing, from -100 to 100 percent.",
+            "name": "brightness",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Contrast change after resizing, from -100 to 100 percent.",
+            "name": "contrast",
+            "in": "formData"
+          },
+          {
+            "maximum": 10,
+            "minimum": 0.1,
+            "type": "number",
+            "description": "Gamma correction after resizing, from 0.1 to 10. Values above 1 brighten midtones.",
+            "name": "gamma",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Saturation change after resizing, from -100 to 100 percent.",
+            "name": "saturation",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Convert the result to grayscale.",
+            "name": "grayscale",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Apply a sepia tone to the result.",
+            "name": "sepia",
+            "in": "formData"
+          },
+          {
+            "maximum": 50,
+            "minimum": 0,
+            "type": "number",
+            "description": "Gaussian blur sigma in pixels of the result, up to 50.",
+            "name": "blur",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Unsharp mask after resizing: sigma[,amount[,threshold]], e.g. 1,1.5,2. Amount is 1 by default.",
+            "name": "sharpen",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Name of a preset from /v2/presets to use instead of the resize parameters",
+            "name": "preset",
+            "in": "formData"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "resize result",
+            "schema": {
+              "$ref": "#/definitions/Resize"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/dead": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "dead",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "User's token",
+            "name": "token",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "dead task list",
+            "schema": {
+              "type": "object"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/deliveries": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "deliveries",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "User's token",
+            "name": "token",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Execution id",
+            "name": "execution",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "webhook delivery log",
+            "schema": {
+              "type": "object"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/download/{key}": {
+      "get": {
+        "produces": [
+          "application/json",
+          "application/octet-stream",
+          "image/bmp",
+          "image/gif",
+          "image/jpeg",
+          "image/png",
+          "image/tiff"
+        ],
+        "operationId": "download",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Object key: an image id or a resized file name",
+            "name": "key",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "User's token. The key must be one of the user's images or their resizes. Not needed for a signed url",
+            "name": "token",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "HMAC-SHA256 signature of the url. Used instead of the token when url signing is enabled",
+            "name": "signature",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Unix time after which a signed url stops working",
+            "name": "expires",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "object content",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "206": {
+            "description": "requested range of the object",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "304": {
+            "description": "object has not changed"
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Forbidden",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Not Found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/events": {
+      "get": {
+        "produces": [
+          "application/json",
+          "text/event-stream"
+        ],
+        "operationId": "events",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "User's token",
+            "name": "token",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Execution id. Without it events of all executions of the token are streamed",
+            "name": "execution",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "stream of task events",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/files": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "v2files",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "User's token",
+            "name": "token",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "file list",
+            "schema": {
+              "type": "object"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
       }
     },
-    "Resize": {
-      "description": "Error",
-      "type": "object",
-      "properties": {
-        "original": {
-          "type": "string"
-        },
-        "resized": {
-          "type": "string"
+    "/v2/images/{id}/metadata": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "imageMetadata",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Image id, as returned by /v2/upload or listed in /v2/files",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "image metadata",
+            "schema": {
+              "type": "object"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
       }
-    }
-  }
-}`))
-	FlatSwaggerJSON = json.RawMessage([]byte(`{
-  "consumes": [
-    "application/json",
-    "multipart/form-data"
-  ],
-  "swagger": "2.0",
-  "info": {
-    "title": "apimediaservice",
-    "version": "1.0.0"
-  },
-  "paths": {
-    "/token": {
+    },
+    "/v2/presets": {
       "get": {
         "produces": [
           "application/json"
         ],
-        "operationId": "token",
+        "operationId": "presets",
         "responses": {
           "200": {
-            "description": "get token",
+            "description": "preset list",
             "schema": {
-              "type": "string"
+              "type": "object"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/v2/presets/{name}": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "preset",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Preset name: lowercase letters, digits, \"-\" and \"_\", up to 64 characters",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "preset",
+            "schema": {
+              "type": "object"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Fatal",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "put": {
+        "consumes": [
+          "multipart/form-data"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "operationId": "putPreset",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Preset name: lowercase letters, digits, \"-\" and \"_\", up to 64 characters",
+            "name": "name",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "description": "Width of the result.",
+            "name": "resize",
+            "in": "formData"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "Height of the result. With only one of width and height the other side is proportional.",
+            "name": "height",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "fit",
+              "fill",
+              "stretch",
+              "pad"
+            ],
+            "type": "string",
+            "default": "fit",
+            "description": "How to fit the image when both width and height are set.",
+            "name": "mode",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "center",
+              "north",
+              "south",
+              "east",
+              "west",
+              "northeast",
+              "northwest",
+              "southeast",
+              "southwest"
+            ],
+            "type": "string",
+            "default": "center",
+            "description": "Which part of the image to keep in fill mode and where to place it in pad mode.",
+            "name": "gravity",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Padding color in pad mode and for the corners of a rotated image, rgb, rrggbb or rrggbbaa hex.",
+            "name": "background",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "jpeg",
+              "png",
+              "gif",
+              "bmp",
+              "tiff"
+            ],
+            "type": "string",
+            "description": "Output format, the input's format by default.",
+            "name": "format",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "nearest",
+              "bilinear",
+              "bicubic",
+              "mitchell",
+              "lanczos2",
+              "lanczos3"
+            ],
+            "type": "string",
+            "description": "Resampling filter, the server default if not set.",
+            "name": "filter",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "JPEG quality from 1 to 100.",
+            "name": "quality",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "default",
+              "none",
+              "speed",
+              "best"
+            ],
+            "type": "string",
+            "default": "default",
+            "description": "PNG compression level.",
+            "name": "compression",
+            "in": "formData"
+          },
+          {
+            "maximum": 256,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "GIF palette size from 1 to 256.",
+            "name": "colors",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Use Floyd-Steinberg dithering for GIF.",
+            "name": "dither",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Keep only the first frame of an animated GIF.",
+            "name": "first_frame",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Rectangle to cut out before resizing: x,y,width,height in pixels of the original.",
+            "name": "crop",
+            "in": "formData"
+          },
+          {
+            "type": "number",
+            "format": "double",
+            "description": "Clockwise rotation in degrees before resizing. Corners are filled with background unless the angle is a multiple of 90.",
+            "name": "rotate",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "horizontal",
+              "vertical",
+              "both"
+            ],
+            "type": "string",
+            "description": "Flip after rotation.",
+            "name": "flip",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Brightness change after resizing, from -100 to 100 percent.",
+            "name": "brightness",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Contrast change after resizing, from -100 to 100 percent.",
+            "name": "contrast",
+            "in": "formData"
+          },
+          {
+            "maximum": 10,
+            "minimum": 0.1,
+            "type": "number",
+            "description": "Gamma correction after resizing, from 0.1 to 10. Values above 1 brighten midtones.",
+            "name": "gamma",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Saturation change after resizing, from -100 to 100 percent.",
+            "name": "saturation",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Convert the result to grayscale.",
+            "name": "grayscale",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Apply a sepia tone to the result.",
+            "name": "sepia",
+            "in": "formData"
+          },
+          {
+            "maximum": 50,
+            "minimum": 0,
+            "type": "number",
+            "description": "Gaussian blur sigma in pixels of the result, up to 50.",
+            "name": "blur",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Unsharp mask after resizing: sigma[,amount[,threshold]], e.g. 1,1.5,2. Amount is 1 by default.",
+            "name": "sharpen",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Id of an uploaded image drawn over the result. Use either watermark or watermark_text.",
+            "name": "watermark",
+            "in": "formData"
+          },
+          {
+            "maxLength": 200,
+            "type": "string",
+            "description": "Text drawn over the result with the bundled Go Bold font.",
+            "name": "watermark_text",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "default": "ffffff",
+            "description": "Text watermark color as rgb, rrggbb or rrggbbaa.",
+            "name": "watermark_color",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "center",
+              "north",
+              "south",
+              "east",
+              "west",
+              "northeast",
+              "northwest",
+              "southeast",
+              "southwest"
+            ],
+            "type": "string",
+            "default": "southeast",
+            "description": "Where to place the watermark.",
+            "name": "watermark_gravity",
+            "in": "formData"
+          },
+          {
+            "maximum": 1,
+            "minimum": 0,
+            "exclusiveMinimum": true,
+            "type": "number",
+            "default": 0.25,
+            "description": "Watermark width relative to the result width.",
+            "name": "watermark_scale",
+            "in": "formData"
+          },
+          {
+            "maximum": 1,
+            "minimum": 0,
+            "exclusiveMinimum": true,
+            "type": "number",
+            "default": 1,
+            "description": "Watermark opacity from 0 to 1.",
+            "name": "watermark_opacity",
+            "in": "formData"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "Distance from the edges in pixels of the result. When tiling, the gap between copies.",
+            "name": "watermark_margin",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Repeat the watermark over the whole result. Gravity is ignored.",
+            "name": "watermark_tile",
+            "in": "formData"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "preset",
+            "schema": {
+              "type": "object"
             }
           },
           "400": {
@@ -449,28 +3080,26 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/v1/files": {
-      "get": {
+      },
+      "delete": {
         "produces": [
           "application/json"
         ],
-        "operationId": "files",
+        "operationId": "deletePreset",
         "parameters": [
           {
             "type": "string",
-            "description": "User's token",
-            "name": "token",
-            "in": "query",
+            "description": "Preset name: lowercase letters, digits, \"-\" and \"_\", up to 64 characters",
+            "name": "name",
+            "in": "path",
             "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "file list",
+            "description": "deleted preset name",
             "schema": {
-              "type": "object"
+              "type": "string"
             }
           },
           "400": {
@@ -488,7 +3117,7 @@ func init() {
         }
       }
     },
-    "/v1/resize": {
+    "/v2/requeue": {
       "post": {
         "consumes": [
           "multipart/form-data"
@@ -496,34 +3125,28 @@ func init() {
         "produces": [
           "application/json"
         ],
-        "operationId": "resize",
+        "operationId": "requeue",
         "parameters": [
           {
-            "type": "file",
-            "description": "The file to upload.",
-            "name": "upfile",
-            "in": "formData"
-          },
-          {
-            "type": "integer",
-            "description": "Param of file resize.",
-            "name": "resize",
+            "type": "string",
+            "description": "User's token",
+            "name": "token",
             "in": "formData",
             "required": true
           },
           {
             "type": "string",
-            "description": "User's token",
-            "name": "token",
+            "description": "Execution id",
+            "name": "execution",
             "in": "formData",
             "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "resize result",
+            "description": "requeued execution id",
             "schema": {
-              "$ref": "#/definitions/Resize"
+              "type": "string"
             }
           },
           "400": {
@@ -541,12 +3164,15 @@ func init() {
         }
       }
     },
-    "/v1/resize_exists": {
+    "/v2/resize": {
       "post": {
+        "consumes": [
+          "multipart/form-data"
+        ],
         "produces": [
           "application/json"
         ],
-        "operationId": "resize_exists",
+        "operationId": "v2resize",
         "parameters": [
           {
             "type": "string",
@@ -562,54 +3188,326 @@ func init() {
           },
           {
             "type": "integer",
-            "description": "Param of file resize.",
+            "description": "Param of file resize. Width of the result. Required when sizes is not set.",
             "name": "resize",
-            "in": "formData",
-            "required": true
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "resize result",
-            "schema": {
-              "$ref": "#/definitions/Resize"
-            }
+            "in": "formData"
           },
-          "400": {
-            "description": "Bad Request",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
+          {
+            "maxItems": 20,
+            "type": "array",
+            "items": {
+              "minimum": 1,
+              "type": "integer"
+            },
+            "collectionFormat": "csv",
+            "description": "Several widths to make from one download of the file, comma separated.",
+            "name": "sizes",
+            "in": "formData"
           },
-          "500": {
-            "description": "Fatal",
-            "schema": {
-              "$ref": "#/definitions/Error"
-            }
-          }
-        }
-      }
-    },
-    "/v2/files": {
-      "get": {
-        "produces": [
-          "application/json"
-        ],
-        "operationId": "v2files",
-        "parameters": [
           {
+            "minimum": 0,
+            "type": "integer",
+            "description": "Height of the result. With only one of width and height the other side is proportional.",
+            "name": "height",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "fit",
+              "fill",
+              "stretch",
+              "pad"
+            ],
             "type": "string",
-            "description": "User's token",
-            "name": "token",
-            "in": "query",
-            "required": true
+            "default": "fit",
+            "description": "How to fit the image when both width and height are set.",
+            "name": "mode",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "center",
+              "north",
+              "south",
+              "east",
+              "west",
+              "northeast",
+              "northwest",
+              "southeast",
+              "southwest"
+            ],
+            "type": "string",
+            "default": "center",
+            "description": "Which part of the image to keep in fill mode and where to place it in pad mode.",
+            "name": "gravity",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Padding color in pad mode and for the corners of a rotated image, rgb, rrggbb or rrggbbaa hex.",
+            "name": "background",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "jpeg",
+              "png",
+              "gif",
+              "bmp",
+              "tiff"
+            ],
+            "type": "string",
+            "description": "Output format, the input's format by default.",
+            "name": "format",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "nearest",
+              "bilinear",
+              "bicubic",
+              "mitchell",
+              "lanczos2",
+              "lanczos3"
+            ],
+            "type": "string",
+            "description": "Resampling filter, the server default if not set.",
+            "name": "filter",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "JPEG quality from 1 to 100.",
+            "name": "quality",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "default",
+              "none",
+              "speed",
+              "best"
+            ],
+            "type": "string",
+            "default": "default",
+            "description": "PNG compression level.",
+            "name": "compression",
+            "in": "formData"
+          },
+          {
+            "maximum": 256,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "GIF palette size from 1 to 256.",
+            "name": "colors",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Use Floyd-Steinberg dithering for GIF.",
+            "name": "dither",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Keep only the first frame of an animated GIF.",
+            "name": "first_frame",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Rectangle to cut out before resizing: x,y,width,height in pixels of the original.",
+            "name": "crop",
+            "in": "formData"
+          },
+          {
+            "type": "number",
+            "format": "double",
+            "description": "Clockwise rotation in degrees before resizing. Corners are filled with background unless the angle is a multiple of 90.",
+            "name": "rotate",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "horizontal",
+              "vertical",
+              "both"
+            ],
+            "type": "string",
+            "description": "Flip after rotation.",
+            "name": "flip",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Brightness change after resizing, from -100 to 100 percent.",
+            "name": "brightness",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Contrast change after resizing, from -100 to 100 percent.",
+            "name": "contrast",
+            "in": "formData"
+          },
+          {
+            "maximum": 10,
+            "minimum": 0.1,
+            "type": "number",
+            "description": "Gamma correction after resizing, from 0.1 to 10. Values above 1 brighten midtones.",
+            "name": "gamma",
+            "in": "formData"
+          },
+          {
+            "maximum": 100,
+            "minimum": -100,
+            "type": "number",
+            "description": "Saturation change after resizing, from -100 to 100 percent.",
+            "name": "saturation",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Convert the result to grayscale.",
+            "name": "grayscale",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Apply a sepia tone to the result.",
+            "name": "sepia",
+            "in": "formData"
+          },
+          {
+            "maximum": 50,
+            "minimum": 0,
+            "type": "number",
+            "description": "Gaussian blur sigma in pixels of the result, up to 50.",
+            "name": "blur",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Unsharp mask after resizing: sigma[,amount[,threshold]], e.g. 1,1.5,2. Amount is 1 by default.",
+            "name": "sharpen",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Id of an uploaded image drawn over the result. Use either watermark or watermark_text.",
+            "name": "watermark",
+            "in": "formData"
+          },
+          {
+            "maxLength": 200,
+            "type": "string",
+            "description": "Text drawn over the result with the bundled Go Bold font.",
+            "name": "watermark_text",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "default": "ffffff",
+            "description": "Text watermark color as rgb, rrggbb or rrggbbaa.",
+            "name": "watermark_color",
+            "in": "formData"
+          },
+          {
+            "enum": [
+              "center",
+              "north",
+              "south",
+              "east",
+              "west",
+              "northeast",
+              "northwest",
+              "southeast",
+              "southwest"
+            ],
+            "type": "string",
+            "default": "southeast",
+            "description": "Where to place the watermark.",
+            "name": "watermark_gravity",
+            "in": "formData"
+          },
+          {
+            "maximum": 1,
+            "minimum": 0,
+            "exclusiveMinimum": true,
+            "type": "number",
+            "default": 0.25,
+            "description": "Watermark width relative to the result width.",
+            "name": "watermark_scale",
+            "in": "formData"
+          },
+          {
+            "maximum": 1,
+            "minimum": 0,
+            "exclusiveMinimum": true,
+            "type": "number",
+            "default": 1,
+            "description": "Watermark opacity from 0 to 1.",
+            "name": "watermark_opacity",
+            "in": "formData"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "Distance from the edges in pixels of the result. When tiling, the gap between copies.",
+            "name": "watermark_margin",
+            "in": "formData"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Repeat the watermark over the whole result. Gravity is ignored.",
+            "name": "watermark_tile",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "JSON array of operations applied in order, e.g. [{\"op\":\"crop\",\"x\":0,\"y\":0,\"width\":100,\"height\":100},{\"op\":\"resize\",\"width\":50},{\"op\":\"encode\",\"format\":\"jpeg\",\"quality\":80}]. Cannot be combined with the other resize parameters",
+            "name": "pipeline",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Name of a preset from /v2/presets to use instead of the resize parameters",
+            "name": "preset",
+            "in": "formData"
+          },
+          {
+            "maximum": 20,
+            "minimum": 1,
+            "type": "integer",
+            "description": "How many times the task is retried on transient errors.",
+            "name": "max_attempts",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Where to POST the signed task result when the task is finished. The body has id, status, error, attempts, original, resized and sizes like /v2/result",
+            "name": "callback_url",
+            "in": "formData"
           }
         ],
         "responses": {
           "200": {
-            "description": "file list",
+            "description": "resize result. execution id",
             "schema": {
-              "type": "object"
+              "type": "string"
             }
           },
           "400": {
@@ -623,45 +3521,49 @@ func init() {
             "schema": {
               "$ref": "#/definitions/Error"
             }
+          },
+          "503": {
+            "description": "Task queue is full, the task is not created. Retry after Retry-After seconds",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "headers": {
+              "Retry-After": {
+                "type": "integer",
+                "description": "Seconds to wait before retrying"
+              }
+            }
           }
         }
       }
     },
-    "/v2/resize": {
-      "post": {
-        "consumes": [
-          "multipart/form-data"
-        ],
+    "/v2/result": {
+      "get": {
         "produces": [
           "application/json"
         ],
-        "operationId": "v2resize",
+        "operationId": "result",
         "parameters": [
           {
             "type": "string",
+            "description": "User's token",
             "name": "token",
-            "in": "formData",
+            "in": "query",
             "required": true
           },
           {
             "type": "string",
-            "name": "file",
-            "in": "formData",
-            "required": true
-          },
-          {
-            "type": "integer",
-            "description": "Param of file resize.",
-            "name": "resize",
-            "in": "formData",
+            "description": "Uexecution id",
+            "name": "execution",
+            "in": "query",
             "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "resize result. execution id",
+            "description": "file resize result",
             "schema": {
-              "type": "string"
+              "$ref": "#/definitions/Resize"
             }
           },
           "400": {
@@ -677,14 +3579,12 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/v2/result": {
-      "get": {
+      },
+      "delete": {
         "produces": [
           "application/json"
         ],
-        "operationId": "result",
+        "operationId": "cancel",
         "parameters": [
           {
             "type": "string",
@@ -695,7 +3595,7 @@ func init() {
           },
           {
             "type": "string",
-            "description": "Uexecution id",
+            "description": "Execution id",
             "name": "execution",
             "in": "query",
             "required": true
@@ -703,9 +3603,9 @@ func init() {
         ],
         "responses": {
           "200": {
-            "description": "file resize result",
+            "description": "cancelled execution id",
             "schema": {
-              "$ref": "#/definitions/Resize"
+              "type": "string"
             }
           },
           "400": {
@@ -797,9 +3697,50 @@ func init() {
       "description": "Error",
       "type": "object",
       "properties": {
+        "attempts": {
+          "description": "how many times the task was started",
+          "type": "integer"
+        },
+        "error": {
+          "description": "error of the last failed attempt",
+          "type": "string"
+        },
         "original": {
           "type": "string"
         },
+        "resized": {
+          "type": "string"
+        },
+        "sizes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ResizeSize"
+          },
+          "x-omitempty": true
+        },
+        "status": {
+          "description": "in_progress while the task is queued, running or waiting for a retry",
+          "type": "string",
+          "enum": [
+            "in_progress",
+            "done",
+            "error",
+            "dead",
+            "cancelled"
+          ]
+        }
+      }
+    },
+    "ResizeSize": {
+      "description": "Result of one width of a batch resize",
+      "type": "object",
+      "properties": {
+        "height": {
+          "type": "integer"
+        },
+        "resize": {
+          "type": "integer"
+        },
         "resized": {
           "type": "string"
         }
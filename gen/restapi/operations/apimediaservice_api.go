@@ -7,6 +7,7 @@ package operations
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 
@@ -38,10 +39,47 @@ func NewApimediaserviceAPI(spec *loads.Document) *ApimediaserviceAPI {
 		BearerAuthenticator:   security.BearerAuth,
 		JSONConsumer:          runtime.JSONConsumer(),
 		MultipartformConsumer: runtime.DiscardConsumer,
+		BinProducer:           runtime.ByteStreamProducer(),
 		JSONProducer:          runtime.JSONProducer(),
+		TextEventStreamProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
+			return errors.NotImplemented("textEventStream producer has not yet been implemented")
+		}),
+		CancelHandler: CancelHandlerFunc(func(params CancelParams) middleware.Responder {
+			return middleware.NotImplemented("operation operations.Cancel has not yet been implemented")
+		}),
+		DeadHandler: DeadHandlerFunc(func(params DeadParams) middleware.Responder {
+			return middleware.NotImplemented("operation operations.Dead has not yet been implemented")
+		}),
+		DeletePresetHandler: DeletePresetHandlerFunc(func(params DeletePresetParams) middleware.Responder {
+			return middleware.NotImplemented("operation operations.DeletePreset has not yet been implemented")
+		}),
+		DeliveriesHandler: DeliveriesHandlerFunc(func(params DeliveriesParams) middleware.Responder {
+			return middleware.NotImplemented("operation operations.Deliveries has not yet been implemented")
+		}),
+		DownloadHandler: DownloadHandlerFunc(func(params DownloadParams) middleware.Responder {
+			return middleware.NotImplemented("operation operations.Download has not yet been implemented")
+		}),
+		EventsHandler: EventsHandlerFunc(func(params EventsParams) middleware.Responder {
+			return middleware.NotImplemented("operation operations.Events has not yet been implemented")
+		}),
 		FilesHandler: FilesHandlerFunc(func(params FilesParams) middleware.Responder {
 			return middleware.NotImplemented("operation operations.Files has not yet been implemented")
 		}),
+		ImageMetadataHandler: ImageMetadataHandlerFunc(func(params ImageMetadataParams) middleware.Responder {
+			return middleware.NotImplemented("operation operations.ImageMetadata has not yet been implemented")
+		}),
+		PresetHandler: PresetHandlerFunc(func(params PresetParams) middleware.Responder {
+			return middleware.NotImplemented("operation operations.Preset has not yet been implemented")
+		}),
+		PresetsHandler: PresetsHandlerFunc(func(params PresetsParams) middleware.Responder {
+			return middleware.NotImplemented("operation operations.Presets has not yet been implemented")
+		}),
+		PutPresetHandler: PutPresetHandlerFunc(func(params PutPresetParams) middleware.Responder {
+			return middleware.NotImplemented("operation operations.PutPreset has not yet been implemented")
+		}),
+		RequeueHandler: RequeueHandlerFunc(func(params RequeueParams) middleware.Responder {
+			return middleware.NotImplemented("operation operations.Requeue has not yet been implemented")
+		}),
 		ResizeHandler: ResizeHandlerFunc(func(params ResizeParams) middleware.Responder {
 			return middleware.NotImplemented("operation operations.Resize has not yet been implemented")
 		}),
@@ -54,6 +92,9 @@ func NewApimediaserviceAPI(spec *loads.Document) *ApimediaserviceAPI {
 		TokenHandler: TokenHandlerFunc(func(params TokenParams) middleware.Responder {
 			return middleware.NotImplemented("operation operations.Token has not yet been implemented")
 		}),
+		TransformHandler: TransformHandlerFunc(func(params TransformParams) middleware.Responder {
+			return middleware.NotImplemented("operation operations.Transform has not yet been implemented")
+		}),
 		UploadHandler: UploadHandlerFunc(func(params UploadParams) middleware.Responder {
 			return middleware.NotImplemented("operation operations.Upload has not yet been implemented")
 		}),
@@ -93,12 +134,45 @@ type ApimediaserviceAPI struct {
 	// MultipartformConsumer registers a consumer for the following mime types:
 	//   - multipart/form-data
 	MultipartformConsumer runtime.Consumer
+	// BinProducer registers a producer for the following mime types:
+	//   - application/octet-stream
+	//   - image/bmp
+	//   - image/gif
+	//   - image/jpeg
+	//   - image/png
+	//   - image/tiff
+	BinProducer runtime.Producer
 	// JSONProducer registers a producer for the following mime types:
 	//   - application/json
 	JSONProducer runtime.Producer
-
+	// TextEventStreamProducer registers a producer for the following mime types:
+	//   - text/event-stream
+	TextEventStreamProducer runtime.Producer
+
+	// CancelHandler sets the operation handler for the cancel operation
+	CancelHandler CancelHandler
+	// DeadHandler sets the operation handler for the dead operation
+	DeadHandler DeadHandler
+	// DeletePresetHandler sets the operation handler for the delete preset operation
+	DeletePresetHandler DeletePresetHandler
+	// DeliveriesHandler sets the operation handler for the deliveries operation
+	DeliveriesHandler DeliveriesHandler
+	// DownloadHandler sets the operation handler for the download operation
+	DownloadHandler DownloadHandler
+	// EventsHandler sets the operation handler for the events operation
+	EventsHandler EventsHandler
 	// FilesHandler sets the operation handler for the files operation
 	FilesHandler FilesHandler
+	// ImageMetadataHandler sets the operation handler for the image metadata operation
+	ImageMetadataHandler ImageMetadataHandler
+	// PresetHandler sets the operation handler for the preset operation
+	PresetHandler PresetHandler
+	// PresetsHandler sets the operation handler for the presets operation
+	PresetsHandler PresetsHandler
+	// PutPresetHandler sets the operation handler for the put preset operation
+	PutPresetHandler PutPresetHandler
+	// RequeueHandler sets the operation handler for the requeue operation
+	RequeueHandler RequeueHandler
 	// ResizeHandler sets the operation handler for the resize operation
 	ResizeHandler ResizeHandler
 	// ResizeExistsHandler sets the operation handler for the resize exists operation
@@ -107,6 +181,8 @@ type ApimediaserviceAPI struct {
 	ResultHandler ResultHandler
 	// TokenHandler sets the operation handler for the token operation
 	TokenHandler TokenHandler
+	// TransformHandler sets the operation handler for the transform operation
+	TransformHandler TransformHandler
 	// UploadHandler sets the operation handler for the upload operation
 	UploadHandler UploadHandler
 	// V2filesHandler sets the operation handler for the v2files operation
@@ -179,14 +255,64 @@ func (o *ApimediaserviceAPI) Validate() error {
 		unregistered = append(unregistered, "MultipartformConsumer")
 	}
 
+	if o.BinProducer == nil {
+		unregistered = append(unregistered, "BinProducer")
+	}
 	if o.JSONProducer == nil {
 		unregistered = append(unregistered, "JSONProducer")
 	}
+	if o.TextEventStreamProducer == nil {
+		unregistered = append(unregistered, "TextEventStreamProducer")
+	}
+
+	if o.CancelHandler == nil {
+		unregistered = append(unregistered, "Operations.CancelHandler")
+	}
+
+	if o.DeadHandler == nil {
+		unregistered = append(unregistered, "Operations.DeadHandler")
+	}
+
+	if o.DeletePresetHandler == nil {
+		unregistered = append(unregistered, "Operations.DeletePresetHandler")
+	}
+
+	if o.DeliveriesHandler == nil {
+		unregistered = append(unregistered, "Operations.DeliveriesHandler")
+	}
+
+	if o.DownloadHandler == nil {
+		unregistered = append(unregistered, "Operations.DownloadHandler")
+	}
+
+	if o.EventsHandler == nil {
+		unregistered = append(unregistered, "Operations.EventsHandler")
+	}
 
 	if o.FilesHandler == nil {
 		unregistered = append(unregistered, "Operations.FilesHandler")
 	}
 
+	if o.ImageMetadataHandler == nil {
+		unregistered = append(unregistered, "Operations.ImageMetadataHandler")
+	}
+
+	if o.PresetHandler == nil {
+		unregistered = append(unregistered, "Operations.PresetHandler")
+	}
+
+	if o.PresetsHandler == nil {
+		unregistered = append(unregistered, "Operations.PresetsHandler")
+	}
+
+	if o.PutPresetHandler == nil {
+		unregistered = append(unregistered, "Operations.PutPresetHandler")
+	}
+
+	if o.RequeueHandler == nil {
+		unregistered = append(unregistered, "Operations.RequeueHandler")
+	}
+
 	if o.ResizeHandler == nil {
 		unregistered = append(unregistered, "Operations.ResizeHandler")
 	}
@@ -203,6 +329,10 @@ func (o *ApimediaserviceAPI) Validate() error {
 		unregistered = append(unregistered, "Operations.TokenHandler")
 	}
 
+	if o.TransformHandler == nil {
+		unregistered = append(unregistered, "Operations.TransformHandler")
+	}
+
 	if o.UploadHandler == nil {
 		unregistered = append(unregistered, "Operations.UploadHandler")
 	}
@@ -266,8 +396,22 @@ func (o *ApimediaserviceAPI) ProducersFor(mediaTypes []string) map[string]runtim
 	result := make(map[string]runtime.Producer, len(mediaTypes))
 	for _, mt := range mediaTypes {
 		switch mt {
+		case "application/octet-stream":
+			result["application/octet-stream"] = o.BinProducer
+		case "image/bmp":
+			result["image/bmp"] = o.BinProducer
+		case "image/gif":
+			result["image/gif"] = o.BinProducer
+		case "image/jpeg":
+			result["image/jpeg"] = o.BinProducer
+		case "image/png":
+			result["image/png"] = o.BinProducer
+		case "image/tiff":
+			result["image/tiff"] = o.BinProducer
 		case "application/json":
 			result["application/json"] = o.JSONProducer
+		case "text/event-stream":
+			result["text/event-stream"] = o.TextEventStreamProducer
 		}
 
 		if p, ok := o.customProducers[mt]; ok {
@@ -309,11 +453,66 @@ func (o *ApimediaserviceAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/v2/result"] = NewCancel(o.context, o.CancelHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/v2/dead"] = NewDead(o.context, o.DeadHandler)
+
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/v2/presets/{name}"] = NewDeletePreset(o.context, o.DeletePresetHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/v2/deliveries"] = NewDeliveries(o.context, o.DeliveriesHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/v2/download/{key}"] = NewDownload(o.context, o.DownloadHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/v2/events"] = NewEvents(o.context, o.EventsHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/v1/files"] = NewFiles(o.context, o.FilesHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/v2/images/{id}/metadata"] = NewImageMetadata(o.context, o.ImageMetadataHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/v2/presets/{name}"] = NewPreset(o.context, o.PresetHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/v2/presets"] = NewPresets(o.context, o.PresetsHandler)
+
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/v2/presets/{name}"] = NewPutPreset(o.context, o.PutPresetHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/v2/requeue"] = NewRequeue(o.context, o.RequeueHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -334,6 +533,11 @@ func (o *ApimediaserviceAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/token"] = NewToken(o.context, o.TokenHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/img/{options}/{image}"] = NewTransform(o.context, o.TransformHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -0,0 +1,113 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+)
+
+// NewCancelParams creates a new CancelParams object
+// no default values defined in spec.
+func NewCancelParams() CancelParams {
+
+	return CancelParams{}
+}
+
+// CancelParams contains all the bound params for the cancel operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters cancel
+type CancelParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*Execution id
+	  Required: true
+	  In: query
+	*/
+	Execution string
+	/*User's token
+	  Required: true
+	  In: query
+	*/
+	Token string
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewCancelParams() beforehand.
+func (o *CancelParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	qs := runtime.Values(r.URL.Query())
+
+	qExecution, qhkExecution, _ := qs.GetOK("execution")
+	if err := o.bindExecution(qExecution, qhkExecution, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qToken, qhkToken, _ := qs.GetOK("token")
+	if err := o.bindToken(qToken, qhkToken, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// bindExecution binds and validates parameter Execution from query.
+func (o *CancelParams) bindExecution(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	if !hasKey {
+		return errors.Required("execution", "query")
+	}
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+	// AllowEmptyValue: false
+	if err := validate.RequiredString("execution", "query", raw); err != nil {
+		return err
+	}
+
+	o.Execution = raw
+
+	return nil
+}
+
+// bindToken binds and validates parameter Token from query.
+func (o *CancelParams) bindToken(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	if !hasKey {
+		return errors.Required("token", "query")
+	}
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+	// AllowEmptyValue: false
+	if err := validate.RequiredString("token", "query", raw); err != nil {
+		return err
+	}
+
+	o.Token = raw
+
+	return nil
+}
@@ -0,0 +1,147 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// CancelOKCode is the HTTP code returned for type CancelOK
+const CancelOKCode int = 200
+
+/*
+CancelOK cancelled execution id
+
+swagger:response cancelOK
+*/
+type CancelOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload string `json:"body,omitempty"`
+}
+
+// NewCancelOK creates CancelOK with default headers values
+func NewCancelOK() *CancelOK {
+
+	return &CancelOK{}
+}
+
+// WithPayload adds the payload to the cancel o k response
+func (o *CancelOK) WithPayload(payload string) *CancelOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the cancel o k response
+func (o *CancelOK) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CancelOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// CancelBadRequestCode is the HTTP code returned for type CancelBadRequest
+const CancelBadRequestCode int = 400
+
+/*
+CancelBadRequest Bad Request
+
+swagger:response cancelBadRequest
+*/
+type CancelBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewCancelBadRequest creates CancelBadRequest with default headers values
+func NewCancelBadRequest() *CancelBadRequest {
+
+	return &CancelBadRequest{}
+}
+
+// WithPayload adds the payload to the cancel bad request response
+func (o *CancelBadRequest) WithPayload(payload *models.Error) *CancelBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the cancel bad request response
+func (o *CancelBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CancelBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// CancelInternalServerErrorCode is the HTTP code returned for type CancelInternalServerError
+const CancelInternalServerErrorCode int = 500
+
+/*
+CancelInternalServerError Fatal
+
+swagger:response cancelInternalServerError
+*/
+type CancelInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewCancelInternalServerError creates CancelInternalServerError with default headers values
+func NewCancelInternalServerError() *CancelInternalServerError {
+
+	return &CancelInternalServerError{}
+}
+
+// WithPayload adds the payload to the cancel internal server error response
+func (o *CancelInternalServerError) WithPayload(payload *models.Error) *CancelInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the cancel internal server error response
+func (o *CancelInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CancelInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// DeadHandlerFunc turns a function with the right signature into a dead handler
+type DeadHandlerFunc func(DeadParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn DeadHandlerFunc) Handle(params DeadParams) middleware.Responder {
+	return fn(params)
+}
+
+// DeadHandler interface for that can handle valid dead params
+type DeadHandler interface {
+	Handle(DeadParams) middleware.Responder
+}
+
+// NewDead creates a new http.Handler for the dead operation
+func NewDead(ctx *middleware.Context, handler DeadHandler) *Dead {
+	return &Dead{Context: ctx, Handler: handler}
+}
+
+/*
+Dead swagger:route GET /v2/dead dead
+
+Dead dead API
+*/
+type Dead struct {
+	Context *middleware.Context
+	Handler DeadHandler
+}
+
+func (o *Dead) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewDeadParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,147 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// DeadOKCode is the HTTP code returned for type DeadOK
+const DeadOKCode int = 200
+
+/*
+DeadOK dead task list
+
+swagger:response deadOK
+*/
+type DeadOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload interface{} `json:"body,omitempty"`
+}
+
+// NewDeadOK creates DeadOK with default headers values
+func NewDeadOK() *DeadOK {
+
+	return &DeadOK{}
+}
+
+// WithPayload adds the payload to the dead o k response
+func (o *DeadOK) WithPayload(payload interface{}) *DeadOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the dead o k response
+func (o *DeadOK) SetPayload(payload interface{}) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeadOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// DeadBadRequestCode is the HTTP code returned for type DeadBadRequest
+const DeadBadRequestCode int = 400
+
+/*
+DeadBadRequest Bad Request
+
+swagger:response deadBadRequest
+*/
+type DeadBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewDeadBadRequest creates DeadBadRequest with default headers values
+func NewDeadBadRequest() *DeadBadRequest {
+
+	return &DeadBadRequest{}
+}
+
+// WithPayload adds the payload to the dead bad request response
+func (o *DeadBadRequest) WithPayload(payload *models.Error) *DeadBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the dead bad request response
+func (o *DeadBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeadBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// DeadInternalServerErrorCode is the HTTP code returned for type DeadInternalServerError
+const DeadInternalServerErrorCode int = 500
+
+/*
+DeadInternalServerError Fatal
+
+swagger:response deadInternalServerError
+*/
+type DeadInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewDeadInternalServerError creates DeadInternalServerError with default headers values
+func NewDeadInternalServerError() *DeadInternalServerError {
+
+	return &DeadInternalServerError{}
+}
+
+// WithPayload adds the payload to the dead internal server error response
+func (o *DeadInternalServerError) WithPayload(payload *models.Error) *DeadInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the dead internal server error response
+func (o *DeadInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeadInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
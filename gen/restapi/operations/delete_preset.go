@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// DeletePresetHandlerFunc turns a function with the right signature into a delete preset handler
+type DeletePresetHandlerFunc func(DeletePresetParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn DeletePresetHandlerFunc) Handle(params DeletePresetParams) middleware.Responder {
+	return fn(params)
+}
+
+// DeletePresetHandler interface for that can handle valid delete preset params
+type DeletePresetHandler interface {
+	Handle(DeletePresetParams) middleware.Responder
+}
+
+// NewDeletePreset creates a new http.Handler for the delete preset operation
+func NewDeletePreset(ctx *middleware.Context, handler DeletePresetHandler) *DeletePreset {
+	return &DeletePreset{Context: ctx, Handler: handler}
+}
+
+/*
+DeletePreset swagger:route DELETE /v2/presets/{name} deletePreset
+
+DeletePreset delete preset API
+*/
+type DeletePreset struct {
+	Context *middleware.Context
+	Handler DeletePresetHandler
+}
+
+func (o *DeletePreset) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewDeletePresetParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
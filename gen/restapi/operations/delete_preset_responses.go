@@ -0,0 +1,147 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// DeletePresetOKCode is the HTTP code returned for type DeletePresetOK
+const DeletePresetOKCode int = 200
+
+/*
+DeletePresetOK deleted preset name
+
+swagger:response deletePresetOK
+*/
+type DeletePresetOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload string `json:"body,omitempty"`
+}
+
+// NewDeletePresetOK creates DeletePresetOK with default headers values
+func NewDeletePresetOK() *DeletePresetOK {
+
+	return &DeletePresetOK{}
+}
+
+// WithPayload adds the payload to the delete preset o k response
+func (o *DeletePresetOK) WithPayload(payload string) *DeletePresetOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete preset o k response
+func (o *DeletePresetOK) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeletePresetOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// DeletePresetBadRequestCode is the HTTP code returned for type DeletePresetBadRequest
+const DeletePresetBadRequestCode int = 400
+
+/*
+DeletePresetBadRequest Bad Request
+
+swagger:response deletePresetBadRequest
+*/
+type DeletePresetBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewDeletePresetBadRequest creates DeletePresetBadRequest with default headers values
+func NewDeletePresetBadRequest() *DeletePresetBadRequest {
+
+	return &DeletePresetBadRequest{}
+}
+
+// WithPayload adds the payload to the delete preset bad request response
+func (o *DeletePresetBadRequest) WithPayload(payload *models.Error) *DeletePresetBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete preset bad request response
+func (o *DeletePresetBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeletePresetBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// DeletePresetInternalServerErrorCode is the HTTP code returned for type DeletePresetInternalServerError
+const DeletePresetInternalServerErrorCode int = 500
+
+/*
+DeletePresetInternalServerError Fatal
+
+swagger:response deletePresetInternalServerError
+*/
+type DeletePresetInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewDeletePresetInternalServerError creates DeletePresetInternalServerError with default headers values
+func NewDeletePresetInternalServerError() *DeletePresetInternalServerError {
+
+	return &DeletePresetInternalServerError{}
+}
+
+// WithPayload adds the payload to the delete preset internal server error response
+func (o *DeletePresetInternalServerError) WithPayload(payload *models.Error) *DeletePresetInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the delete preset internal server error response
+func (o *DeletePresetInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeletePresetInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// DeliveriesHandlerFunc turns a function with the right signature into a deliveries handler
+type DeliveriesHandlerFunc func(DeliveriesParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn DeliveriesHandlerFunc) Handle(params DeliveriesParams) middleware.Responder {
+	return fn(params)
+}
+
+// DeliveriesHandler interface for that can handle valid deliveries params
+type DeliveriesHandler interface {
+	Handle(DeliveriesParams) middleware.Responder
+}
+
+// NewDeliveries creates a new http.Handler for the deliveries operation
+func NewDeliveries(ctx *middleware.Context, handler DeliveriesHandler) *Deliveries {
+	return &Deliveries{Context: ctx, Handler: handler}
+}
+
+/*
+Deliveries swagger:route GET /v2/deliveries deliveries
+
+Deliveries deliveries API
+*/
+type Deliveries struct {
+	Context *middleware.Context
+	Handler DeliveriesHandler
+}
+
+func (o *Deliveries) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewDeliveriesParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
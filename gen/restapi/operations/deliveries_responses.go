@@ -0,0 +1,147 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// DeliveriesOKCode is the HTTP code returned for type DeliveriesOK
+const DeliveriesOKCode int = 200
+
+/*
+DeliveriesOK webhook delivery log
+
+swagger:response deliveriesOK
+*/
+type DeliveriesOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload interface{} `json:"body,omitempty"`
+}
+
+// NewDeliveriesOK creates DeliveriesOK with default headers values
+func NewDeliveriesOK() *DeliveriesOK {
+
+	return &DeliveriesOK{}
+}
+
+// WithPayload adds the payload to the deliveries o k response
+func (o *DeliveriesOK) WithPayload(payload interface{}) *DeliveriesOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the deliveries o k response
+func (o *DeliveriesOK) SetPayload(payload interface{}) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeliveriesOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// DeliveriesBadRequestCode is the HTTP code returned for type DeliveriesBadRequest
+const DeliveriesBadRequestCode int = 400
+
+/*
+DeliveriesBadRequest Bad Request
+
+swagger:response deliveriesBadRequest
+*/
+type DeliveriesBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewDeliveriesBadRequest creates DeliveriesBadRequest with default headers values
+func NewDeliveriesBadRequest() *DeliveriesBadRequest {
+
+	return &DeliveriesBadRequest{}
+}
+
+// WithPayload adds the payload to the deliveries bad request response
+func (o *DeliveriesBadRequest) WithPayload(payload *models.Error) *DeliveriesBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the deliveries bad request response
+func (o *DeliveriesBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeliveriesBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// DeliveriesInternalServerErrorCode is the HTTP code returned for type DeliveriesInternalServerError
+const DeliveriesInternalServerErrorCode int = 500
+
+/*
+DeliveriesInternalServerError Fatal
+
+swagger:response deliveriesInternalServerError
+*/
+type DeliveriesInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewDeliveriesInternalServerError creates DeliveriesInternalServerError with default headers values
+func NewDeliveriesInternalServerError() *DeliveriesInternalServerError {
+
+	return &DeliveriesInternalServerError{}
+}
+
+// WithPayload adds the payload to the deliveries internal server error response
+func (o *DeliveriesInternalServerError) WithPayload(payload *models.Error) *DeliveriesInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the deliveries internal server error response
+func (o *DeliveriesInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DeliveriesInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// DownloadHandlerFunc turns a function with the right signature into a download handler
+type DownloadHandlerFunc func(DownloadParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn DownloadHandlerFunc) Handle(params DownloadParams) middleware.Responder {
+	return fn(params)
+}
+
+// DownloadHandler interface for that can handle valid download params
+type DownloadHandler interface {
+	Handle(DownloadParams) middleware.Responder
+}
+
+// NewDownload creates a new http.Handler for the download operation
+func NewDownload(ctx *middleware.Context, handler DownloadHandler) *Download {
+	return &Download{Context: ctx, Handler: handler}
+}
+
+/*
+Download swagger:route GET /v2/download/{key} download
+
+Download download API
+*/
+type Download struct {
+	Context *middleware.Context
+	Handler DownloadHandler
+}
+
+func (o *Download) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewDownloadParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
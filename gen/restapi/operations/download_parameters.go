@@ -0,0 +1,161 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewDownloadParams creates a new DownloadParams object
+// no default values defined in spec.
+func NewDownloadParams() DownloadParams {
+
+	return DownloadParams{}
+}
+
+// DownloadParams contains all the bound params for the download operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters download
+type DownloadParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*Unix time after which a signed url stops working
+	  In: query
+	*/
+	Expires *int64
+	/*Object key: an image id or a resized file name
+	  Required: true
+	  In: path
+	*/
+	Key string
+	/*HMAC-SHA256 signature of the url. Used instead of the token when url signing is enabled
+	  In: query
+	*/
+	Signature *string
+	/*User's token. The key must be one of the user's images or their resizes. Not needed for a signed url
+	  In: query
+	*/
+	Token *string
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewDownloadParams() beforehand.
+func (o *DownloadParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	qs := runtime.Values(r.URL.Query())
+
+	qExpires, qhkExpires, _ := qs.GetOK("expires")
+	if err := o.bindExpires(qExpires, qhkExpires, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	rKey, rhkKey, _ := route.Params.GetOK("key")
+	if err := o.bindKey(rKey, rhkKey, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qSignature, qhkSignature, _ := qs.GetOK("signature")
+	if err := o.bindSignature(qSignature, qhkSignature, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qToken, qhkToken, _ := qs.GetOK("token")
+	if err := o.bindToken(qToken, qhkToken, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// bindExpires binds and validates parameter Expires from query.
+func (o *DownloadParams) bindExpires(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("expires", "query", "int64", raw)
+	}
+	o.Expires = &value
+
+	return nil
+}
+
+// bindKey binds and validates parameter Key from path.
+func (o *DownloadParams) bindKey(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+	// Parameter is provided by construction from the route
+
+	o.Key = raw
+
+	return nil
+}
+
+// bindSignature binds and validates parameter Signature from query.
+func (o *DownloadParams) bindSignature(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Signature = &raw
+
+	return nil
+}
+
+// bindToken binds and validates parameter Token from query.
+func (o *DownloadParams) bindToken(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Token = &raw
+
+	return nil
+}
@@ -0,0 +1,306 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// DownloadOKCode is the HTTP code returned for type DownloadOK
+const DownloadOKCode int = 200
+
+/*
+DownloadOK object content
+
+swagger:response downloadOK
+*/
+type DownloadOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload io.ReadCloser `json:"body,omitempty"`
+}
+
+// NewDownloadOK creates DownloadOK with default headers values
+func NewDownloadOK() *DownloadOK {
+
+	return &DownloadOK{}
+}
+
+// WithPayload adds the payload to the download o k response
+func (o *DownloadOK) WithPayload(payload io.ReadCloser) *DownloadOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the download o k response
+func (o *DownloadOK) SetPayload(payload io.ReadCloser) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DownloadOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// DownloadPartialContentCode is the HTTP code returned for type DownloadPartialContent
+const DownloadPartialContentCode int = 206
+
+/*
+DownloadPartialContent requested range of the object
+
+swagger:response downloadPartialContent
+*/
+type DownloadPartialContent struct {
+
+	/*
+	  In: Body
+	*/
+	Payload io.ReadCloser `json:"body,omitempty"`
+}
+
+// NewDownloadPartialContent creates DownloadPartialContent with default headers values
+func NewDownloadPartialContent() *DownloadPartialContent {
+
+	return &DownloadPartialContent{}
+}
+
+// WithPayload adds the payload to the download partial content response
+func (o *DownloadPartialContent) WithPayload(payload io.ReadCloser) *DownloadPartialContent {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the download partial content response
+func (o *DownloadPartialContent) SetPayload(payload io.ReadCloser) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DownloadPartialContent) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(206)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// DownloadNotModifiedCode is the HTTP code returned for type DownloadNotModified
+const DownloadNotModifiedCode int = 304
+
+/*
+DownloadNotModified object has not changed
+
+swagger:response downloadNotModified
+*/
+type DownloadNotModified struct {
+}
+
+// NewDownloadNotModified creates DownloadNotModified with default headers values
+func NewDownloadNotModified() *DownloadNotModified {
+
+	return &DownloadNotModified{}
+}
+
+// WriteResponse to the client
+func (o *DownloadNotModified) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(304)
+}
+
+// DownloadBadRequestCode is the HTTP code returned for type DownloadBadRequest
+const DownloadBadRequestCode int = 400
+
+/*
+DownloadBadRequest Bad Request
+
+swagger:response downloadBadRequest
+*/
+type DownloadBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewDownloadBadRequest creates DownloadBadRequest with default headers values
+func NewDownloadBadRequest() *DownloadBadRequest {
+
+	return &DownloadBadRequest{}
+}
+
+// WithPayload adds the payload to the download bad request response
+func (o *DownloadBadRequest) WithPayload(payload *models.Error) *DownloadBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the download bad request response
+func (o *DownloadBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DownloadBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// DownloadForbiddenCode is the HTTP code returned for type DownloadForbidden
+const DownloadForbiddenCode int = 403
+
+/*
+DownloadForbidden Forbidden
+
+swagger:response downloadForbidden
+*/
+type DownloadForbidden struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewDownloadForbidden creates DownloadForbidden with default headers values
+func NewDownloadForbidden() *DownloadForbidden {
+
+	return &DownloadForbidden{}
+}
+
+// WithPayload adds the payload to the download forbidden response
+func (o *DownloadForbidden) WithPayload(payload *models.Error) *DownloadForbidden {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the download forbidden response
+func (o *DownloadForbidden) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DownloadForbidden) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(403)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// DownloadNotFoundCode is the HTTP code returned for type DownloadNotFound
+const DownloadNotFoundCode int = 404
+
+/*
+DownloadNotFound Not Found
+
+swagger:response downloadNotFound
+*/
+type DownloadNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewDownloadNotFound creates DownloadNotFound with default headers values
+func NewDownloadNotFound() *DownloadNotFound {
+
+	return &DownloadNotFound{}
+}
+
+// WithPayload adds the payload to the download not found response
+func (o *DownloadNotFound) WithPayload(payload *models.Error) *DownloadNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the download not found response
+func (o *DownloadNotFound) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DownloadNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// DownloadInternalServerErrorCode is the HTTP code returned for type DownloadInternalServerError
+const DownloadInternalServerErrorCode int = 500
+
+/*
+DownloadInternalServerError Fatal
+
+swagger:response downloadInternalServerError
+*/
+type DownloadInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewDownloadInternalServerError creates DownloadInternalServerError with default headers values
+func NewDownloadInternalServerError() *DownloadInternalServerError {
+
+	return &DownloadInternalServerError{}
+}
+
+// WithPayload adds the payload to the download internal server error response
+func (o *DownloadInternalServerError) WithPayload(payload *models.Error) *DownloadInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the download internal server error response
+func (o *DownloadInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DownloadInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
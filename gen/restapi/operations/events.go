@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// EventsHandlerFunc turns a function with the right signature into a events handler
+type EventsHandlerFunc func(EventsParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn EventsHandlerFunc) Handle(params EventsParams) middleware.Responder {
+	return fn(params)
+}
+
+// EventsHandler interface for that can handle valid events params
+type EventsHandler interface {
+	Handle(EventsParams) middleware.Responder
+}
+
+// NewEvents creates a new http.Handler for the events operation
+func NewEvents(ctx *middleware.Context, handler EventsHandler) *Events {
+	return &Events{Context: ctx, Handler: handler}
+}
+
+/*
+Events swagger:route GET /v2/events events
+
+Events events API
+*/
+type Events struct {
+	Context *middleware.Context
+	Handler EventsHandler
+}
+
+func (o *Events) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewEventsParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,147 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// EventsOKCode is the HTTP code returned for type EventsOK
+const EventsOKCode int = 200
+
+/*
+EventsOK stream of task events
+
+swagger:response eventsOK
+*/
+type EventsOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload string `json:"body,omitempty"`
+}
+
+// NewEventsOK creates EventsOK with default headers values
+func NewEventsOK() *EventsOK {
+
+	return &EventsOK{}
+}
+
+// WithPayload adds the payload to the events o k response
+func (o *EventsOK) WithPayload(payload string) *EventsOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the events o k response
+func (o *EventsOK) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *EventsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// EventsBadRequestCode is the HTTP code returned for type EventsBadRequest
+const EventsBadRequestCode int = 400
+
+/*
+EventsBadRequest Bad Request
+
+swagger:response eventsBadRequest
+*/
+type EventsBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewEventsBadRequest creates EventsBadRequest with default headers values
+func NewEventsBadRequest() *EventsBadRequest {
+
+	return &EventsBadRequest{}
+}
+
+// WithPayload adds the payload to the events bad request response
+func (o *EventsBadRequest) WithPayload(payload *models.Error) *EventsBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the events bad request response
+func (o *EventsBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *EventsBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// EventsInternalServerErrorCode is the HTTP code returned for type EventsInternalServerError
+const EventsInternalServerErrorCode int = 500
+
+/*
+EventsInternalServerError Fatal
+
+swagger:response eventsInternalServerError
+*/
+type EventsInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewEventsInternalServerError creates EventsInternalServerError with default headers values
+func NewEventsInternalServerError() *EventsInternalServerError {
+
+	return &EventsInternalServerError{}
+}
+
+// WithPayload adds the payload to the events internal server error response
+func (o *EventsInternalServerError) WithPayload(payload *models.Error) *EventsInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the events internal server error response
+func (o *EventsInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *EventsInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
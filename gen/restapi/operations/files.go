@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// FilesHandlerFunc turns a function with the right signature into a files handler
+type FilesHandlerFunc func(FilesParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn FilesHandlerFunc) Handle(params FilesParams) middleware.Responder {
+	return fn(params)
+}
+
+// FilesHandler interface for that can handle valid files params
+type FilesHandler interface {
+	Handle(FilesParams) middleware.Responder
+}
+
+// NewFiles creates a new http.Handler for the files operation
+func NewFiles(ctx *middleware.Context, handler FilesHandler) *Files {
+	return &Files{Context: ctx, Handler: handler}
+}
+
+/*
+Files swagger:route GET /v1/files files
+
+Files files API
+*/
+type Files struct {
+	Context *middleware.Context
+	Handler FilesHandler
+}
+
+func (o *Files) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewFilesParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
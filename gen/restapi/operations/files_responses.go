@@ -0,0 +1,147 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// FilesOKCode is the HTTP code returned for type FilesOK
+const FilesOKCode int = 200
+
+/*
+FilesOK file list
+
+swagger:response filesOK
+*/
+type FilesOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload interface{} `json:"body,omitempty"`
+}
+
+// NewFilesOK creates FilesOK with default headers values
+func NewFilesOK() *FilesOK {
+
+	return &FilesOK{}
+}
+
+// WithPayload adds the payload to the files o k response
+func (o *FilesOK) WithPayload(payload interface{}) *FilesOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the files o k response
+func (o *FilesOK) SetPayload(payload interface{}) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *FilesOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// FilesBadRequestCode is the HTTP code returned for type FilesBadRequest
+const FilesBadRequestCode int = 400
+
+/*
+FilesBadRequest Bad Request
+
+swagger:response filesBadRequest
+*/
+type FilesBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewFilesBadRequest creates FilesBadRequest with default headers values
+func NewFilesBadRequest() *FilesBadRequest {
+
+	return &FilesBadRequest{}
+}
+
+// WithPayload adds the payload to the files bad request response
+func (o *FilesBadRequest) WithPayload(payload *models.Error) *FilesBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the files bad request response
+func (o *FilesBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *FilesBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// FilesInternalServerErrorCode is the HTTP code returned for type FilesInternalServerError
+const FilesInternalServerErrorCode int = 500
+
+/*
+FilesInternalServerError Fatal
+
+swagger:response filesInternalServerError
+*/
+type FilesInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewFilesInternalServerError creates FilesInternalServerError with default headers values
+func NewFilesInternalServerError() *FilesInternalServerError {
+
+	return &FilesInternalServerError{}
+}
+
+// WithPayload adds the payload to the files internal server error response
+func (o *FilesInternalServerError) WithPayload(payload *models.Error) *FilesInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the files internal server error response
+func (o *FilesInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *FilesInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// ImageMetadataHandlerFunc turns a function with the right signature into a image metadata handler
+type ImageMetadataHandlerFunc func(ImageMetadataParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn ImageMetadataHandlerFunc) Handle(params ImageMetadataParams) middleware.Responder {
+	return fn(params)
+}
+
+// ImageMetadataHandler interface for that can handle valid image metadata params
+type ImageMetadataHandler interface {
+	Handle(ImageMetadataParams) middleware.Responder
+}
+
+// NewImageMetadata creates a new http.Handler for the image metadata operation
+func NewImageMetadata(ctx *middleware.Context, handler ImageMetadataHandler) *ImageMetadata {
+	return &ImageMetadata{Context: ctx, Handler: handler}
+}
+
+/*
+ImageMetadata swagger:route GET /v2/images/{id}/metadata imageMetadata
+
+ImageMetadata image metadata API
+*/
+type ImageMetadata struct {
+	Context *middleware.Context
+	Handler ImageMetadataHandler
+}
+
+func (o *ImageMetadata) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewImageMetadataParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,147 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// ImageMetadataOKCode is the HTTP code returned for type ImageMetadataOK
+const ImageMetadataOKCode int = 200
+
+/*
+ImageMetadataOK image metadata
+
+swagger:response imageMetadataOK
+*/
+type ImageMetadataOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload interface{} `json:"body,omitempty"`
+}
+
+// NewImageMetadataOK creates ImageMetadataOK with default headers values
+func NewImageMetadataOK() *ImageMetadataOK {
+
+	return &ImageMetadataOK{}
+}
+
+// WithPayload adds the payload to the image metadata o k response
+func (o *ImageMetadataOK) WithPayload(payload interface{}) *ImageMetadataOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the image metadata o k response
+func (o *ImageMetadataOK) SetPayload(payload interface{}) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ImageMetadataOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// ImageMetadataBadRequestCode is the HTTP code returned for type ImageMetadataBadRequest
+const ImageMetadataBadRequestCode int = 400
+
+/*
+ImageMetadataBadRequest Bad Request
+
+swagger:response imageMetadataBadRequest
+*/
+type ImageMetadataBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewImageMetadataBadRequest creates ImageMetadataBadRequest with default headers values
+func NewImageMetadataBadRequest() *ImageMetadataBadRequest {
+
+	return &ImageMetadataBadRequest{}
+}
+
+// WithPayload adds the payload to the image metadata bad request response
+func (o *ImageMetadataBadRequest) WithPayload(payload *models.Error) *ImageMetadataBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the image metadata bad request response
+func (o *ImageMetadataBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ImageMetadataBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// ImageMetadataInternalServerErrorCode is the HTTP code returned for type ImageMetadataInternalServerError
+const ImageMetadataInternalServerErrorCode int = 500
+
+/*
+ImageMetadataInternalServerError Fatal
+
+swagger:response imageMetadataInternalServerError
+*/
+type ImageMetadataInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewImageMetadataInternalServerError creates ImageMetadataInternalServerError with default headers values
+func NewImageMetadataInternalServerError() *ImageMetadataInternalServerError {
+
+	return &ImageMetadataInternalServerError{}
+}
+
+// WithPayload adds the payload to the image metadata internal server error response
+func (o *ImageMetadataInternalServerError) WithPayload(payload *models.Error) *ImageMetadataInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the image metadata internal server error response
+func (o *ImageMetadataInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ImageMetadataInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// PresetHandlerFunc turns a function with the right signature into a preset handler
+type PresetHandlerFunc func(PresetParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn PresetHandlerFunc) Handle(params PresetParams) middleware.Responder {
+	return fn(params)
+}
+
+// PresetHandler interface for that can handle valid preset params
+type PresetHandler interface {
+	Handle(PresetParams) middleware.Responder
+}
+
+// NewPreset creates a new http.Handler for the preset operation
+func NewPreset(ctx *middleware.Context, handler PresetHandler) *Preset {
+	return &Preset{Context: ctx, Handler: handler}
+}
+
+/*
+Preset swagger:route GET /v2/presets/{name} preset
+
+Preset preset API
+*/
+type Preset struct {
+	Context *middleware.Context
+	Handler PresetHandler
+}
+
+func (o *Preset) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewPresetParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
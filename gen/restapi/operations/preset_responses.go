@@ -0,0 +1,147 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// PresetOKCode is the HTTP code returned for type PresetOK
+const PresetOKCode int = 200
+
+/*
+PresetOK preset
+
+swagger:response presetOK
+*/
+type PresetOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload interface{} `json:"body,omitempty"`
+}
+
+// NewPresetOK creates PresetOK with default headers values
+func NewPresetOK() *PresetOK {
+
+	return &PresetOK{}
+}
+
+// WithPayload adds the payload to the preset o k response
+func (o *PresetOK) WithPayload(payload interface{}) *PresetOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the preset o k response
+func (o *PresetOK) SetPayload(payload interface{}) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PresetOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// PresetBadRequestCode is the HTTP code returned for type PresetBadRequest
+const PresetBadRequestCode int = 400
+
+/*
+PresetBadRequest Bad Request
+
+swagger:response presetBadRequest
+*/
+type PresetBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewPresetBadRequest creates PresetBadRequest with default headers values
+func NewPresetBadRequest() *PresetBadRequest {
+
+	return &PresetBadRequest{}
+}
+
+// WithPayload adds the payload to the preset bad request response
+func (o *PresetBadRequest) WithPayload(payload *models.Error) *PresetBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the preset bad request response
+func (o *PresetBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PresetBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// PresetInternalServerErrorCode is the HTTP code returned for type PresetInternalServerError
+const PresetInternalServerErrorCode int = 500
+
+/*
+PresetInternalServerError Fatal
+
+swagger:response presetInternalServerError
+*/
+type PresetInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewPresetInternalServerError creates PresetInternalServerError with default headers values
+func NewPresetInternalServerError() *PresetInternalServerError {
+
+	return &PresetInternalServerError{}
+}
+
+// WithPayload adds the payload to the preset internal server error response
+func (o *PresetInternalServerError) WithPayload(payload *models.Error) *PresetInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the preset internal server error response
+func (o *PresetInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PresetInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// PresetsOKCode is the HTTP code returned for type PresetsOK
+const PresetsOKCode int = 200
+
+/*
+PresetsOK preset list
+
+swagger:response presetsOK
+*/
+type PresetsOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload interface{} `json:"body,omitempty"`
+}
+
+// NewPresetsOK creates PresetsOK with default headers values
+func NewPresetsOK() *PresetsOK {
+
+	return &PresetsOK{}
+}
+
+// WithPayload adds the payload to the presets o k response
+func (o *PresetsOK) WithPayload(payload interface{}) *PresetsOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the presets o k response
+func (o *PresetsOK) SetPayload(payload interface{}) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PresetsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// PresetsBadRequestCode is the HTTP code returned for type PresetsBadRequest
+const PresetsBadRequestCode int = 400
+
+/*
+PresetsBadRequest Bad Request
+
+swagger:response presetsBadRequest
+*/
+type PresetsBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewPresetsBadRequest creates PresetsBadRequest with default headers values
+func NewPresetsBadRequest() *PresetsBadRequest {
+
+	return &PresetsBadRequest{}
+}
+
+// WithPayload adds the payload to the presets bad request response
+func (o *PresetsBadRequest) WithPayload(payload *models.Error) *PresetsBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the presets bad request response
+func (o *PresetsBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PresetsBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// PresetsInternalServerErrorCode is the HTTP code returned for type PresetsInternalServerError
+const PresetsInternalServerErrorCode int = 500
+
+/*
+PresetsInternalServerError Fatal
+
+swagger:response presetsInternalServerError
+*/
+type PresetsInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewPresetsInternalServerError creates PresetsInternalServerError with default headers values
+func NewPresetsInternalServerError() *PresetsInternalServerError {
+
+	return &PresetsInternalServerError{}
+}
+
+// WithPayload adds the payload to the presets internal server error response
+func (o *PresetsInternalServerError) WithPayload(payload *models.Error) *PresetsInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the presets internal server error response
+func (o *PresetsInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PresetsInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// PutPresetHandlerFunc turns a function with the right signature into a put preset handler
+type PutPresetHandlerFunc func(PutPresetParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn PutPresetHandlerFunc) Handle(params PutPresetParams) middleware.Responder {
+	return fn(params)
+}
+
+// PutPresetHandler interface for that can handle valid put preset params
+type PutPresetHandler interface {
+	Handle(PutPresetParams) middleware.Responder
+}
+
+// NewPutPreset creates a new http.Handler for the put preset operation
+func NewPutPreset(ctx *middleware.Context, handler PutPresetHandler) *PutPreset {
+	return &PutPreset{Context: ctx, Handler: handler}
+}
+
+/*
+PutPreset swagger:route PUT /v2/presets/{name} putPreset
+
+PutPreset put preset API
+*/
+type PutPreset struct {
+	Context *middleware.Context
+	Handler PutPresetHandler
+}
+
+func (o *PutPreset) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewPutPresetParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
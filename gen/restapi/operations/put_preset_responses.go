@@ -0,0 +1,147 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// PutPresetOKCode is the HTTP code returned for type PutPresetOK
+const PutPresetOKCode int = 200
+
+/*
+PutPresetOK preset
+
+swagger:response putPresetOK
+*/
+type PutPresetOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload interface{} `json:"body,omitempty"`
+}
+
+// NewPutPresetOK creates PutPresetOK with default headers values
+func NewPutPresetOK() *PutPresetOK {
+
+	return &PutPresetOK{}
+}
+
+// WithPayload adds the payload to the put preset o k response
+func (o *PutPresetOK) WithPayload(payload interface{}) *PutPresetOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the put preset o k response
+func (o *PutPresetOK) SetPayload(payload interface{}) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PutPresetOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// PutPresetBadRequestCode is the HTTP code returned for type PutPresetBadRequest
+const PutPresetBadRequestCode int = 400
+
+/*
+PutPresetBadRequest Bad Request
+
+swagger:response putPresetBadRequest
+*/
+type PutPresetBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewPutPresetBadRequest creates PutPresetBadRequest with default headers values
+func NewPutPresetBadRequest() *PutPresetBadRequest {
+
+	return &PutPresetBadRequest{}
+}
+
+// WithPayload adds the payload to the put preset bad request response
+func (o *PutPresetBadRequest) WithPayload(payload *models.Error) *PutPresetBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the put preset bad request response
+func (o *PutPresetBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PutPresetBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// PutPresetInternalServerErrorCode is the HTTP code returned for type PutPresetInternalServerError
+const PutPresetInternalServerErrorCode int = 500
+
+/*
+PutPresetInternalServerError Fatal
+
+swagger:response putPresetInternalServerError
+*/
+type PutPresetInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewPutPresetInternalServerError creates PutPresetInternalServerError with default headers values
+func NewPutPresetInternalServerError() *PutPresetInternalServerError {
+
+	return &PutPresetInternalServerError{}
+}
+
+// WithPayload adds the payload to the put preset internal server error response
+func (o *PutPresetInternalServerError) WithPayload(payload *models.Error) *PutPresetInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the put preset internal server error response
+func (o *PutPresetInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PutPresetInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
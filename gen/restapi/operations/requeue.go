@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// RequeueHandlerFunc turns a function with the right signature into a requeue handler
+type RequeueHandlerFunc func(RequeueParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn RequeueHandlerFunc) Handle(params RequeueParams) middleware.Responder {
+	return fn(params)
+}
+
+// RequeueHandler interface for that can handle valid requeue params
+type RequeueHandler interface {
+	Handle(RequeueParams) middleware.Responder
+}
+
+// NewRequeue creates a new http.Handler for the requeue operation
+func NewRequeue(ctx *middleware.Context, handler RequeueHandler) *Requeue {
+	return &Requeue{Context: ctx, Handler: handler}
+}
+
+/*
+Requeue swagger:route POST /v2/requeue requeue
+
+Requeue requeue API
+*/
+type Requeue struct {
+	Context *middleware.Context
+	Handler RequeueHandler
+}
+
+func (o *Requeue) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewRequeueParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,120 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+)
+
+// NewRequeueParams creates a new RequeueParams object
+// no default values defined in spec.
+func NewRequeueParams() RequeueParams {
+
+	return RequeueParams{}
+}
+
+// RequeueParams contains all the bound params for the requeue operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters requeue
+type RequeueParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*Execution id
+	  Required: true
+	  In: formData
+	*/
+	Execution string
+	/*User's token
+	  Required: true
+	  In: formData
+	*/
+	Token string
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewRequeueParams() beforehand.
+func (o *RequeueParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		if err != http.ErrNotMultipart {
+			return errors.New(400, "%v", err)
+		} else if err := r.ParseForm(); err != nil {
+			return errors.New(400, "%v", err)
+		}
+	}
+	fds := runtime.Values(r.Form)
+
+	fdExecution, fdhkExecution, _ := fds.GetOK("execution")
+	if err := o.bindExecution(fdExecution, fdhkExecution, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdToken, fdhkToken, _ := fds.GetOK("token")
+	if err := o.bindToken(fdToken, fdhkToken, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// bindExecution binds and validates parameter Execution from formData.
+func (o *RequeueParams) bindExecution(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	if !hasKey {
+		return errors.Required("execution", "formData")
+	}
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+
+	if err := validate.RequiredString("execution", "formData", raw); err != nil {
+		return err
+	}
+
+	o.Execution = raw
+
+	return nil
+}
+
+// bindToken binds and validates parameter Token from formData.
+func (o *RequeueParams) bindToken(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	if !hasKey {
+		return errors.Required("token", "formData")
+	}
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+
+	if err := validate.RequiredString("token", "formData", raw); err != nil {
+		return err
+	}
+
+	o.Token = raw
+
+	return nil
+}
@@ -0,0 +1,147 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// RequeueOKCode is the HTTP code returned for type RequeueOK
+const RequeueOKCode int = 200
+
+/*
+RequeueOK requeued execution id
+
+swagger:response requeueOK
+*/
+type RequeueOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload string `json:"body,omitempty"`
+}
+
+// NewRequeueOK creates RequeueOK with default headers values
+func NewRequeueOK() *RequeueOK {
+
+	return &RequeueOK{}
+}
+
+// WithPayload adds the payload to the requeue o k response
+func (o *RequeueOK) WithPayload(payload string) *RequeueOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the requeue o k response
+func (o *RequeueOK) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *RequeueOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// RequeueBadRequestCode is the HTTP code returned for type RequeueBadRequest
+const RequeueBadRequestCode int = 400
+
+/*
+RequeueBadRequest Bad Request
+
+swagger:response requeueBadRequest
+*/
+type RequeueBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewRequeueBadRequest creates RequeueBadRequest with default headers values
+func NewRequeueBadRequest() *RequeueBadRequest {
+
+	return &RequeueBadRequest{}
+}
+
+// WithPayload adds the payload to the requeue bad request response
+func (o *RequeueBadRequest) WithPayload(payload *models.Error) *RequeueBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the requeue bad request response
+func (o *RequeueBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *RequeueBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// RequeueInternalServerErrorCode is the HTTP code returned for type RequeueInternalServerError
+const RequeueInternalServerErrorCode int = 500
+
+/*
+RequeueInternalServerError Fatal
+
+swagger:response requeueInternalServerError
+*/
+type RequeueInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewRequeueInternalServerError creates RequeueInternalServerError with default headers values
+func NewRequeueInternalServerError() *RequeueInternalServerError {
+
+	return &RequeueInternalServerError{}
+}
+
+// WithPayload adds the payload to the requeue internal server error response
+func (o *RequeueInternalServerError) WithPayload(payload *models.Error) *RequeueInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the requeue internal server error response
+func (o *RequeueInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *RequeueInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
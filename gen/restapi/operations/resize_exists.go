@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// ResizeExistsHandlerFunc turns a function with the right signature into a resize exists handler
+type ResizeExistsHandlerFunc func(ResizeExistsParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn ResizeExistsHandlerFunc) Handle(params ResizeExistsParams) middleware.Responder {
+	return fn(params)
+}
+
+// ResizeExistsHandler interface for that can handle valid resize exists params
+type ResizeExistsHandler interface {
+	Handle(ResizeExistsParams) middleware.Responder
+}
+
+// NewResizeExists creates a new http.Handler for the resize exists operation
+func NewResizeExists(ctx *middleware.Context, handler ResizeExistsHandler) *ResizeExists {
+	return &ResizeExists{Context: ctx, Handler: handler}
+}
+
+/*
+ResizeExists swagger:route POST /v1/resize_exists resizeExists
+
+ResizeExists resize exists API
+*/
+type ResizeExists struct {
+	Context *middleware.Context
+	Handler ResizeExistsHandler
+}
+
+func (o *ResizeExists) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewResizeExistsParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
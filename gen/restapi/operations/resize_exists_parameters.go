@@ -0,0 +1,1010 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// NewResizeExistsParams creates a new ResizeExistsParams object
+// with the default values initialized.
+func NewResizeExistsParams() ResizeExistsParams {
+
+	var (
+		// initialize parameters with default values
+
+		compressionDefault = string("default")
+
+		ditherDefault = bool(true)
+
+		firstFrameDefault = bool(false)
+
+		gravityDefault   = string("center")
+		grayscaleDefault = bool(false)
+
+		modeDefault = string("fit")
+
+		sepiaDefault = bool(false)
+	)
+
+	return ResizeExistsParams{
+		Compression: &compressionDefault,
+
+		Dither: &ditherDefault,
+
+		FirstFrame: &firstFrameDefault,
+
+		Gravity: &gravityDefault,
+
+		Grayscale: &grayscaleDefault,
+
+		Mode: &modeDefault,
+
+		Sepia: &sepiaDefault,
+	}
+}
+
+// ResizeExistsParams contains all the bound params for the resize exists operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters resize_exists
+type ResizeExistsParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*Padding color in pad mode, rgb, rrggbb or rrggbbaa hex.
+	  In: formData
+	*/
+	Background *string
+	/*Gaussian blur sigma in pixels of the result, up to 50.
+	  Maximum: 50
+	  Minimum: 0
+	  In: formData
+	*/
+	Blur *float64
+	/*Brightness change after resizing, from -100 to 100 percent.
+	  Maximum: 100
+	  Minimum: -100
+	  In: formData
+	*/
+	Brightness *float64
+	/*GIF palette size from 1 to 256.
+	  Maximum: 256
+	  Minimum: 1
+	  In: formData
+	*/
+	Colors *int64
+	/*PNG compression level.
+	  In: formData
+	  Default: "default"
+	*/
+	Compression *string
+	/*Contrast change after resizing, from -100 to 100 percent.
+	  Maximum: 100
+	  Minimum: -100
+	  In: formData
+	*/
+	Contrast *float64
+	/*Use Floyd-Steinberg dithering for GIF.
+	  In: formData
+	  Default: true
+	*/
+	Dither *bool
+	/*
+	  Required: true
+	  In: formData
+	*/
+	File string
+	/*Resampling filter, the server default if not set.
+	  In: formData
+	*/
+	Filter *string
+	/*Keep only the first frame of an animated GIF.
+	  In: formData
+	  Default: false
+	*/
+	FirstFrame *bool
+	/*Output format, the input's format by default.
+	  In: formData
+	*/
+	Format *string
+	/*Gamma correction after resizing, from 0.1 to 10. Values above 1 brighten midtones.
+	  Maximum: 10
+	  Minimum: 0.1
+	  In: formData
+	*/
+	Gamma *float64
+	/*Which part of the image to keep in fill mode and where to place it in pad mode.
+	  In: formData
+	  Default: "center"
+	*/
+	Gravity *string
+	/*Convert the result to grayscale.
+	  In: formData
+	  Default: false
+	*/
+	Grayscale *bool
+	/*Height of the result. With only one of width and height the other side is proportional.
+	  Minimum: 0
+	  In: formData
+	*/
+	Height *int64
+	/*How to fit the image when both width and height are set.
+	  In: formData
+	  Default: "fit"
+	*/
+	Mode *string
+	/*Name of a preset from /v2/presets to use instead of the resize parameters
+	  In: formData
+	*/
+	Preset *string
+	/*JPEG quality from 1 to 100.
+	  Maximum: 100
+	  Minimum: 1
+	  In: formData
+	*/
+	Quality *int64
+	/*Param of file resize. Width of the result. Required when preset is not set.
+	  In: formData
+	*/
+	Resize *int64
+	/*Saturation change after resizing, from -100 to 100 percent.
+	  Maximum: 100
+	  Minimum: -100
+	  In: formData
+	*/
+	Saturation *float64
+	/*Apply a sepia tone to the result.
+	  In: formData
+	  Default: false
+	*/
+	Sepia *bool
+	/*Unsharp mask after resizing: sigma[,amount[,threshold]], e.g. 1,1.5,2. Amount is 1 by default.
+	  In: formData
+	*/
+	Sharpen *string
+	/*
+	  Required: true
+	  In: formData
+	*/
+	Token string
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewResizeExistsParams() beforehand.
+func (o *ResizeExistsParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		if err != http.ErrNotMultipart {
+			return errors.New(400, "%v", err)
+		} else if err := r.ParseForm(); err != nil {
+			return errors.New(400, "%v", err)
+		}
+	}
+	fds := runtime.Values(r.Form)
+
+	fdBackground, fdhkBackground, _ := fds.GetOK("background")
+	if err := o.bindBackground(fdBackground, fdhkBackground, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdBlur, fdhkBlur, _ := fds.GetOK("blur")
+	if err := o.bindBlur(fdBlur, fdhkBlur, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdBrightness, fdhkBrightness, _ := fds.GetOK("brightness")
+	if err := o.bindBrightness(fdBrightness, fdhkBrightness, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdColors, fdhkColors, _ := fds.GetOK("colors")
+	if err := o.bindColors(fdColors, fdhkColors, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdCompression, fdhkCompression, _ := fds.GetOK("compression")
+	if err := o.bindCompression(fdCompression, fdhkCompression, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdContrast, fdhkContrast, _ := fds.GetOK("contrast")
+	if err := o.bindContrast(fdContrast, fdhkContrast, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdDither, fdhkDither, _ := fds.GetOK("dither")
+	if err := o.bindDither(fdDither, fdhkDither, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdFile, fdhkFile, _ := fds.GetOK("file")
+	if err := o.bindFile(fdFile, fdhkFile, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdFilter, fdhkFilter, _ := fds.GetOK("filter")
+	if err := o.bindFilter(fdFilter, fdhkFilter, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdFirstFrame, fdhkFirstFrame, _ := fds.GetOK("first_frame")
+	if err := o.bindFirstFrame(fdFirstFrame, fdhkFirstFrame, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdFormat, fdhkFormat, _ := fds.GetOK("format")
+	if err := o.bindFormat(fdFormat, fdhkFormat, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdGamma, fdhkGamma, _ := fds.GetOK("gamma")
+	if err := o.bindGamma(fdGamma, fdhkGamma, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdGravity, fdhkGravity, _ := fds.GetOK("gravity")
+	if err := o.bindGravity(fdGravity, fdhkGravity, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdGrayscale, fdhkGrayscale, _ := fds.GetOK("grayscale")
+	if err := o.bindGrayscale(fdGrayscale, fdhkGrayscale, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdHeight, fdhkHeight, _ := fds.GetOK("height")
+	if err := o.bindHeight(fdHeight, fdhkHeight, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdMode, fdhkMode, _ := fds.GetOK("mode")
+	if err := o.bindMode(fdMode, fdhkMode, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdPreset, fdhkPreset, _ := fds.GetOK("preset")
+	if err := o.bindPreset(fdPreset, fdhkPreset, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdQuality, fdhkQuality, _ := fds.GetOK("quality")
+	if err := o.bindQuality(fdQuality, fdhkQuality, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdResize, fdhkResize, _ := fds.GetOK("resize")
+	if err := o.bindResize(fdResize, fdhkResize, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdSaturation, fdhkSaturation, _ := fds.GetOK("saturation")
+	if err := o.bindSaturation(fdSaturation, fdhkSaturation, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdSepia, fdhkSepia, _ := fds.GetOK("sepia")
+	if err := o.bindSepia(fdSepia, fdhkSepia, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdSharpen, fdhkSharpen, _ := fds.GetOK("sharpen")
+	if err := o.bindSharpen(fdSharpen, fdhkSharpen, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdToken, fdhkToken, _ := fds.GetOK("token")
+	if err := o.bindToken(fdToken, fdhkToken, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// bindBackground binds and validates parameter Background from formData.
+func (o *ResizeExistsParams) bindBackground(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Background = &raw
+
+	return nil
+}
+
+// bindBlur binds and validates parameter Blur from formData.
+func (o *ResizeExistsParams) bindBlur(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertFloat64(raw)
+	if err != nil {
+		return errors.InvalidType("blur", "formData", "float64", raw)
+	}
+	o.Blur = &value
+
+	if err := o.validateBlur(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateBlur carries on validations for parameter Blur
+func (o *ResizeExistsParams) validateBlur(formats strfmt.Registry) error {
+
+	if err := validate.Minimum("blur", "formData", float64(*o.Blur), 0, false); err != nil {
+		return err
+	}
+
+	if err := validate.Maximum("blur", "formData", float64(*o.Blur), 50, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindBrightness binds and validates parameter Brightness from formData.
+func (o *ResizeExistsParams) bindBrightness(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertFloat64(raw)
+	if err != nil {
+		return errors.InvalidType("brightness", "formData", "float64", raw)
+	}
+	o.Brightness = &value
+
+	if err := o.validateBrightness(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateBrightness carries on validations for parameter Brightness
+func (o *ResizeExistsParams) validateBrightness(formats strfmt.Registry) error {
+
+	if err := validate.Minimum("brightness", "formData", float64(*o.Brightness), -100, false); err != nil {
+		return err
+	}
+
+	if err := validate.Maximum("brightness", "formData", float64(*o.Brightness), 100, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindColors binds and validates parameter Colors from formData.
+func (o *ResizeExistsParams) bindColors(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("colors", "formData", "int64", raw)
+	}
+	o.Colors = &value
+
+	if err := o.validateColors(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateColors carries on validations for parameter Colors
+func (o *ResizeExistsParams) validateColors(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("colors", "formData", int64(*o.Colors), 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("colors", "formData", int64(*o.Colors), 256, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindCompression binds and validates parameter Compression from formData.
+func (o *ResizeExistsParams) bindCompression(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewResizeExistsParams()
+		return nil
+	}
+
+	o.Compression = &raw
+
+	if err := o.validateCompression(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateCompression carries on validations for parameter Compression
+func (o *ResizeExistsParams) validateCompression(formats strfmt.Registry) error {
+
+	if err := validate.Enum("compression", "formData", *o.Compression, []interface{}{"default", "none", "speed", "best"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindContrast binds and validates parameter Contrast from formData.
+func (o *ResizeExistsParams) bindContrast(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertFloat64(raw)
+	if err != nil {
+		return errors.InvalidType("contrast", "formData", "float64", raw)
+	}
+	o.Contrast = &value
+
+	if err := o.validateContrast(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateContrast carries on validations for parameter Contrast
+func (o *ResizeExistsParams) validateContrast(formats strfmt.Registry) error {
+
+	if err := validate.Minimum("contrast", "formData", float64(*o.Contrast), -100, false); err != nil {
+		return err
+	}
+
+	if err := validate.Maximum("contrast", "formData", float64(*o.Contrast), 100, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindDither binds and validates parameter Dither from formData.
+func (o *ResizeExistsParams) bindDither(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewResizeExistsParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("dither", "formData", "bool", raw)
+	}
+	o.Dither = &value
+
+	return nil
+}
+
+// bindFile binds and validates parameter File from formData.
+func (o *ResizeExistsParams) bindFile(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	if !hasKey {
+		return errors.Required("file", "formData")
+	}
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+
+	if err := validate.RequiredString("file", "formData", raw); err != nil {
+		return err
+	}
+
+	o.File = raw
+
+	return nil
+}
+
+// bindFilter binds and validates parameter Filter from formData.
+func (o *ResizeExistsParams) bindFilter(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Filter = &raw
+
+	if err := o.validateFilter(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateFilter carries on validations for parameter Filter
+func (o *ResizeExistsParams) validateFilter(formats strfmt.Registry) error {
+
+	if err := validate.Enum("filter", "formData", *o.Filter, []interface{}{"nearest", "bilinear", "bicubic", "mitchell", "lanczos2", "lanczos3"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindFirstFrame binds and validates parameter FirstFrame from formData.
+func (o *ResizeExistsParams) bindFirstFrame(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewResizeExistsParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("first_frame", "formData", "bool", raw)
+	}
+	o.FirstFrame = &value
+
+	return nil
+}
+
+// bindFormat binds and validates parameter Format from formData.
+func (o *ResizeExistsParams) bindFormat(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Format = &raw
+
+	if err := o.validateFormat(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateFormat carries on validations for parameter Format
+func (o *ResizeExistsParams) validateFormat(formats strfmt.Registry) error {
+
+	if err := validate.Enum("format", "formData", *o.Format, []interface{}{"jpeg", "png", "gif", "bmp", "tiff"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindGamma binds and validates parameter Gamma from formData.
+func (o *ResizeExistsParams) bindGamma(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertFloat64(raw)
+	if err != nil {
+		return errors.InvalidType("gamma", "formData", "float64", raw)
+	}
+	o.Gamma = &value
+
+	if err := o.validateGamma(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateGamma carries on validations for parameter Gamma
+func (o *ResizeExistsParams) validateGamma(formats strfmt.Registry) error {
+
+	if err := validate.Minimum("gamma", "formData", float64(*o.Gamma), 0.1, false); err != nil {
+		return err
+	}
+
+	if err := validate.Maximum("gamma", "formData", float64(*o.Gamma), 10, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindGravity binds and validates parameter Gravity from formData.
+func (o *ResizeExistsParams) bindGravity(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewResizeExistsParams()
+		return nil
+	}
+
+	o.Gravity = &raw
+
+	if err := o.validateGravity(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateGravity carries on validations for parameter Gravity
+func (o *ResizeExistsParams) validateGravity(formats strfmt.Registry) error {
+
+	if err := validate.Enum("gravity", "formData", *o.Gravity, []interface{}{"center", "north", "south", "east", "west", "northeast", "northwest", "southeast", "southwest"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindGrayscale binds and validates parameter Grayscale from formData.
+func (o *ResizeExistsParams) bindGrayscale(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewResizeExistsParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("grayscale", "formData", "bool", raw)
+	}
+	o.Grayscale = &value
+
+	return nil
+}
+
+// bindHeight binds and validates parameter Height from formData.
+func (o *ResizeExistsParams) bindHeight(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("height", "formData", "int64", raw)
+	}
+	o.Height = &value
+
+	if err := o.validateHeight(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateHeight carries on validations for parameter Height
+func (o *ResizeExistsParams) validateHeight(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("height", "formData", int64(*o.Height), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindMode binds and validates parameter Mode from formData.
+func (o *ResizeExistsParams) bindMode(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewResizeExistsParams()
+		return nil
+	}
+
+	o.Mode = &raw
+
+	if err := o.validateMode(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateMode carries on validations for parameter Mode
+func (o *ResizeExistsParams) validateMode(formats strfmt.Registry) error {
+
+	if err := validate.Enum("mode", "formData", *o.Mode, []interface{}{"fit", "fill", "stretch", "pad"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindPreset binds and validates parameter Preset from formData.
+func (o *ResizeExistsParams) bindPreset(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Preset = &raw
+
+	return nil
+}
+
+// bindQuality binds and validates parameter Quality from formData.
+func (o *ResizeExistsParams) bindQuality(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("quality", "formData", "int64", raw)
+	}
+	o.Quality = &value
+
+	if err := o.validateQuality(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateQuality carries on validations for parameter Quality
+func (o *ResizeExistsParams) validateQuality(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("quality", "formData", int64(*o.Quality), 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("quality", "formData", int64(*o.Quality), 100, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindResize binds and validates parameter Resize from formData.
+func (o *ResizeExistsParams) bindResize(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("resize", "formData", "int64", raw)
+	}
+	o.Resize = &value
+
+	return nil
+}
+
+// bindSaturation binds and validates parameter Saturation from formData.
+func (o *ResizeExistsParams) bindSaturation(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertFloat64(raw)
+	if err != nil {
+		return errors.InvalidType("saturation", "formData", "float64", raw)
+	}
+	o.Saturation = &value
+
+	if err := o.validateSaturation(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateSaturation carries on validations for parameter Saturation
+func (o *ResizeExistsParams) validateSaturation(formats strfmt.Registry) error {
+
+	if err := validate.Minimum("saturation", "formData", float64(*o.Saturation), -100, false); err != nil {
+		return err
+	}
+
+	if err := validate.Maximum("saturation", "formData", float64(*o.Saturation), 100, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindSepia binds and validates parameter Sepia from formData.
+func (o *ResizeExistsParams) bindSepia(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewResizeExistsParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("sepia", "formData", "bool", raw)
+	}
+	o.Sepia = &value
+
+	return nil
+}
+
+// bindSharpen binds and validates parameter Sharpen from formData.
+func (o *ResizeExistsParams) bindSharpen(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Sharpen = &raw
+
+	return nil
+}
+
+// bindToken binds and validates parameter Token from formData.
+func (o *ResizeExistsParams) bindToken(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	if !hasKey {
+		return errors.Required("token", "formData")
+	}
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+
+	if err := validate.RequiredString("token", "formData", raw); err != nil {
+		return err
+	}
+
+	o.Token = raw
+
+	return nil
+}
@@ -0,0 +1,149 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// ResizeExistsOKCode is the HTTP code returned for type ResizeExistsOK
+const ResizeExistsOKCode int = 200
+
+/*
+ResizeExistsOK resize result
+
+swagger:response resizeExistsOK
+*/
+type ResizeExistsOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Resize `json:"body,omitempty"`
+}
+
+// NewResizeExistsOK creates ResizeExistsOK with default headers values
+func NewResizeExistsOK() *ResizeExistsOK {
+
+	return &ResizeExistsOK{}
+}
+
+// WithPayload adds the payload to the resize exists o k response
+func (o *ResizeExistsOK) WithPayload(payload *models.Resize) *ResizeExistsOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the resize exists o k response
+func (o *ResizeExistsOK) SetPayload(payload *models.Resize) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ResizeExistsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// ResizeExistsBadRequestCode is the HTTP code returned for type ResizeExistsBadRequest
+const ResizeExistsBadRequestCode int = 400
+
+/*
+ResizeExistsBadRequest Bad Request
+
+swagger:response resizeExistsBadRequest
+*/
+type ResizeExistsBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewResizeExistsBadRequest creates ResizeExistsBadRequest with default headers values
+func NewResizeExistsBadRequest() *ResizeExistsBadRequest {
+
+	return &ResizeExistsBadRequest{}
+}
+
+// WithPayload adds the payload to the resize exists bad request response
+func (o *ResizeExistsBadRequest) WithPayload(payload *models.Error) *ResizeExistsBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the resize exists bad request response
+func (o *ResizeExistsBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ResizeExistsBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// ResizeExistsInternalServerErrorCode is the HTTP code returned for type ResizeExistsInternalServerError
+const ResizeExistsInternalServerErrorCode int = 500
+
+/*
+ResizeExistsInternalServerError Fatal
+
+swagger:response resizeExistsInternalServerError
+*/
+type ResizeExistsInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewResizeExistsInternalServerError creates ResizeExistsInternalServerError with default headers values
+func NewResizeExistsInternalServerError() *ResizeExistsInternalServerError {
+
+	return &ResizeExistsInternalServerError{}
+}
+
+// WithPayload adds the payload to the resize exists internal server error response
+func (o *ResizeExistsInternalServerError) WithPayload(payload *models.Error) *ResizeExistsInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the resize exists internal server error response
+func (o *ResizeExistsInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ResizeExistsInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
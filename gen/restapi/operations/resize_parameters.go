@@ -13,17 +13,39 @@ import (
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
-
-	strfmt "github.com/go-openapi/strfmt"
 )
 
 // NewResizeParams creates a new ResizeParams object
-// no default values defined in spec.
+// with the default values initialized.
 func NewResizeParams() ResizeParams {
 
-	return ResizeParams{}
+	var (
+		// initialize parameters with default values
+
+		compressionDefault = string("default")
+		ditherDefault      = bool(true)
+
+		firstFrameDefault = bool(false)
+
+		gravityDefault = string("center")
+
+		modeDefault = string("fit")
+	)
+
+	return ResizeParams{
+		Compression: &compressionDefault,
+
+		Dither: &ditherDefault,
+
+		FirstFrame: &firstFrameDefault,
+
+		Gravity: &gravityDefault,
+
+		Mode: &modeDefault,
+	}
 }
 
 // ResizeParams contains all the bound params for the resize operation
@@ -35,7 +57,61 @@ type ResizeParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
-	/*Param of file resize.
+	/*Padding color in pad mode, rgb, rrggbb or rrggbbaa hex.
+	  In: formData
+	*/
+	Background *string
+	/*GIF palette size from 1 to 256.
+	  Maximum: 256
+	  Minimum: 1
+	  In: formData
+	*/
+	Colors *int64
+	/*PNG compression level.
+	  In: formData
+	  Default: "default"
+	*/
+	Compression *string
+	/*Use Floyd-Steinberg dithering for GIF.
+	  In: formData
+	  Default: true
+	*/
+	Dither *bool
+	/*Resampling filter, the server default if not set.
+	  In: formData
+	*/
+	Filter *string
+	/*Keep only the first frame of an animated GIF.
+	  In: formData
+	  Default: false
+	*/
+	FirstFrame *bool
+	/*Output format, the input's format by default.
+	  In: formData
+	*/
+	Format *string
+	/*Which part of the image to keep in fill mode and where to place it in pad mode.
+	  In: formData
+	  Default: "center"
+	*/
+	Gravity *string
+	/*Height of the result. With only one of width and height the other side is proportional.
+	  Minimum: 0
+	  In: formData
+	*/
+	Height *int64
+	/*How to fit the image when both width and height are set.
+	  In: formData
+	  Default: "fit"
+	*/
+	Mode *string
+	/*JPEG quality from 1 to 100.
+	  Maximum: 100
+	  Minimum: 1
+	  In: formData
+	*/
+	Quality *int64
+	/*Param of file resize. Width of the result.
 	  Required: true
 	  In: formData
 	*/
@@ -69,6 +145,61 @@ func (o *ResizeParams) BindRequest(r *http.Request, route *middleware.MatchedRou
 	}
 	fds := runtime.Values(r.Form)
 
+	fdBackground, fdhkBackground, _ := fds.GetOK("background")
+	if err := o.bindBackground(fdBackground, fdhkBackground, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdColors, fdhkColors, _ := fds.GetOK("colors")
+	if err := o.bindColors(fdColors, fdhkColors, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdCompression, fdhkCompression, _ := fds.GetOK("compression")
+	if err := o.bindCompression(fdCompression, fdhkCompression, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdDither, fdhkDither, _ := fds.GetOK("dither")
+	if err := o.bindDither(fdDither, fdhkDither, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdFilter, fdhkFilter, _ := fds.GetOK("filter")
+	if err := o.bindFilter(fdFilter, fdhkFilter, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdFirstFrame, fdhkFirstFrame, _ := fds.GetOK("first_frame")
+	if err := o.bindFirstFrame(fdFirstFrame, fdhkFirstFrame, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdFormat, fdhkFormat, _ := fds.GetOK("format")
+	if err := o.bindFormat(fdFormat, fdhkFormat, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdGravity, fdhkGravity, _ := fds.GetOK("gravity")
+	if err := o.bindGravity(fdGravity, fdhkGravity, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdHeight, fdhkHeight, _ := fds.GetOK("height")
+	if err := o.bindHeight(fdHeight, fdhkHeight, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdMode, fdhkMode, _ := fds.GetOK("mode")
+	if err := o.bindMode(fdMode, fdhkMode, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdQuality, fdhkQuality, _ := fds.GetOK("quality")
+	if err := o.bindQuality(fdQuality, fdhkQuality, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	fdResize, fdhkResize, _ := fds.GetOK("resize")
 	if err := o.bindResize(fdResize, fdhkResize, route.Formats); err != nil {
 		res = append(res, err)
@@ -96,6 +227,349 @@ func (o *ResizeParams) BindRequest(r *http.Request, route *middleware.MatchedRou
 	return nil
 }
 
+// bindBackground binds and validates parameter Background from formData.
+func (o *ResizeParams) bindBackground(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Background = &raw
+
+	return nil
+}
+
+// bindColors binds and validates parameter Colors from formData.
+func (o *ResizeParams) bindColors(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("colors", "formData", "int64", raw)
+	}
+	o.Colors = &value
+
+	if err := o.validateColors(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateColors carries on validations for parameter Colors
+func (o *ResizeParams) validateColors(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("colors", "formData", int64(*o.Colors), 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("colors", "formData", int64(*o.Colors), 256, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindCompression binds and validates parameter Compression from formData.
+func (o *ResizeParams) bindCompression(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewResizeParams()
+		return nil
+	}
+
+	o.Compression = &raw
+
+	if err := o.validateCompression(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateCompression carries on validations for parameter Compression
+func (o *ResizeParams) validateCompression(formats strfmt.Registry) error {
+
+	if err := validate.Enum("compression", "formData", *o.Compression, []interface{}{"default", "none", "speed", "best"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindDither binds and validates parameter Dither from formData.
+func (o *ResizeParams) bindDither(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewResizeParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("dither", "formData", "bool", raw)
+	}
+	o.Dither = &value
+
+	return nil
+}
+
+// bindFilter binds and validates parameter Filter from formData.
+func (o *ResizeParams) bindFilter(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Filter = &raw
+
+	if err := o.validateFilter(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateFilter carries on validations for parameter Filter
+func (o *ResizeParams) validateFilter(formats strfmt.Registry) error {
+
+	if err := validate.Enum("filter", "formData", *o.Filter, []interface{}{"nearest", "bilinear", "bicubic", "mitchell", "lanczos2", "lanczos3"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindFirstFrame binds and validates parameter FirstFrame from formData.
+func (o *ResizeParams) bindFirstFrame(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewResizeParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("first_frame", "formData", "bool", raw)
+	}
+	o.FirstFrame = &value
+
+	return nil
+}
+
+// bindFormat binds and validates parameter Format from formData.
+func (o *ResizeParams) bindFormat(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Format = &raw
+
+	if err := o.validateFormat(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateFormat carries on validations for parameter Format
+func (o *ResizeParams) validateFormat(formats strfmt.Registry) error {
+
+	if err := validate.Enum("format", "formData", *o.Format, []interface{}{"jpeg", "png", "gif", "bmp", "tiff"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindGravity binds and validates parameter Gravity from formData.
+func (o *ResizeParams) bindGravity(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewResizeParams()
+		return nil
+	}
+
+	o.Gravity = &raw
+
+	if err := o.validateGravity(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateGravity carries on validations for parameter Gravity
+func (o *ResizeParams) validateGravity(formats strfmt.Registry) error {
+
+	if err := validate.Enum("gravity", "formData", *o.Gravity, []interface{}{"center", "north", "south", "east", "west", "northeast", "northwest", "southeast", "southwest"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindHeight binds and validates parameter Height from formData.
+func (o *ResizeParams) bindHeight(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("height", "formData", "int64", raw)
+	}
+	o.Height = &value
+
+	if err := o.validateHeight(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateHeight carries on validations for parameter Height
+func (o *ResizeParams) validateHeight(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("height", "formData", int64(*o.Height), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindMode binds and validates parameter Mode from formData.
+func (o *ResizeParams) bindMode(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewResizeParams()
+		return nil
+	}
+
+	o.Mode = &raw
+
+	if err := o.validateMode(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateMode carries on validations for parameter Mode
+func (o *ResizeParams) validateMode(formats strfmt.Registry) error {
+
+	if err := validate.Enum("mode", "formData", *o.Mode, []interface{}{"fit", "fill", "stretch", "pad"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindQuality binds and validates parameter Quality from formData.
+func (o *ResizeParams) bindQuality(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("quality", "formData", "int64", raw)
+	}
+	o.Quality = &value
+
+	if err := o.validateQuality(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateQuality carries on validations for parameter Quality
+func (o *ResizeParams) validateQuality(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("quality", "formData", int64(*o.Quality), 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("quality", "formData", int64(*o.Quality), 100, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // bindResize binds and validates parameter Resize from formData.
 func (o *ResizeParams) bindResize(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	if !hasKey {
@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// ResultHandlerFunc turns a function with the right signature into a result handler
+type ResultHandlerFunc func(ResultParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn ResultHandlerFunc) Handle(params ResultParams) middleware.Responder {
+	return fn(params)
+}
+
+// ResultHandler interface for that can handle valid result params
+type ResultHandler interface {
+	Handle(ResultParams) middleware.Responder
+}
+
+// NewResult creates a new http.Handler for the result operation
+func NewResult(ctx *middleware.Context, handler ResultHandler) *Result {
+	return &Result{Context: ctx, Handler: handler}
+}
+
+/*
+Result swagger:route GET /v2/result result
+
+Result result API
+*/
+type Result struct {
+	Context *middleware.Context
+	Handler ResultHandler
+}
+
+func (o *Result) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewResultParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
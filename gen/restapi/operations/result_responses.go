@@ -0,0 +1,149 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// ResultOKCode is the HTTP code returned for type ResultOK
+const ResultOKCode int = 200
+
+/*
+ResultOK file resize result
+
+swagger:response resultOK
+*/
+type ResultOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Resize `json:"body,omitempty"`
+}
+
+// NewResultOK creates ResultOK with default headers values
+func NewResultOK() *ResultOK {
+
+	return &ResultOK{}
+}
+
+// WithPayload adds the payload to the result o k response
+func (o *ResultOK) WithPayload(payload *models.Resize) *ResultOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the result o k response
+func (o *ResultOK) SetPayload(payload *models.Resize) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ResultOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// ResultBadRequestCode is the HTTP code returned for type ResultBadRequest
+const ResultBadRequestCode int = 400
+
+/*
+ResultBadRequest Bad Request
+
+swagger:response resultBadRequest
+*/
+type ResultBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewResultBadRequest creates ResultBadRequest with default headers values
+func NewResultBadRequest() *ResultBadRequest {
+
+	return &ResultBadRequest{}
+}
+
+// WithPayload adds the payload to the result bad request response
+func (o *ResultBadRequest) WithPayload(payload *models.Error) *ResultBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the result bad request response
+func (o *ResultBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ResultBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// ResultInternalServerErrorCode is the HTTP code returned for type ResultInternalServerError
+const ResultInternalServerErrorCode int = 500
+
+/*
+ResultInternalServerError Fatal
+
+swagger:response resultInternalServerError
+*/
+type ResultInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewResultInternalServerError creates ResultInternalServerError with default headers values
+func NewResultInternalServerError() *ResultInternalServerError {
+
+	return &ResultInternalServerError{}
+}
+
+// WithPayload adds the payload to the result internal server error response
+func (o *ResultInternalServerError) WithPayload(payload *models.Error) *ResultInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the result internal server error response
+func (o *ResultInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ResultInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
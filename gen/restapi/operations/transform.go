@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// TransformHandlerFunc turns a function with the right signature into a transform handler
+type TransformHandlerFunc func(TransformParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn TransformHandlerFunc) Handle(params TransformParams) middleware.Responder {
+	return fn(params)
+}
+
+// TransformHandler interface for that can handle valid transform params
+type TransformHandler interface {
+	Handle(TransformParams) middleware.Responder
+}
+
+// NewTransform creates a new http.Handler for the transform operation
+func NewTransform(ctx *middleware.Context, handler TransformHandler) *Transform {
+	return &Transform{Context: ctx, Handler: handler}
+}
+
+/*
+Transform swagger:route GET /img/{options}/{image} transform
+
+Transform transform API
+*/
+type Transform struct {
+	Context *middleware.Context
+	Handler TransformHandler
+}
+
+func (o *Transform) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewTransformParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
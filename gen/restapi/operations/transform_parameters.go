@@ -0,0 +1,159 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewTransformParams creates a new TransformParams object
+// no default values defined in spec.
+func NewTransformParams() TransformParams {
+
+	return TransformParams{}
+}
+
+// TransformParams contains all the bound params for the transform operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters transform
+type TransformParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*Unix time after which a signed url stops working
+	  In: query
+	*/
+	Expires *int64
+	/*Id of an uploaded image
+	  Required: true
+	  In: path
+	*/
+	Image string
+	/*Transformation options: comma separated key:value pairs, e.g. w:200,h:100,m:fill,f:jpeg,q:80. Width and height are at most 8192 and the result and the source image are at most 50000000 pixels
+	  Required: true
+	  In: path
+	*/
+	Options string
+	/*HMAC-SHA256 signature of the url. Required when url signing is enabled
+	  In: query
+	*/
+	Signature *string
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewTransformParams() beforehand.
+func (o *TransformParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	qs := runtime.Values(r.URL.Query())
+
+	qExpires, qhkExpires, _ := qs.GetOK("expires")
+	if err := o.bindExpires(qExpires, qhkExpires, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	rImage, rhkImage, _ := route.Params.GetOK("image")
+	if err := o.bindImage(rImage, rhkImage, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	rOptions, rhkOptions, _ := route.Params.GetOK("options")
+	if err := o.bindOptions(rOptions, rhkOptions, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qSignature, qhkSignature, _ := qs.GetOK("signature")
+	if err := o.bindSignature(qSignature, qhkSignature, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// bindExpires binds and validates parameter Expires from query.
+func (o *TransformParams) bindExpires(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("expires", "query", "int64", raw)
+	}
+	o.Expires = &value
+
+	return nil
+}
+
+// bindImage binds and validates parameter Image from path.
+func (o *TransformParams) bindImage(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+	// Parameter is provided by construction from the route
+
+	o.Image = raw
+
+	return nil
+}
+
+// bindOptions binds and validates parameter Options from path.
+func (o *TransformParams) bindOptions(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+	// Parameter is provided by construction from the route
+
+	o.Options = raw
+
+	return nil
+}
+
+// bindSignature binds and validates parameter Signature from query.
+func (o *TransformParams) bindSignature(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Signature = &raw
+
+	return nil
+}
@@ -0,0 +1,193 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// TransformOKCode is the HTTP code returned for type TransformOK
+const TransformOKCode int = 200
+
+/*
+TransformOK transformed image
+
+swagger:response transformOK
+*/
+type TransformOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload io.ReadCloser `json:"body,omitempty"`
+}
+
+// NewTransformOK creates TransformOK with default headers values
+func NewTransformOK() *TransformOK {
+
+	return &TransformOK{}
+}
+
+// WithPayload adds the payload to the transform o k response
+func (o *TransformOK) WithPayload(payload io.ReadCloser) *TransformOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the transform o k response
+func (o *TransformOK) SetPayload(payload io.ReadCloser) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *TransformOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// TransformBadRequestCode is the HTTP code returned for type TransformBadRequest
+const TransformBadRequestCode int = 400
+
+/*
+TransformBadRequest Bad Request
+
+swagger:response transformBadRequest
+*/
+type TransformBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewTransformBadRequest creates TransformBadRequest with default headers values
+func NewTransformBadRequest() *TransformBadRequest {
+
+	return &TransformBadRequest{}
+}
+
+// WithPayload adds the payload to the transform bad request response
+func (o *TransformBadRequest) WithPayload(payload *models.Error) *TransformBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the transform bad request response
+func (o *TransformBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *TransformBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// TransformForbiddenCode is the HTTP code returned for type TransformForbidden
+const TransformForbiddenCode int = 403
+
+/*
+TransformForbidden Forbidden
+
+swagger:response transformForbidden
+*/
+type TransformForbidden struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewTransformForbidden creates TransformForbidden with default headers values
+func NewTransformForbidden() *TransformForbidden {
+
+	return &TransformForbidden{}
+}
+
+// WithPayload adds the payload to the transform forbidden response
+func (o *TransformForbidden) WithPayload(payload *models.Error) *TransformForbidden {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the transform forbidden response
+func (o *TransformForbidden) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *TransformForbidden) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(403)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// TransformInternalServerErrorCode is the HTTP code returned for type TransformInternalServerError
+const TransformInternalServerErrorCode int = 500
+
+/*
+TransformInternalServerError Fatal
+
+swagger:response transformInternalServerError
+*/
+type TransformInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewTransformInternalServerError creates TransformInternalServerError with default headers values
+func NewTransformInternalServerError() *TransformInternalServerError {
+
+	return &TransformInternalServerError{}
+}
+
+// WithPayload adds the payload to the transform internal server error response
+func (o *TransformInternalServerError) WithPayload(payload *models.Error) *TransformInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the transform internal server error response
+func (o *TransformInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *TransformInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
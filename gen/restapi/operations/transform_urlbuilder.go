@@ -0,0 +1,129 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"errors"
+	"net/url"
+	golangswaggerpaths "path"
+	"strings"
+
+	"github.com/go-openapi/swag"
+)
+
+// TransformURL generates an URL for the transform operation
+type TransformURL struct {
+	Image   string
+	Options string
+
+	Expires   *int64
+	Signature *string
+
+	_basePath string
+	// avoid unkeyed usage
+	_ struct{}
+}
+
+// WithBasePath sets the base path for this url builder, only required when it's different from the
+// base path specified in the swagger spec.
+// When the value of the base path is an empty string
+func (o *TransformURL) WithBasePath(bp string) *TransformURL {
+	o.SetBasePath(bp)
+	return o
+}
+
+// SetBasePath sets the base path for this url builder, only required when it's different from the
+// base path specified in the swagger spec.
+// When the value of the base path is an empty string
+func (o *TransformURL) SetBasePath(bp string) {
+	o._basePath = bp
+}
+
+// Build a url path and query string
+func (o *TransformURL) Build() (*url.URL, error) {
+	var _result url.URL
+
+	var _path = "/img/{options}/{image}"
+
+	image := o.Image
+	if image != "" {
+		_path = strings.Replace(_path, "{image}", image, -1)
+	} else {
+		return nil, errors.New("image is required on TransformURL")
+	}
+
+	options := o.Options
+	if options != "" {
+		_path = strings.Replace(_path, "{options}", options, -1)
+	} else {
+		return nil, errors.New("options is required on TransformURL")
+	}
+
+	_basePath := o._basePath
+	_result.Path = golangswaggerpaths.Join(_basePath, _path)
+
+	qs := make(url.Values)
+
+	var expiresQ string
+	if o.Expires != nil {
+		expiresQ = swag.FormatInt64(*o.Expires)
+	}
+	if expiresQ != "" {
+		qs.Set("expires", expiresQ)
+	}
+
+	var signatureQ string
+	if o.Signature != nil {
+		signatureQ = *o.Signature
+	}
+	if signatureQ != "" {
+		qs.Set("signature", signatureQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
+	return &_result, nil
+}
+
+// Must is a helper function to panic when the url builder returns an error
+func (o *TransformURL) Must(u *url.URL, err error) *url.URL {
+	if err != nil {
+		panic(err)
+	}
+	if u == nil {
+		panic("url can't be nil")
+	}
+	return u
+}
+
+// String returns the string representation of the path with query string
+func (o *TransformURL) String() string {
+	return o.Must(o.Build()).String()
+}
+
+// BuildFull builds a full url with scheme, host, path and query string
+func (o *TransformURL) BuildFull(scheme, host string) (*url.URL, error) {
+	if scheme == "" {
+		return nil, errors.New("scheme is required for a full url on TransformURL")
+	}
+	if host == "" {
+		return nil, errors.New("host is required for a full url on TransformURL")
+	}
+
+	base, err := o.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	base.Scheme = scheme
+	base.Host = host
+	return base, nil
+}
+
+// StringFull returns the string representation of a complete url
+func (o *TransformURL) StringFull(scheme, host string) string {
+	return o.Must(o.BuildFull(scheme, host)).String()
+}
@@ -0,0 +1,147 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// UploadOKCode is the HTTP code returned for type UploadOK
+const UploadOKCode int = 200
+
+/*
+UploadOK upload result
+
+swagger:response uploadOK
+*/
+type UploadOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload string `json:"body,omitempty"`
+}
+
+// NewUploadOK creates UploadOK with default headers values
+func NewUploadOK() *UploadOK {
+
+	return &UploadOK{}
+}
+
+// WithPayload adds the payload to the upload o k response
+func (o *UploadOK) WithPayload(payload string) *UploadOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the upload o k response
+func (o *UploadOK) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *UploadOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// UploadBadRequestCode is the HTTP code returned for type UploadBadRequest
+const UploadBadRequestCode int = 400
+
+/*
+UploadBadRequest Bad Request
+
+swagger:response uploadBadRequest
+*/
+type UploadBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewUploadBadRequest creates UploadBadRequest with default headers values
+func NewUploadBadRequest() *UploadBadRequest {
+
+	return &UploadBadRequest{}
+}
+
+// WithPayload adds the payload to the upload bad request response
+func (o *UploadBadRequest) WithPayload(payload *models.Error) *UploadBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the upload bad request response
+func (o *UploadBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *UploadBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// UploadInternalServerErrorCode is the HTTP code returned for type UploadInternalServerError
+const UploadInternalServerErrorCode int = 500
+
+/*
+UploadInternalServerError Fatal
+
+swagger:response uploadInternalServerError
+*/
+type UploadInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewUploadInternalServerError creates UploadInternalServerError with default headers values
+func NewUploadInternalServerError() *UploadInternalServerError {
+
+	return &UploadInternalServerError{}
+}
+
+// WithPayload adds the payload to the upload internal server error response
+func (o *UploadInternalServerError) WithPayload(payload *models.Error) *UploadInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the upload internal server error response
+func (o *UploadInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *UploadInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
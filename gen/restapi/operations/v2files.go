@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// V2filesHandlerFunc turns a function with the right signature into a v2files handler
+type V2filesHandlerFunc func(V2filesParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn V2filesHandlerFunc) Handle(params V2filesParams) middleware.Responder {
+	return fn(params)
+}
+
+// V2filesHandler interface for that can handle valid v2files params
+type V2filesHandler interface {
+	Handle(V2filesParams) middleware.Responder
+}
+
+// NewV2files creates a new http.Handler for the v2files operation
+func NewV2files(ctx *middleware.Context, handler V2filesHandler) *V2files {
+	return &V2files{Context: ctx, Handler: handler}
+}
+
+/*
+V2files swagger:route GET /v2/files v2files
+
+V2files v2files API
+*/
+type V2files struct {
+	Context *middleware.Context
+	Handler V2filesHandler
+}
+
+func (o *V2files) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewV2filesParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,147 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// V2filesOKCode is the HTTP code returned for type V2filesOK
+const V2filesOKCode int = 200
+
+/*
+V2filesOK file list
+
+swagger:response v2filesOK
+*/
+type V2filesOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload interface{} `json:"body,omitempty"`
+}
+
+// NewV2filesOK creates V2filesOK with default headers values
+func NewV2filesOK() *V2filesOK {
+
+	return &V2filesOK{}
+}
+
+// WithPayload adds the payload to the v2files o k response
+func (o *V2filesOK) WithPayload(payload interface{}) *V2filesOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the v2files o k response
+func (o *V2filesOK) SetPayload(payload interface{}) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *V2filesOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// V2filesBadRequestCode is the HTTP code returned for type V2filesBadRequest
+const V2filesBadRequestCode int = 400
+
+/*
+V2filesBadRequest Bad Request
+
+swagger:response v2filesBadRequest
+*/
+type V2filesBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewV2filesBadRequest creates V2filesBadRequest with default headers values
+func NewV2filesBadRequest() *V2filesBadRequest {
+
+	return &V2filesBadRequest{}
+}
+
+// WithPayload adds the payload to the v2files bad request response
+func (o *V2filesBadRequest) WithPayload(payload *models.Error) *V2filesBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the v2files bad request response
+func (o *V2filesBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *V2filesBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// V2filesInternalServerErrorCode is the HTTP code returned for type V2filesInternalServerError
+const V2filesInternalServerErrorCode int = 500
+
+/*
+V2filesInternalServerError Fatal
+
+swagger:response v2filesInternalServerError
+*/
+type V2filesInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewV2filesInternalServerError creates V2filesInternalServerError with default headers values
+func NewV2filesInternalServerError() *V2filesInternalServerError {
+
+	return &V2filesInternalServerError{}
+}
+
+// WithPayload adds the payload to the v2files internal server error response
+func (o *V2filesInternalServerError) WithPayload(payload *models.Error) *V2filesInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the v2files internal server error response
+func (o *V2filesInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *V2filesInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
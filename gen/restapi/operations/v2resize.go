@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// V2resizeHandlerFunc turns a function with the right signature into a v2resize handler
+type V2resizeHandlerFunc func(V2resizeParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn V2resizeHandlerFunc) Handle(params V2resizeParams) middleware.Responder {
+	return fn(params)
+}
+
+// V2resizeHandler interface for that can handle valid v2resize params
+type V2resizeHandler interface {
+	Handle(V2resizeParams) middleware.Responder
+}
+
+// NewV2resize creates a new http.Handler for the v2resize operation
+func NewV2resize(ctx *middleware.Context, handler V2resizeHandler) *V2resize {
+	return &V2resize{Context: ctx, Handler: handler}
+}
+
+/*
+V2resize swagger:route POST /v2/resize v2resize
+
+V2resize v2resize API
+*/
+type V2resize struct {
+	Context *middleware.Context
+	Handler V2resizeHandler
+}
+
+func (o *V2resize) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		r = rCtx
+	}
+	var Params = NewV2resizeParams()
+
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,1621 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// NewV2resizeParams creates a new V2resizeParams object
+// with the default values initialized.
+func NewV2resizeParams() V2resizeParams {
+
+	var (
+		// initialize parameters with default values
+
+		compressionDefault = string("default")
+
+		ditherDefault = bool(true)
+
+		firstFrameDefault = bool(false)
+
+		gravityDefault   = string("center")
+		grayscaleDefault = bool(false)
+
+		modeDefault = string("fit")
+
+		sepiaDefault = bool(false)
+
+		watermarkColorDefault   = string("ffffff")
+		watermarkGravityDefault = string("southeast")
+
+		watermarkOpacityDefault = float64(1)
+		watermarkScaleDefault   = float64(0.25)
+
+		watermarkTileDefault = bool(false)
+	)
+
+	return V2resizeParams{
+		Compression: &compressionDefault,
+
+		Dither: &ditherDefault,
+
+		FirstFrame: &firstFrameDefault,
+
+		Gravity: &gravityDefault,
+
+		Grayscale: &grayscaleDefault,
+
+		Mode: &modeDefault,
+
+		Sepia: &sepiaDefault,
+
+		WatermarkColor: &watermarkColorDefault,
+
+		WatermarkGravity: &watermarkGravityDefault,
+
+		WatermarkOpacity: &watermarkOpacityDefault,
+
+		WatermarkScale: &watermarkScaleDefault,
+
+		WatermarkTile: &watermarkTileDefault,
+	}
+}
+
+// V2resizeParams contains all the bound params for the v2resize operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters v2resize
+type V2resizeParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*Padding color in pad mode and for the corners of a rotated image, rgb, rrggbb or rrggbbaa hex.
+	  In: formData
+	*/
+	Background *string
+	/*Gaussian blur sigma in pixels of the result, up to 50.
+	  Maximum: 50
+	  Minimum: 0
+	  In: formData
+	*/
+	Blur *float64
+	/*Brightness change after resizing, from -100 to 100 percent.
+	  Maximum: 100
+	  Minimum: -100
+	  In: formData
+	*/
+	Brightness *float64
+	/*Where to POST the signed task result when the task is finished. The body has id, status, error, attempts, original, resized and sizes like /v2/result
+	  In: formData
+	*/
+	CallbackURL *string
+	/*GIF palette size from 1 to 256.
+	  Maximum: 256
+	  Minimum: 1
+	  In: formData
+	*/
+	Colors *int64
+	/*PNG compression level.
+	  In: formData
+	  Default: "default"
+	*/
+	Compression *string
+	/*Contrast change after resizing, from -100 to 100 percent.
+	  Maximum: 100
+	  Minimum: -100
+	  In: formData
+	*/
+	Contrast *float64
+	/*Rectangle to cut out before resizing: x,y,width,height in pixels of the original.
+	  In: formData
+	*/
+	Crop *string
+	/*Use Floyd-Steinberg dithering for GIF.
+	  In: formData
+	  Default: true
+	*/
+	Dither *bool
+	/*
+	  Required: true
+	  In: formData
+	*/
+	File string
+	/*Resampling filter, the server default if not set.
+	  In: formData
+	*/
+	Filter *string
+	/*Keep only the first frame of an animated GIF.
+	  In: formData
+	  Default: false
+	*/
+	FirstFrame *bool
+	/*Flip after rotation.
+	  In: formData
+	*/
+	Flip *string
+	/*Output format, the input's format by default.
+	  In: formData
+	*/
+	Format *string
+	/*Gamma correction after resizing, from 0.1 to 10. Values above 1 brighten midtones.
+	  Maximum: 10
+	  Minimum: 0.1
+	  In: formData
+	*/
+	Gamma *float64
+	/*Which part of the image to keep in fill mode and where to place it in pad mode.
+	  In: formData
+	  Default: "center"
+	*/
+	Gravity *string
+	/*Convert the result to grayscale.
+	  In: formData
+	  Default: false
+	*/
+	Grayscale *bool
+	/*Height of the result. With only one of width and height the other side is proportional.
+	  Minimum: 0
+	  In: formData
+	*/
+	Height *int64
+	/*How many times the task is retried on transient errors.
+	  Maximum: 20
+	  Minimum: 1
+	  In: formData
+	*/
+	MaxAttempts *int64
+	/*How to fit the image when both width and height are set.
+	  In: formData
+	  Default: "fit"
+	*/
+	Mode *string
+	/*JSON array of operations applied in order, e.g. [{"op":"crop","x":0,"y":0,"width":100,"height":100},{"op":"resize","width":50},{"op":"encode","format":"jpeg","quality":80}]. Cannot be combined with the other resize parameters
+	  In: formData
+	*/
+	Pipeline *string
+	/*Name of a preset from /v2/presets to use instead of the resize parameters
+	  In: formData
+	*/
+	Preset *string
+	/*JPEG quality from 1 to 100.
+	  Maximum: 100
+	  Minimum: 1
+	  In: formData
+	*/
+	Quality *int64
+	/*Param of file resize. Width of the result. Required when sizes is not set.
+	  In: formData
+	*/
+	Resize *int64
+	/*Clockwise rotation in degrees before resizing. Corners are filled with background unless the angle is a multiple of 90.
+	  In: formData
+	*/
+	Rotate *float64
+	/*Saturation change after resizing, from -100 to 100 percent.
+	  Maximum: 100
+	  Minimum: -100
+	  In: formData
+	*/
+	Saturation *float64
+	/*Apply a sepia tone to the result.
+	  In: formData
+	  Default: false
+	*/
+	Sepia *bool
+	/*Unsharp mask after resizing: sigma[,amount[,threshold]], e.g. 1,1.5,2. Amount is 1 by default.
+	  In: formData
+	*/
+	Sharpen *string
+	/*Several widths to make from one download of the file, comma separated.
+	  Max Items: 20
+	  In: formData
+	  Collection Format: csv
+	*/
+	Sizes []int64
+	/*
+	  Required: true
+	  In: formData
+	*/
+	Token string
+	/*Id of an uploaded image drawn over the result. Use either watermark or watermark_text.
+	  In: formData
+	*/
+	Watermark *string
+	/*Text watermark color as rgb, rrggbb or rrggbbaa.
+	  In: formData
+	  Default: "ffffff"
+	*/
+	WatermarkColor *string
+	/*Where to place the watermark.
+	  In: formData
+	  Default: "southeast"
+	*/
+	WatermarkGravity *string
+	/*Distance from the edges in pixels of the result. When tiling, the gap between copies.
+	  Minimum: 0
+	  In: formData
+	*/
+	WatermarkMargin *int64
+	/*Watermark opacity from 0 to 1.
+	  Maximum: 1
+	  Minimum: > 0
+	  In: formData
+	  Default: 1
+	*/
+	WatermarkOpacity *float64
+	/*Watermark width relative to the result width.
+	  Maximum: 1
+	  Minimum: > 0
+	  In: formData
+	  Default: 0.25
+	*/
+	WatermarkScale *float64
+	/*Text drawn over the result with the bundled Go Bold font.
+	  Max Length: 200
+	  In: formData
+	*/
+	WatermarkText *string
+	/*Repeat the watermark over the whole result. Gravity is ignored.
+	  In: formData
+	  Default: false
+	*/
+	WatermarkTile *bool
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewV2resizeParams() beforehand.
+func (o *V2resizeParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		if err != http.ErrNotMultipart {
+			return errors.New(400, "%v", err)
+		} else if err := r.ParseForm(); err != nil {
+			return errors.New(400, "%v", err)
+		}
+	}
+	fds := runtime.Values(r.Form)
+
+	fdBackground, fdhkBackground, _ := fds.GetOK("background")
+	if err := o.bindBackground(fdBackground, fdhkBackground, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdBlur, fdhkBlur, _ := fds.GetOK("blur")
+	if err := o.bindBlur(fdBlur, fdhkBlur, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdBrightness, fdhkBrightness, _ := fds.GetOK("brightness")
+	if err := o.bindBrightness(fdBrightness, fdhkBrightness, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdCallbackURL, fdhkCallbackURL, _ := fds.GetOK("callback_url")
+	if err := o.bindCallbackURL(fdCallbackURL, fdhkCallbackURL, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdColors, fdhkColors, _ := fds.GetOK("colors")
+	if err := o.bindColors(fdColors, fdhkColors, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdCompression, fdhkCompression, _ := fds.GetOK("compression")
+	if err := o.bindCompression(fdCompression, fdhkCompression, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdContrast, fdhkContrast, _ := fds.GetOK("contrast")
+	if err := o.bindContrast(fdContrast, fdhkContrast, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdCrop, fdhkCrop, _ := fds.GetOK("crop")
+	if err := o.bindCrop(fdCrop, fdhkCrop, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdDither, fdhkDither, _ := fds.GetOK("dither")
+	if err := o.bindDither(fdDither, fdhkDither, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdFile, fdhkFile, _ := fds.GetOK("file")
+	if err := o.bindFile(fdFile, fdhkFile, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdFilter, fdhkFilter, _ := fds.GetOK("filter")
+	if err := o.bindFilter(fdFilter, fdhkFilter, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdFirstFrame, fdhkFirstFrame, _ := fds.GetOK("first_frame")
+	if err := o.bindFirstFrame(fdFirstFrame, fdhkFirstFrame, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdFlip, fdhkFlip, _ := fds.GetOK("flip")
+	if err := o.bindFlip(fdFlip, fdhkFlip, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdFormat, fdhkFormat, _ := fds.GetOK("format")
+	if err := o.bindFormat(fdFormat, fdhkFormat, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdGamma, fdhkGamma, _ := fds.GetOK("gamma")
+	if err := o.bindGamma(fdGamma, fdhkGamma, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdGravity, fdhkGravity, _ := fds.GetOK("gravity")
+	if err := o.bindGravity(fdGravity, fdhkGravity, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdGrayscale, fdhkGrayscale, _ := fds.GetOK("grayscale")
+	if err := o.bindGrayscale(fdGrayscale, fdhkGrayscale, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdHeight, fdhkHeight, _ := fds.GetOK("height")
+	if err := o.bindHeight(fdHeight, fdhkHeight, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdMaxAttempts, fdhkMaxAttempts, _ := fds.GetOK("max_attempts")
+	if err := o.bindMaxAttempts(fdMaxAttempts, fdhkMaxAttempts, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdMode, fdhkMode, _ := fds.GetOK("mode")
+	if err := o.bindMode(fdMode, fdhkMode, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdPipeline, fdhkPipeline, _ := fds.GetOK("pipeline")
+	if err := o.bindPipeline(fdPipeline, fdhkPipeline, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdPreset, fdhkPreset, _ := fds.GetOK("preset")
+	if err := o.bindPreset(fdPreset, fdhkPreset, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdQuality, fdhkQuality, _ := fds.GetOK("quality")
+	if err := o.bindQuality(fdQuality, fdhkQuality, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdResize, fdhkResize, _ := fds.GetOK("resize")
+	if err := o.bindResize(fdResize, fdhkResize, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdRotate, fdhkRotate, _ := fds.GetOK("rotate")
+	if err := o.bindRotate(fdRotate, fdhkRotate, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdSaturation, fdhkSaturation, _ := fds.GetOK("saturation")
+	if err := o.bindSaturation(fdSaturation, fdhkSaturation, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdSepia, fdhkSepia, _ := fds.GetOK("sepia")
+	if err := o.bindSepia(fdSepia, fdhkSepia, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdSharpen, fdhkSharpen, _ := fds.GetOK("sharpen")
+	if err := o.bindSharpen(fdSharpen, fdhkSharpen, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdSizes, fdhkSizes, _ := fds.GetOK("sizes")
+	if err := o.bindSizes(fdSizes, fdhkSizes, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdToken, fdhkToken, _ := fds.GetOK("token")
+	if err := o.bindToken(fdToken, fdhkToken, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdWatermark, fdhkWatermark, _ := fds.GetOK("watermark")
+	if err := o.bindWatermark(fdWatermark, fdhkWatermark, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdWatermarkColor, fdhkWatermarkColor, _ := fds.GetOK("watermark_color")
+	if err := o.bindWatermarkColor(fdWatermarkColor, fdhkWatermarkColor, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdWatermarkGravity, fdhkWatermarkGravity, _ := fds.GetOK("watermark_gravity")
+	if err := o.bindWatermarkGravity(fdWatermarkGravity, fdhkWatermarkGravity, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdWatermarkMargin, fdhkWatermarkMargin, _ := fds.GetOK("watermark_margin")
+	if err := o.bindWatermarkMargin(fdWatermarkMargin, fdhkWatermarkMargin, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdWatermarkOpacity, fdhkWatermarkOpacity, _ := fds.GetOK("watermark_opacity")
+	if err := o.bindWatermarkOpacity(fdWatermarkOpacity, fdhkWatermarkOpacity, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdWatermarkScale, fdhkWatermarkScale, _ := fds.GetOK("watermark_scale")
+	if err := o.bindWatermarkScale(fdWatermarkScale, fdhkWatermarkScale, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdWatermarkText, fdhkWatermarkText, _ := fds.GetOK("watermark_text")
+	if err := o.bindWatermarkText(fdWatermarkText, fdhkWatermarkText, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	fdWatermarkTile, fdhkWatermarkTile, _ := fds.GetOK("watermark_tile")
+	if err := o.bindWatermarkTile(fdWatermarkTile, fdhkWatermarkTile, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// bindBackground binds and validates parameter Background from formData.
+func (o *V2resizeParams) bindBackground(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Background = &raw
+
+	return nil
+}
+
+// bindBlur binds and validates parameter Blur from formData.
+func (o *V2resizeParams) bindBlur(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertFloat64(raw)
+	if err != nil {
+		return errors.InvalidType("blur", "formData", "float64", raw)
+	}
+	o.Blur = &value
+
+	if err := o.validateBlur(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateBlur carries on validations for parameter Blur
+func (o *V2resizeParams) validateBlur(formats strfmt.Registry) error {
+
+	if err := validate.Minimum("blur", "formData", float64(*o.Blur), 0, false); err != nil {
+		return err
+	}
+
+	if err := validate.Maximum("blur", "formData", float64(*o.Blur), 50, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindBrightness binds and validates parameter Brightness from formData.
+func (o *V2resizeParams) bindBrightness(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertFloat64(raw)
+	if err != nil {
+		return errors.InvalidType("brightness", "formData", "float64", raw)
+	}
+	o.Brightness = &value
+
+	if err := o.validateBrightness(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateBrightness carries on validations for parameter Brightness
+func (o *V2resizeParams) validateBrightness(formats strfmt.Registry) error {
+
+	if err := validate.Minimum("brightness", "formData", float64(*o.Brightness), -100, false); err != nil {
+		return err
+	}
+
+	if err := validate.Maximum("brightness", "formData", float64(*o.Brightness), 100, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindCallbackURL binds and validates parameter CallbackURL from formData.
+func (o *V2resizeParams) bindCallbackURL(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.CallbackURL = &raw
+
+	return nil
+}
+
+// bindColors binds and validates parameter Colors from formData.
+func (o *V2resizeParams) bindColors(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("colors", "formData", "int64", raw)
+	}
+	o.Colors = &value
+
+	if err := o.validateColors(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateColors carries on validations for parameter Colors
+func (o *V2resizeParams) validateColors(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("colors", "formData", int64(*o.Colors), 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("colors", "formData", int64(*o.Colors), 256, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindCompression binds and validates parameter Compression from formData.
+func (o *V2resizeParams) bindCompression(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewV2resizeParams()
+		return nil
+	}
+
+	o.Compression = &raw
+
+	if err := o.validateCompression(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateCompression carries on validations for parameter Compression
+func (o *V2resizeParams) validateCompression(formats strfmt.Registry) error {
+
+	if err := validate.Enum("compression", "formData", *o.Compression, []interface{}{"default", "none", "speed", "best"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindContrast binds and validates parameter Contrast from formData.
+func (o *V2resizeParams) bindContrast(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertFloat64(raw)
+	if err != nil {
+		return errors.InvalidType("contrast", "formData", "float64", raw)
+	}
+	o.Contrast = &value
+
+	if err := o.validateContrast(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateContrast carries on validations for parameter Contrast
+func (o *V2resizeParams) validateContrast(formats strfmt.Registry) error {
+
+	if err := validate.Minimum("contrast", "formData", float64(*o.Contrast), -100, false); err != nil {
+		return err
+	}
+
+	if err := validate.Maximum("contrast", "formData", float64(*o.Contrast), 100, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindCrop binds and validates parameter Crop from formData.
+func (o *V2resizeParams) bindCrop(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Crop = &raw
+
+	return nil
+}
+
+// bindDither binds and validates parameter Dither from formData.
+func (o *V2resizeParams) bindDither(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewV2resizeParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("dither", "formData", "bool", raw)
+	}
+	o.Dither = &value
+
+	return nil
+}
+
+// bindFile binds and validates parameter File from formData.
+func (o *V2resizeParams) bindFile(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	if !hasKey {
+		return errors.Required("file", "formData")
+	}
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+
+	if err := validate.RequiredString("file", "formData", raw); err != nil {
+		return err
+	}
+
+	o.File = raw
+
+	return nil
+}
+
+// bindFilter binds and validates parameter Filter from formData.
+func (o *V2resizeParams) bindFilter(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Filter = &raw
+
+	if err := o.validateFilter(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateFilter carries on validations for parameter Filter
+func (o *V2resizeParams) validateFilter(formats strfmt.Registry) error {
+
+	if err := validate.Enum("filter", "formData", *o.Filter, []interface{}{"nearest", "bilinear", "bicubic", "mitchell", "lanczos2", "lanczos3"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindFirstFrame binds and validates parameter FirstFrame from formData.
+func (o *V2resizeParams) bindFirstFrame(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewV2resizeParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("first_frame", "formData", "bool", raw)
+	}
+	o.FirstFrame = &value
+
+	return nil
+}
+
+// bindFlip binds and validates parameter Flip from formData.
+func (o *V2resizeParams) bindFlip(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Flip = &raw
+
+	if err := o.validateFlip(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateFlip carries on validations for parameter Flip
+func (o *V2resizeParams) validateFlip(formats strfmt.Registry) error {
+
+	if err := validate.Enum("flip", "formData", *o.Flip, []interface{}{"horizontal", "vertical", "both"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindFormat binds and validates parameter Format from formData.
+func (o *V2resizeParams) bindFormat(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Format = &raw
+
+	if err := o.validateFormat(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateFormat carries on validations for parameter Format
+func (o *V2resizeParams) validateFormat(formats strfmt.Registry) error {
+
+	if err := validate.Enum("format", "formData", *o.Format, []interface{}{"jpeg", "png", "gif", "bmp", "tiff"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindGamma binds and validates parameter Gamma from formData.
+func (o *V2resizeParams) bindGamma(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertFloat64(raw)
+	if err != nil {
+		return errors.InvalidType("gamma", "formData", "float64", raw)
+	}
+	o.Gamma = &value
+
+	if err := o.validateGamma(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateGamma carries on validations for parameter Gamma
+func (o *V2resizeParams) validateGamma(formats strfmt.Registry) error {
+
+	if err := validate.Minimum("gamma", "formData", float64(*o.Gamma), 0.1, false); err != nil {
+		return err
+	}
+
+	if err := validate.Maximum("gamma", "formData", float64(*o.Gamma), 10, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindGravity binds and validates parameter Gravity from formData.
+func (o *V2resizeParams) bindGravity(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewV2resizeParams()
+		return nil
+	}
+
+	o.Gravity = &raw
+
+	if err := o.validateGravity(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateGravity carries on validations for parameter Gravity
+func (o *V2resizeParams) validateGravity(formats strfmt.Registry) error {
+
+	if err := validate.Enum("gravity", "formData", *o.Gravity, []interface{}{"center", "north", "south", "east", "west", "northeast", "northwest", "southeast", "southwest"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindGrayscale binds and validates parameter Grayscale from formData.
+func (o *V2resizeParams) bindGrayscale(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewV2resizeParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("grayscale", "formData", "bool", raw)
+	}
+	o.Grayscale = &value
+
+	return nil
+}
+
+// bindHeight binds and validates parameter Height from formData.
+func (o *V2resizeParams) bindHeight(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("height", "formData", "int64", raw)
+	}
+	o.Height = &value
+
+	if err := o.validateHeight(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateHeight carries on validations for parameter Height
+func (o *V2resizeParams) validateHeight(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("height", "formData", int64(*o.Height), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindMaxAttempts binds and validates parameter MaxAttempts from formData.
+func (o *V2resizeParams) bindMaxAttempts(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("max_attempts", "formData", "int64", raw)
+	}
+	o.MaxAttempts = &value
+
+	if err := o.validateMaxAttempts(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateMaxAttempts carries on validations for parameter MaxAttempts
+func (o *V2resizeParams) validateMaxAttempts(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("max_attempts", "formData", int64(*o.MaxAttempts), 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("max_attempts", "formData", int64(*o.MaxAttempts), 20, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindMode binds and validates parameter Mode from formData.
+func (o *V2resizeParams) bindMode(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewV2resizeParams()
+		return nil
+	}
+
+	o.Mode = &raw
+
+	if err := o.validateMode(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateMode carries on validations for parameter Mode
+func (o *V2resizeParams) validateMode(formats strfmt.Registry) error {
+
+	if err := validate.Enum("mode", "formData", *o.Mode, []interface{}{"fit", "fill", "stretch", "pad"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindPipeline binds and validates parameter Pipeline from formData.
+func (o *V2resizeParams) bindPipeline(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Pipeline = &raw
+
+	return nil
+}
+
+// bindPreset binds and validates parameter Preset from formData.
+func (o *V2resizeParams) bindPreset(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Preset = &raw
+
+	return nil
+}
+
+// bindQuality binds and validates parameter Quality from formData.
+func (o *V2resizeParams) bindQuality(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("quality", "formData", "int64", raw)
+	}
+	o.Quality = &value
+
+	if err := o.validateQuality(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateQuality carries on validations for parameter Quality
+func (o *V2resizeParams) validateQuality(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("quality", "formData", int64(*o.Quality), 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("quality", "formData", int64(*o.Quality), 100, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindResize binds and validates parameter Resize from formData.
+func (o *V2resizeParams) bindResize(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("resize", "formData", "int64", raw)
+	}
+	o.Resize = &value
+
+	return nil
+}
+
+// bindRotate binds and validates parameter Rotate from formData.
+func (o *V2resizeParams) bindRotate(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertFloat64(raw)
+	if err != nil {
+		return errors.InvalidType("rotate", "formData", "float64", raw)
+	}
+	o.Rotate = &value
+
+	return nil
+}
+
+// bindSaturation binds and validates parameter Saturation from formData.
+func (o *V2resizeParams) bindSaturation(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertFloat64(raw)
+	if err != nil {
+		return errors.InvalidType("saturation", "formData", "float64", raw)
+	}
+	o.Saturation = &value
+
+	if err := o.validateSaturation(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateSaturation carries on validations for parameter Saturation
+func (o *V2resizeParams) validateSaturation(formats strfmt.Registry) error {
+
+	if err := validate.Minimum("saturation", "formData", float64(*o.Saturation), -100, false); err != nil {
+		return err
+	}
+
+	if err := validate.Maximum("saturation", "formData", float64(*o.Saturation), 100, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindSepia binds and validates parameter Sepia from formData.
+func (o *V2resizeParams) bindSepia(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewV2resizeParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("sepia", "formData", "bool", raw)
+	}
+	o.Sepia = &value
+
+	return nil
+}
+
+// bindSharpen binds and validates parameter Sharpen from formData.
+func (o *V2resizeParams) bindSharpen(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Sharpen = &raw
+
+	return nil
+}
+
+// bindSizes binds and validates array parameter Sizes from formData.
+//
+// Arrays are parsed according to CollectionFormat: "csv" (defaults to "csv" when empty).
+func (o *V2resizeParams) bindSizes(rawData []string, hasKey bool, formats strfmt.Registry) error {
+
+	var qvSizes string
+	if len(rawData) > 0 {
+		qvSizes = rawData[len(rawData)-1]
+	}
+
+	// CollectionFormat: csv
+	sizesIC := swag.SplitByFormat(qvSizes, "csv")
+	if len(sizesIC) == 0 {
+		return nil
+	}
+
+	var sizesIR []int64
+	for i, sizesIV := range sizesIC {
+		sizesI, err := swag.ConvertInt64(sizesIV)
+		if err != nil {
+			return errors.InvalidType(fmt.Sprintf("%s.%v", "sizes", i), "formData", "int64", sizesI)
+		}
+
+		if err := validate.MinimumInt(fmt.Sprintf("%s.%v", "sizes", i), "formData", int64(sizesI), 1, false); err != nil {
+			return err
+		}
+
+		sizesIR = append(sizesIR, sizesI)
+	}
+
+	o.Sizes = sizesIR
+	if err := o.validateSizes(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateSizes carries on validations for parameter Sizes
+func (o *V2resizeParams) validateSizes(formats strfmt.Registry) error {
+
+	sizesSize := int64(len(o.Sizes))
+
+	// maxItems: 20
+	if err := validate.MaxItems("sizes", "formData", sizesSize, 20); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindToken binds and validates parameter Token from formData.
+func (o *V2resizeParams) bindToken(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	if !hasKey {
+		return errors.Required("token", "formData")
+	}
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+
+	if err := validate.RequiredString("token", "formData", raw); err != nil {
+		return err
+	}
+
+	o.Token = raw
+
+	return nil
+}
+
+// bindWatermark binds and validates parameter Watermark from formData.
+func (o *V2resizeParams) bindWatermark(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Watermark = &raw
+
+	return nil
+}
+
+// bindWatermarkColor binds and validates parameter WatermarkColor from formData.
+func (o *V2resizeParams) bindWatermarkColor(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewV2resizeParams()
+		return nil
+	}
+
+	o.WatermarkColor = &raw
+
+	return nil
+}
+
+// bindWatermarkGravity binds and validates parameter WatermarkGravity from formData.
+func (o *V2resizeParams) bindWatermarkGravity(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewV2resizeParams()
+		return nil
+	}
+
+	o.WatermarkGravity = &raw
+
+	if err := o.validateWatermarkGravity(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateWatermarkGravity carries on validations for parameter WatermarkGravity
+func (o *V2resizeParams) validateWatermarkGravity(formats strfmt.Registry) error {
+
+	if err := validate.Enum("watermark_gravity", "formData", *o.WatermarkGravity, []interface{}{"center", "north", "south", "east", "west", "northeast", "northwest", "southeast", "southwest"}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindWatermarkMargin binds and validates parameter WatermarkMargin from formData.
+func (o *V2resizeParams) bindWatermarkMargin(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("watermark_margin", "formData", "int64", raw)
+	}
+	o.WatermarkMargin = &value
+
+	if err := o.validateWatermarkMargin(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateWatermarkMargin carries on validations for parameter WatermarkMargin
+func (o *V2resizeParams) validateWatermarkMargin(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("watermark_margin", "formData", int64(*o.WatermarkMargin), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindWatermarkOpacity binds and validates parameter WatermarkOpacity from formData.
+func (o *V2resizeParams) bindWatermarkOpacity(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewV2resizeParams()
+		return nil
+	}
+
+	value, err := swag.ConvertFloat64(raw)
+	if err != nil {
+		return errors.InvalidType("watermark_opacity", "formData", "float64", raw)
+	}
+	o.WatermarkOpacity = &value
+
+	if err := o.validateWatermarkOpacity(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateWatermarkOpacity carries on validations for parameter WatermarkOpacity
+func (o *V2resizeParams) validateWatermarkOpacity(formats strfmt.Registry) error {
+
+	if err := validate.Minimum("watermark_opacity", "formData", float64(*o.WatermarkOpacity), 0, true); err != nil {
+		return err
+	}
+
+	if err := validate.Maximum("watermark_opacity", "formData", float64(*o.WatermarkOpacity), 1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindWatermarkScale binds and validates parameter WatermarkScale from formData.
+func (o *V2resizeParams) bindWatermarkScale(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewV2resizeParams()
+		return nil
+	}
+
+	value, err := swag.ConvertFloat64(raw)
+	if err != nil {
+		return errors.InvalidType("watermark_scale", "formData", "float64", raw)
+	}
+	o.WatermarkScale = &value
+
+	if err := o.validateWatermarkScale(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateWatermarkScale carries on validations for parameter WatermarkScale
+func (o *V2resizeParams) validateWatermarkScale(formats strfmt.Registry) error {
+
+	if err := validate.Minimum("watermark_scale", "formData", float64(*o.WatermarkScale), 0, true); err != nil {
+		return err
+	}
+
+	if err := validate.Maximum("watermark_scale", "formData", float64(*o.WatermarkScale), 1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindWatermarkText binds and validates parameter WatermarkText from formData.
+func (o *V2resizeParams) bindWatermarkText(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.WatermarkText = &raw
+
+	if err := o.validateWatermarkText(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateWatermarkText carries on validations for parameter WatermarkText
+func (o *V2resizeParams) validateWatermarkText(formats strfmt.Registry) error {
+
+	if err := validate.MaxLength("watermark_text", "formData", (*o.WatermarkText), 200); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindWatermarkTile binds and validates parameter WatermarkTile from formData.
+func (o *V2resizeParams) bindWatermarkTile(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewV2resizeParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("watermark_tile", "formData", "bool", raw)
+	}
+	o.WatermarkTile = &value
+
+	return nil
+}
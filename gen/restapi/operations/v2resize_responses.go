@@ -0,0 +1,215 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
+
+	"github.com/xan-mortum/apimediaservice/gen/models"
+)
+
+// V2resizeOKCode is the HTTP code returned for type V2resizeOK
+const V2resizeOKCode int = 200
+
+/*
+V2resizeOK resize result. execution id
+
+swagger:response v2resizeOK
+*/
+type V2resizeOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload string `json:"body,omitempty"`
+}
+
+// NewV2resizeOK creates V2resizeOK with default headers values
+func NewV2resizeOK() *V2resizeOK {
+
+	return &V2resizeOK{}
+}
+
+// WithPayload adds the payload to the v2resize o k response
+func (o *V2resizeOK) WithPayload(payload string) *V2resizeOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the v2resize o k response
+func (o *V2resizeOK) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *V2resizeOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// V2resizeBadRequestCode is the HTTP code returned for type V2resizeBadRequest
+const V2resizeBadRequestCode int = 400
+
+/*
+V2resizeBadRequest Bad Request
+
+swagger:response v2resizeBadRequest
+*/
+type V2resizeBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewV2resizeBadRequest creates V2resizeBadRequest with default headers values
+func NewV2resizeBadRequest() *V2resizeBadRequest {
+
+	return &V2resizeBadRequest{}
+}
+
+// WithPayload adds the payload to the v2resize bad request response
+func (o *V2resizeBadRequest) WithPayload(payload *models.Error) *V2resizeBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the v2resize bad request response
+func (o *V2resizeBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *V2resizeBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// V2resizeInternalServerErrorCode is the HTTP code returned for type V2resizeInternalServerError
+const V2resizeInternalServerErrorCode int = 500
+
+/*
+V2resizeInternalServerError Fatal
+
+swagger:response v2resizeInternalServerError
+*/
+type V2resizeInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewV2resizeInternalServerError creates V2resizeInternalServerError with default headers values
+func NewV2resizeInternalServerError() *V2resizeInternalServerError {
+
+	return &V2resizeInternalServerError{}
+}
+
+// WithPayload adds the payload to the v2resize internal server error response
+func (o *V2resizeInternalServerError) WithPayload(payload *models.Error) *V2resizeInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the v2resize internal server error response
+func (o *V2resizeInternalServerError) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *V2resizeInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// V2resizeServiceUnavailableCode is the HTTP code returned for type V2resizeServiceUnavailable
+const V2resizeServiceUnavailableCode int = 503
+
+/*
+V2resizeServiceUnavailable Task queue is full, the task is not created. Retry after Retry-After seconds
+
+swagger:response v2resizeServiceUnavailable
+*/
+type V2resizeServiceUnavailable struct {
+	/*Seconds to wait before retrying
+
+	 */
+	RetryAfter int64 `json:"Retry-After"`
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewV2resizeServiceUnavailable creates V2resizeServiceUnavailable with default headers values
+func NewV2resizeServiceUnavailable() *V2resizeServiceUnavailable {
+
+	return &V2resizeServiceUnavailable{}
+}
+
+// WithRetryAfter adds the retryAfter to the v2resize service unavailable response
+func (o *V2resizeServiceUnavailable) WithRetryAfter(retryAfter int64) *V2resizeServiceUnavailable {
+	o.RetryAfter = retryAfter
+	return o
+}
+
+// SetRetryAfter sets the retryAfter to the v2resize service unavailable response
+func (o *V2resizeServiceUnavailable) SetRetryAfter(retryAfter int64) {
+	o.RetryAfter = retryAfter
+}
+
+// WithPayload adds the payload to the v2resize service unavailable response
+func (o *V2resizeServiceUnavailable) WithPayload(payload *models.Error) *V2resizeServiceUnavailable {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the v2resize service unavailable response
+func (o *V2resizeServiceUnavailable) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *V2resizeServiceUnavailable) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	// response header Retry-After
+
+	retryAfter := swag.FormatInt64(o.RetryAfter)
+	if retryAfter != "" {
+		rw.Header().Set("Retry-After", retryAfter)
+	}
+
+	rw.WriteHeader(503)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
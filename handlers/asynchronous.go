@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/google/uuid"
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
 	"github.com/xan-mortum/apimediaservice/gen/models"
 	"github.com/xan-mortum/apimediaservice/gen/restapi/operations"
 	"github.com/xan-mortum/apimediaservice/interfaces"
@@ -10,38 +12,141 @@ import (
 	"github.com/xan-mortum/apimediaservice/repositories"
 )
 
+//через сколько секунд клиенту стоит повторить запрос если очередь задач заполнена
+const queueFullRetryAfter = 5
+
 type AsynchronousHandler struct {
 	Logger              interfaces.Logger
 	ImageProcessor      *processors.ImageProcessor
-	UserImageRepository *repositories.UserImageRepository
-	ResizeRepository    *repositories.ResizeRepository
+	UserImageRepository repositories.UserImageRepository
+	ResizeRepository    repositories.ResizeRepository
+	ImageRepository     repositories.ImageRepository
+	PresetRepository    repositories.PresetRepository
 }
 
 func NewAsynchronousHandler(
 	logger interfaces.Logger,
 	ip *processors.ImageProcessor,
-	userImageRepository *repositories.UserImageRepository,
-	resizeRepository *repositories.ResizeRepository,
+	userImageRepository repositories.UserImageRepository,
+	resizeRepository repositories.ResizeRepository,
+	imageRepository repositories.ImageRepository,
+	presetRepository repositories.PresetRepository,
 ) *AsynchronousHandler {
 	return &AsynchronousHandler{
-		Logger: logger,
+		Logger:              logger,
 		ImageProcessor:      ip,
 		UserImageRepository: userImageRepository,
 		ResizeRepository:    resizeRepository,
+		ImageRepository:     imageRepository,
+		PresetRepository:    presetRepository,
 	}
 }
 
 func (handler *AsynchronousHandler) V2resizeHandler(params operations.V2resizeParams) middleware.Responder {
-	inputResize := params.Resize
-
 	id := uuid.New().String()
 	task := processors.ResizeTask{
-		UUID:   id,
-		Image:  params.File,
-		Resize: uint(inputResize),
+		UUID:  id,
+		Token: params.Token,
+		Image: params.File,
+	}
+	//можно передать один размер в resize или сразу несколько в sizes
+	if params.Resize != nil {
+		task.Resize = uint(*params.Resize)
+	}
+	for _, size := range params.Sizes {
+		task.Sizes = append(task.Sizes, uint(size))
+	}
+	if task.Resize != 0 && len(task.Sizes) != 0 {
+		task.Sizes = append([]uint{task.Resize}, task.Sizes...)
+	}
+	//высота и режим общие для всех ширин. если задана только высота, ширина считаеться пропорционально
+	task.Options = newResizeOptions(0, params.Height, params.Mode, params.Gravity, params.Background, params.Format, params.Filter)
+	task.Options = withEncoderOptions(task.Options, params.Quality, params.Compression, params.Colors, params.Dither, params.FirstFrame)
+	//обрезка, поворот и отражение делаються до ресайза
+	var err error
+	task.Options, err = withTransformOptions(task.Options, params.Crop, params.Rotate, params.Flip)
+	if err != nil {
+		return operations.NewV2resizeBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	//цветокоррекция и резкость после ресайза
+	task.Options, err = withAdjustOptions(task.Options, params.Brightness, params.Contrast, params.Gamma, params.Saturation,
+		params.Grayscale, params.Sepia, params.Blur, params.Sharpen)
+	if err != nil {
+		return operations.NewV2resizeBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	task.Options, err = withWatermarkOptions(task.Options, params.Watermark, params.WatermarkText, params.WatermarkColor,
+		params.WatermarkGravity, params.WatermarkScale, params.WatermarkOpacity, params.WatermarkMargin, params.WatermarkTile)
+	if err != nil {
+		return operations.NewV2resizeBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	//пресет подставляет все параметры ресайза сразу, поэтому передавать их вместе с ним нельзя
+	if params.Preset != nil {
+		if params.Pipeline != nil || task.Resize != 0 || len(task.Sizes) != 0 || task.Options != (imagemanager.ResizeOptions{}) {
+			return operations.NewV2resizeBadRequest().WithPayload(&models.Error{Detail: "preset cannot be combined with other resize parameters"})
+		}
+		preset, err := handler.PresetRepository.Get(*params.Preset)
+		if err != nil {
+			return operations.NewV2resizeInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+		}
+		if preset == nil {
+			return operations.NewV2resizeBadRequest().WithPayload(&models.Error{Detail: "preset " + *params.Preset + " not found"})
+		}
+		task.Resize = preset.Options.Width
+		task.Options = preset.Options
+		task.Options.Width = 0
+		task.Preset = preset.Name
+	}
+	//конвеер заменяет все остальные параметры, смешивать их нельзя
+	if params.Pipeline != nil {
+		if task.Resize != 0 || len(task.Sizes) != 0 || task.Options != (imagemanager.ResizeOptions{}) {
+			return operations.NewV2resizeBadRequest().WithPayload(&models.Error{Detail: "pipeline cannot be combined with other resize parameters"})
+		}
+		pipeline, err := parsePipeline(*params.Pipeline)
+		if err != nil {
+			return operations.NewV2resizeBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+		}
+		task.Pipeline = pipeline
+	} else {
+		if task.Resize == 0 && len(task.Sizes) == 0 && task.Options.Height == 0 &&
+			task.Options.Crop == nil && task.Options.Rotate == 0 && task.Options.Flip == "" && !task.Options.HasAdjust() &&
+			task.Options.Watermark == nil {
+			return operations.NewV2resizeBadRequest().WithPayload(&models.Error{Detail: "resize, sizes, height, crop, rotate, flip, an adjustment, a watermark, pipeline or preset is required"})
+		}
+		for _, options := range task.ResizeOptions() {
+			err := options.Validate()
+			if err != nil {
+				return operations.NewV2resizeBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+			}
+		}
+	}
+	//картинки водяных знаков должны быть загружены заранее, иначе задача упадет только в воркере
+	for _, watermark := range task.WatermarkImages() {
+		image, err := handler.ImageRepository.Get(watermark)
+		if err != nil {
+			return operations.NewV2resizeInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+		}
+		if image.Uuid == "" {
+			return operations.NewV2resizeBadRequest().WithPayload(&models.Error{Detail: "watermark image " + watermark + " not found"})
+		}
+	}
+	//остальные параметры повторов берутся из настроек процессора
+	if params.MaxAttempts != nil {
+		task.Retry.MaxAttempts = int(*params.MaxAttempts)
+	}
+	if params.CallbackURL != nil && *params.CallbackURL != "" {
+		err := handler.ImageProcessor.ValidateCallbackURL(*params.CallbackURL)
+		if err != nil {
+			return operations.NewV2resizeBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+		}
+		task.CallbackURL = *params.CallbackURL
 	}
 
-	err := handler.ImageProcessor.AddTask(task)
+	err = handler.ImageProcessor.AddTask(task)
+	if errors.Is(err, processors.ErrQueueFull) {
+		return operations.NewV2resizeServiceUnavailable().
+			WithRetryAfter(queueFullRetryAfter).
+			WithPayload(&models.Error{Detail: err.Error()})
+	}
 	if err != nil {
 		return operations.NewV2resizeInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
@@ -49,20 +154,30 @@ func (handler *AsynchronousHandler) V2resizeHandler(params operations.V2resizePa
 }
 
 func (handler *AsynchronousHandler) ResultHandler(params operations.ResultParams) middleware.Responder {
-	taskId := params.Execution
-
-	task, err := handler.ImageProcessor.GetTask(taskId)
-	if task == nil {
-		return operations.NewResultBadRequest().WithPayload(&models.Error{Detail: "execution " + taskId + " not found"})
+	task, err := handler.ImageProcessor.GetTask(params.Execution, params.Token)
+	if err == processors.ErrTaskNotFound {
+		return operations.NewResultBadRequest().WithPayload(&models.Error{Detail: err.Error()})
 	}
 	if err != nil {
 		return operations.NewResultInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
 
-	return operations.NewResultOK().WithPayload(&models.Resize{
+	//по статусу и ошибке клиент отличает задачу которая ждет повтора, мертвую или отмененную от выполняемой
+	result := &models.Resize{
+		Status:   task.Status,
+		Error:    task.Error,
+		Attempts: int64(task.Attempts),
 		Original: task.FilePath,
 		Resized:  task.ResizedFilePath,
-	})
+	}
+	for _, resized := range task.Resized {
+		result.Sizes = append(result.Sizes, &models.ResizeSize{
+			Resize:  resized.ResizeParam,
+			Height:  resized.Height,
+			Resized: resized.ResizedFilePath,
+		})
+	}
+	return operations.NewResultOK().WithPayload(result)
 }
 
 func (handler *AsynchronousHandler) V2filesHandler(params operations.V2filesParams) middleware.Responder {
@@ -88,3 +203,88 @@ func (handler *AsynchronousHandler) V2filesHandler(params operations.V2filesPara
 
 	return operations.NewV2filesOK().WithPayload(result)
 }
+
+//задачи которые не удалось выполнить после всех попыток
+func (handler *AsynchronousHandler) DeadHandler(params operations.DeadParams) middleware.Responder {
+	tasks, err := handler.ImageProcessor.GetDeadTasks(params.Token)
+	if err != nil {
+		return operations.NewDeadInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	return operations.NewDeadOK().WithPayload(tasks)
+}
+
+//возвращает мертвую задачу в очередь
+func (handler *AsynchronousHandler) RequeueHandler(params operations.RequeueParams) middleware.Responder {
+	err := handler.ImageProcessor.RequeueTask(params.Execution, params.Token)
+	if err == processors.ErrTaskNotFound || err == processors.ErrTaskNotDead {
+		return operations.NewRequeueBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	if err != nil {
+		return operations.NewRequeueInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	return operations.NewRequeueOK().WithPayload(params.Execution)
+}
+
+//отменяет задачу которая еще не выполнилась
+func (handler *AsynchronousHandler) CancelHandler(params operations.CancelParams) middleware.Responder {
+	err := handler.ImageProcessor.CancelTask(params.Execution, params.Token)
+	if err == processors.ErrTaskNotFound || err == processors.ErrTaskNotCancellable {
+		return operations.NewCancelBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	if err != nil {
+		return operations.NewCancelInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	return operations.NewCancelOK().WithPayload(params.Execution)
+}
+
+//журнал доставки вебхуков задачи
+func (handler *AsynchronousHandler) DeliveriesHandler(params operations.DeliveriesParams) middleware.Responder {
+	deliveries, err := handler.ImageProcessor.GetDeliveries(params.Execution, params.Token)
+	if err == processors.ErrTaskNotFound {
+		return operations.NewDeliveriesBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	if err != nil {
+		return operations.NewDeliveriesInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	return operations.NewDeliveriesOK().WithPayload(deliveries)
+}
+
+//события задач пользователя через Server-Sent Events
+func (handler *AsynchronousHandler) EventsHandler(params operations.EventsParams) middleware.Responder {
+	execution := ""
+	if params.Execution != nil {
+		execution = *params.Execution
+	}
+
+	events, unsubscribe, err := handler.ImageProcessor.SubscribeEvents(params.Token, execution)
+	if err == processors.ErrTaskNotFound {
+		return operations.NewEventsBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	if err != nil {
+		return operations.NewEventsInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+
+	return &eventStream{
+		ctx:         params.HTTPRequest.Context(),
+		logger:      handler.Logger,
+		events:      events,
+		unsubscribe: unsubscribe,
+		single:      execution != "",
+	}
+}
+
+//информация о загруженной картинке: размеры, формат, exif
+func (handler *AsynchronousHandler) ImageMetadataHandler(params operations.ImageMetadataParams) middleware.Responder {
+	image, err := handler.ImageRepository.Get(params.ID)
+	if err != nil {
+		return operations.NewImageMetadataInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	if image.Uuid == "" {
+		return operations.NewImageMetadataBadRequest().WithPayload(&models.Error{Detail: "image " + params.ID + " not found"})
+	}
+	//картинки загруженные до появления этого метода
+	if image.Info == nil {
+		return operations.NewImageMetadataBadRequest().WithPayload(&models.Error{Detail: "image " + params.ID + " has no metadata"})
+	}
+	return operations.NewImageMetadataOK().WithPayload(image.Info)
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/go-openapi/runtime"
+	"github.com/op/go-logging"
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"github.com/xan-mortum/apimediaservice/gen/restapi/operations"
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestImageMetadataHandler(t *testing.T) {
+	repos, err := repositories.NewRepositories(repositories.NewConfig(repositories.DriverMemory, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &imagemanager.ImageInfo{
+		Width:      20,
+		Height:     40,
+		Format:     imagemanager.FormatJPEG,
+		ColorModel: "ycbcr",
+		Frames:     1,
+		FileSize:   1024,
+		Exif:       &imagemanager.ExifInfo{CameraMake: "Camera", GPS: &imagemanager.GPSInfo{Latitude: 55.75, Longitude: 37.61}},
+	}
+	err = repos.ImageRepository.Put(repositories.Image{Uuid: "photo", FileName: "photo.jpg", Info: info})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//картинка загруженная до того как информация стала сохраняться
+	err = repos.ImageRepository.Put(repositories.Image{Uuid: "old", FileName: "old.jpg"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := NewAsynchronousHandler(logging.MustGetLogger("test"), nil, repos.UserImageRepository, repos.ResizeRepository, repos.ImageRepository, repos.PresetRepository)
+
+	tests := []struct {
+		id   string
+		code int
+		info *imagemanager.ImageInfo
+	}{
+		{"photo", http.StatusOK, info},
+		{"old", http.StatusBadRequest, nil},
+		{"missing", http.StatusBadRequest, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			responder := handler.ImageMetadataHandler(operations.ImageMetadataParams{
+				HTTPRequest: httptest.NewRequest(http.MethodGet, "/v2/images/"+test.id+"/metadata", nil),
+				ID:          test.id,
+			})
+			responder.WriteResponse(recorder, runtime.JSONProducer())
+			if recorder.Code != test.code {
+				t.Fatalf("status %d, want %d: %s", recorder.Code, test.code, recorder.Body)
+			}
+			if test.info == nil {
+				return
+			}
+			var result imagemanager.ImageInfo
+			err := json.Unmarshal(recorder.Body.Bytes(), &result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(&result, test.info) {
+				t.Fatalf("info %+v, want %+v", result, test.info)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"github.com/xan-mortum/apimediaservice/components/storage"
+	"github.com/xan-mortum/apimediaservice/components/urlsign"
+	"github.com/xan-mortum/apimediaservice/gen/models"
+	"github.com/xan-mortum/apimediaservice/gen/restapi/operations"
+	"github.com/xan-mortum/apimediaservice/interfaces"
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"io"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//отдает оригиналы и ресайзы из хранилища через сервис, так бакет может быть закрытым
+//файл отдаеться владельцу по токену или кому угодно по подписанной ссылке
+//кеширование по ETag и Last-Modified, куски файла по Range
+type DownloadHandler struct {
+	Logger              interfaces.Logger
+	Storage             storage.BlobStore
+	UserImageRepository repositories.UserImageRepository
+	ResizeRepository    repositories.ResizeRepository
+	Signing             urlsign.Config
+	//сколько клиенты и прокси могут не перепроверять файл. оригинал можно перезалить под тем же именем,
+	//поэтому слишком большим его делать не стоит, после истечения файл проверяеться по ETag
+	MaxAge time.Duration
+}
+
+func NewDownloadHandler(
+	logger interfaces.Logger,
+	storage storage.BlobStore,
+	userImageRepository repositories.UserImageRepository,
+	resizeRepository repositories.ResizeRepository,
+	signing urlsign.Config,
+	maxAge time.Duration,
+) *DownloadHandler {
+	return &DownloadHandler{
+		Logger:              logger,
+		Storage:             storage,
+		UserImageRepository: userImageRepository,
+		ResizeRepository:    resizeRepository,
+		Signing:             signing,
+		MaxAge:              maxAge,
+	}
+}
+
+func (handler *DownloadHandler) DownloadHandler(params operations.DownloadParams) middleware.Responder {
+	responder := handler.download(params)
+	if _, ok := responder.(*objectResponse); ok {
+		return responder
+	}
+	return jsonResponse{responder}
+}
+
+func (handler *DownloadHandler) download(params operations.DownloadParams) middleware.Responder {
+	notFound := operations.NewDownloadNotFound().WithPayload(&models.Error{Detail: "object " + params.Key + " not found"})
+	//служебные файлы хранилища не отдаються даже по подписи
+	if storage.IsInternalKey(params.Key) {
+		return notFound
+	}
+	//по подписи неизвестно чей это файл, поэтому ресайз узнаеться по имени
+	derivative := strings.HasPrefix(path.Base(params.Key), imagemanager.ThumbPrefix)
+	if params.Signature != nil && handler.Signing.Enabled() {
+		var expires int64
+		if params.Expires != nil {
+			expires = *params.Expires
+		}
+		err := urlsign.VerifyDownload(handler.Signing, params.Key, expires, *params.Signature, time.Now())
+		if err != nil {
+			return operations.NewDownloadForbidden().WithPayload(&models.Error{Detail: err.Error()})
+		}
+	} else {
+		if params.Token == nil {
+			return operations.NewDownloadForbidden().WithPayload(&models.Error{Detail: "token or signature is required"})
+		}
+		//чужой файл выглядит так же как несуществующий, так по ключам нельзя узнать что лежит в хранилище
+		var owned bool
+		var err error
+		owned, derivative, err = handler.owns(*params.Token, params.Key)
+		if err != nil {
+			return operations.NewDownloadInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+		}
+		if !owned {
+			return notFound
+		}
+	}
+
+	//ETag и Last-Modified берутся из Stat, сам файл читаеться только если его надо отдать
+	info, err := handler.Storage.Stat(params.Key)
+	if err == storage.ErrNotFound {
+		return notFound
+	}
+	if err != nil {
+		return operations.NewDownloadInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+
+	return &objectResponse{
+		request:     params.HTTPRequest,
+		contentType: imagemanager.ContentType(imagemanager.FormatByExtension(filepath.Ext(params.Key))),
+		info:        info,
+		derivative:  derivative,
+		maxAge:      handler.MaxAge,
+		reader: &objectReader{
+			storage: handler.Storage,
+			key:     params.Key,
+			size:    info.Size,
+			end:     rangeEnd(params.HTTPRequest.Header.Get("Range"), info.Size),
+		},
+	}
+}
+
+//ключ принадлежит пользователю если это его оригинал или ресайз его картинки. второе значение - ресайз ли это
+func (handler *DownloadHandler) owns(token string, key string) (bool, bool, error) {
+	images, err := handler.UserImageRepository.Get(token)
+	if err != nil {
+		return false, false, err
+	}
+	for _, image := range images {
+		if image.OriginalFileName == key {
+			return true, false, nil
+		}
+		//ресайзы v1 лежат под id картинки, а v2 и /img под именем файла, как их и ищут /v1/files и /v2/files
+		for _, id := range []string{image.Uuid, image.OriginalFileName} {
+			resizes, err := handler.ResizeRepository.Get(id)
+			if err != nil {
+				return false, false, err
+			}
+			for _, resize := range resizes {
+				if resize.ResizedFileName == key {
+					return true, true, nil
+				}
+			}
+		}
+	}
+	return false, false, nil
+}
+
+//файл из хранилища. условные запросы (304) и Range обрабатывает http.ServeContent
+type objectResponse struct {
+	request     *http.Request
+	contentType string
+	info        storage.ObjectInfo
+	//ресайз а не оригинал
+	derivative bool
+	maxAge     time.Duration
+	reader     *objectReader
+}
+
+func (r *objectResponse) WriteResponse(rw http.ResponseWriter, _ runtime.Producer) {
+	defer func() {
+		_ = r.reader.Close()
+	}()
+	rw.Header().Set("Content-Type", r.contentType)
+	if r.info.ETag != "" {
+		etag := `"` + r.info.ETag + `"`
+		if r.info.WeakETag {
+			etag = "W/" + etag
+		}
+		rw.Header().Set("ETag", etag)
+	}
+	//оригиналы могут содержать личные данные и отдаються только владельцу, общие кеши их хранить не должны
+	cacheControl := "private"
+	if r.derivative {
+		cacheControl = "public"
+	}
+	rw.Header().Set("Cache-Control", cacheControl+", max-age="+strconv.Itoa(int(r.maxAge/time.Second)))
+	http.ServeContent(rw, r.request, "", r.info.LastModified, r.reader)
+}
+
+//io.ReadSeeker поверх хранилища для http.ServeContent. Seek только запоминает позицию,
+//файл открываеться с этого места при первом чтении, так 304 не читает файл вовсе, а Range читает только свой кусок
+type objectReader struct {
+	storage storage.BlobStore
+	key     string
+	size    int64
+	//до куда читать первым запросом к хранилищу, если запрошен кусок файла
+	end    int64
+	offset int64
+	body   io.ReadCloser
+	//где кончаеться открытый кусок
+	bodyEnd int64
+}
+
+func (r *objectReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.body == nil {
+		length := r.size - r.offset
+		if r.offset < r.end {
+			length = r.end - r.offset
+		}
+		body, err := r.storage.GetRange(r.key, r.offset, length)
+		if err != nil {
+			return 0, err
+		}
+		r.body = body
+		r.bodyEnd = r.offset + length
+	}
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	//ServeContent может читать дальше Range, например если не совпал If-Range, тогда открываем остаток
+	if err == io.EOF && r.offset == r.bodyEnd && r.offset < r.size {
+		_ = r.Close()
+		err = nil
+	}
+	return n, err
+}
+
+func (r *objectReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	if offset != r.offset {
+		_ = r.Close()
+		r.offset = offset
+	}
+	return offset, nil
+}
+
+func (r *objectReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
+
+//конец первого куска из заголовка Range (не включительно). для остальных случаев весь файл,
+//заголовок целиком разбирает http.ServeContent, тут нужна только граница чтения
+func rangeEnd(header string, size int64) int64 {
+	if !strings.HasPrefix(header, "bytes=") || strings.Contains(header, ",") {
+		return size
+	}
+	bounds := strings.SplitN(strings.TrimSpace(strings.TrimPrefix(header, "bytes=")), "-", 2)
+	if len(bounds) != 2 || bounds[0] == "" || bounds[1] == "" {
+		return size
+	}
+	end, err := strconv.ParseInt(strings.TrimSpace(bounds[1]), 10, 64)
+	if err != nil || end < 0 || end >= size {
+		return size
+	}
+	return end + 1
+}
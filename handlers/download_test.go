@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"github.com/op/go-logging"
+	"github.com/xan-mortum/apimediaservice/components/storage"
+	"github.com/xan-mortum/apimediaservice/components/urlsign"
+	"github.com/xan-mortum/apimediaservice/gen/restapi/operations"
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testDownloadBody = "0123456789"
+
+//хранилище считает запросы к содержимому файлов
+type countingStore struct {
+	storage.BlobStore
+	reads int
+}
+
+func (s *countingStore) GetRange(key string, offset int64, length int64) (io.ReadCloser, error) {
+	s.reads++
+	return s.BlobStore.GetRange(key, offset, length)
+}
+
+//у пользователя owner оригинал orig.png с ресайзом thumb10.orig.png, other.png ничей
+func newTestDownloadHandler(t *testing.T) (*DownloadHandler, *countingStore) {
+	repos, err := repositories.NewRepositories(repositories.NewConfig(repositories.DriverMemory, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &countingStore{BlobStore: storage.NewMemoryStore()}
+	for _, key := range []string{"orig.png", "thumb10.orig.png", "other.png", ".etag/orig.png"} {
+		_, err = store.Put(key, strings.NewReader(testDownloadBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = repos.UserImageRepository.Put([]repositories.UserImage{
+		{Uuid: "img", OriginalFileName: "orig.png"},
+		//запись есть, а файла уже нет
+		{Uuid: "gone", OriginalFileName: "gone.png"},
+	}, "owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = repos.ResizeRepository.Put([]repositories.ImageResizeInfo{{ResizedFileName: "thumb10.orig.png", ResizeParam: 10}}, "img")
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := NewDownloadHandler(
+		logging.MustGetLogger("test"),
+		store,
+		repos.UserImageRepository,
+		repos.ResizeRepository,
+		urlsign.NewConfig("secret", "salt"),
+		time.Minute,
+	)
+	return handler, store
+}
+
+func serveDownload(handler *DownloadHandler, params operations.DownloadParams, header http.Header) *httptest.ResponseRecorder {
+	params.HTTPRequest = httptest.NewRequest(http.MethodGet, "/v2/download/"+params.Key, nil)
+	for name, values := range header {
+		params.HTTPRequest.Header[name] = values
+	}
+	recorder := httptest.NewRecorder()
+	handler.DownloadHandler(params).WriteResponse(recorder, nil)
+	return recorder
+}
+
+func TestDownloadAccess(t *testing.T) {
+	handler, _ := newTestDownloadHandler(t)
+	owner := "owner"
+	stranger := "stranger"
+	signing := urlsign.NewConfig("secret", "salt")
+	expires := time.Now().Add(time.Hour).Unix()
+	expired := time.Now().Add(-time.Hour).Unix()
+	signature := urlsign.SignDownload(signing, "other.png", expires)
+	expiredSignature := urlsign.SignDownload(signing, "other.png", expired)
+	thumbSignature := urlsign.SignDownload(signing, "thumb10.orig.png", 0)
+	internalSignature := urlsign.SignDownload(signing, ".etag/orig.png", 0)
+	//подпись ресайза /img не подходит к скачиванию
+	transformSignature := urlsign.Sign(signing, "", "other.png", 0)
+	tests := []struct {
+		name   string
+		params operations.DownloadParams
+		code   int
+		//пусто если файл не отдаеться
+		cacheControl string
+	}{
+		{"original", operations.DownloadParams{Key: "orig.png", Token: &owner}, http.StatusOK, "private, max-age=60"},
+		{"resize", operations.DownloadParams{Key: "thumb10.orig.png", Token: &owner}, http.StatusOK, "public, max-age=60"},
+		//чужой и несуществующий файл неотличимы
+		{"stranger", operations.DownloadParams{Key: "orig.png", Token: &stranger}, http.StatusNotFound, ""},
+		{"not owned", operations.DownloadParams{Key: "other.png", Token: &owner}, http.StatusNotFound, ""},
+		{"deleted file", operations.DownloadParams{Key: "gone.png", Token: &owner}, http.StatusNotFound, ""},
+		{"no token", operations.DownloadParams{Key: "orig.png"}, http.StatusForbidden, ""},
+		{"signed", operations.DownloadParams{Key: "other.png", Signature: &signature, Expires: &expires}, http.StatusOK, "private, max-age=60"},
+		//по подписи ресайз узнаеться по имени файла
+		{"signed resize", operations.DownloadParams{Key: "thumb10.orig.png", Signature: &thumbSignature}, http.StatusOK, "public, max-age=60"},
+		{"signature for other key", operations.DownloadParams{Key: "orig.png", Signature: &signature, Expires: &expires}, http.StatusForbidden, ""},
+		{"changed expires", operations.DownloadParams{Key: "other.png", Signature: &signature}, http.StatusForbidden, ""},
+		{"expired", operations.DownloadParams{Key: "other.png", Signature: &expiredSignature, Expires: &expired}, http.StatusForbidden, ""},
+		{"transform signature", operations.DownloadParams{Key: "other.png", Signature: &transformSignature}, http.StatusForbidden, ""},
+		//служебные файлы не отдаються никому
+		{"internal key", operations.DownloadParams{Key: ".etag/orig.png", Token: &owner}, http.StatusNotFound, ""},
+		{"signed internal key", operations.DownloadParams{Key: ".etag/orig.png", Signature: &internalSignature}, http.StatusNotFound, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := serveDownload(handler, test.params, nil)
+			if response.Code != test.code {
+				t.Fatalf("status %d, want %d: %s", response.Code, test.code, response.Body)
+			}
+			if test.code != http.StatusOK {
+				if response.Header().Get("Content-Type") != "application/json" {
+					t.Fatalf("error Content-Type %s", response.Header().Get("Content-Type"))
+				}
+				return
+			}
+			header := response.Header()
+			if response.Body.String() != testDownloadBody || header.Get("Content-Type") != "image/png" ||
+				header.Get("Content-Length") != strconv.Itoa(len(testDownloadBody)) || header.Get("Last-Modified") == "" {
+				t.Fatalf("response %v %q", header, response.Body)
+			}
+			if header.Get("Cache-Control") != test.cacheControl {
+				t.Fatalf("Cache-Control %s, want %s", header.Get("Cache-Control"), test.cacheControl)
+			}
+			//сильная метка в кавычках
+			etag := header.Get("ETag")
+			if len(etag) < 3 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+				t.Fatalf("ETag %s", etag)
+			}
+		})
+	}
+}
+
+func TestDownloadConditionalAndRange(t *testing.T) {
+	handler, store := newTestDownloadHandler(t)
+	owner := "owner"
+	params := operations.DownloadParams{Key: "orig.png", Token: &owner}
+	first := serveDownload(handler, params, nil)
+	etag := first.Header().Get("ETag")
+	lastModified := first.Header().Get("Last-Modified")
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+
+	tests := []struct {
+		name         string
+		header       http.Header
+		code         int
+		body         string
+		contentRange string
+		//сколько раз открывали файл в хранилище
+		reads int
+	}{
+		{"if-none-match", http.Header{"If-None-Match": {etag}}, http.StatusNotModified, "", "", 0},
+		{"if-none-match list", http.Header{"If-None-Match": {`"other", ` + etag}}, http.StatusNotModified, "", "", 0},
+		{"changed etag", http.Header{"If-None-Match": {`"other"`}}, http.StatusOK, testDownloadBody, "", 1},
+		{"if-modified-since", http.Header{"If-Modified-Since": {lastModified}}, http.StatusNotModified, "", "", 0},
+		//If-None-Match важнее If-Modified-Since
+		{"etag wins", http.Header{"If-None-Match": {`"other"`}, "If-Modified-Since": {future}}, http.StatusOK, testDownloadBody, "", 1},
+		{"range", http.Header{"Range": {"bytes=2-5"}}, http.StatusPartialContent, "2345", "bytes 2-5/10", 1},
+		{"open range", http.Header{"Range": {"bytes=8-"}}, http.StatusPartialContent, "89", "bytes 8-9/10", 1},
+		{"suffix range", http.Header{"Range": {"bytes=-3"}}, http.StatusPartialContent, "789", "bytes 7-9/10", 1},
+		{"range past the end", http.Header{"Range": {"bytes=5-100"}}, http.StatusPartialContent, "56789", "bytes 5-9/10", 1},
+		{"unsatisfiable range", http.Header{"Range": {"bytes=20-30"}}, http.StatusRequestedRangeNotSatisfiable, "", "bytes */10", 0},
+		{"if-range matches", http.Header{"Range": {"bytes=2-5"}, "If-Range": {etag}}, http.StatusPartialContent, "2345", "bytes 2-5/10", 1},
+		//файл поменялся, отдаеться целиком. сначала открываеться кусок из Range, потом остаток
+		{"if-range changed", http.Header{"Range": {"bytes=2-5"}, "If-Range": {`"other"`}}, http.StatusOK, testDownloadBody, "", 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store.reads = 0
+			response := serveDownload(handler, params, test.header)
+			if response.Code != test.code {
+				t.Fatalf("status %d, want %d", response.Code, test.code)
+			}
+			//у 416 в теле текст ошибки от http.ServeContent
+			body := response.Body.String()
+			if test.code == http.StatusRequestedRangeNotSatisfiable {
+				body = ""
+			}
+			if body != test.body || response.Header().Get("Content-Range") != test.contentRange {
+				t.Fatalf("body %q range %s, want %q %s", response.Body, response.Header().Get("Content-Range"), test.body, test.contentRange)
+			}
+			if store.reads != test.reads {
+				t.Fatalf("%d reads, want %d", store.reads, test.reads)
+			}
+			if response.Code != http.StatusRequestedRangeNotSatisfiable && response.Header().Get("ETag") != etag {
+				t.Fatalf("ETag %s, want %s", response.Header().Get("ETag"), etag)
+			}
+		})
+	}
+}
+
+func TestRangeEnd(t *testing.T) {
+	tests := []struct {
+		header string
+		end    int64
+	}{
+		{"", 10},
+		{"bytes=2-5", 6},
+		{"bytes= 2 - 5 ", 6},
+		{"bytes=8-", 10},
+		{"bytes=-3", 10},
+		{"bytes=5-100", 10},
+		//несколько кусков читаються до конца
+		{"bytes=0-1,4-5", 10},
+		{"items=2-5", 10},
+		{"bytes=2-x", 10},
+	}
+	for _, test := range tests {
+		t.Run(test.header, func(t *testing.T) {
+			end := rangeEnd(test.header, 10)
+			if end != test.end {
+				t.Fatalf("rangeEnd() = %d, want %d", end, test.end)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-openapi/runtime"
+	"github.com/xan-mortum/apimediaservice/interfaces"
+	"github.com/xan-mortum/apimediaservice/processors"
+	"io"
+	"net/http"
+	"time"
+)
+
+//как часто отправлять комментарий что бы прокси не закрывали молчащее соединение
+const eventStreamKeepAlive = 15 * time.Second
+
+//отдает события задач в формате Server-Sent Events
+//поток закрываеться когда клиент отключился или когда завершилась задача на которую подписались
+type eventStream struct {
+	ctx         context.Context
+	logger      interfaces.Logger
+	events      <-chan processors.TaskEvent
+	unsubscribe func()
+	//подписка на одну задачу заканчиваеться вместе с задачей
+	single bool
+}
+
+func (s *eventStream) WriteResponse(rw http.ResponseWriter, _ runtime.Producer) {
+	defer s.unsubscribe()
+
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		http.Error(rw, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.Header().Set("Connection", "keep-alive")
+	//nginx иначе копит ответ в буфере
+	rw.Header().Set("X-Accel-Buffering", "no")
+	rw.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(eventStreamKeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := io.WriteString(rw, ": ping\n\n")
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+		case event := <-s.events:
+			err := writeEvent(rw, event.Stage, event)
+			if err != nil {
+				s.logger.Warning(err)
+				return
+			}
+			flusher.Flush()
+			if s.single && event.Final() {
+				return
+			}
+		}
+	}
+}
+
+func writeEvent(w io.Writer, name string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, payload)
+	return err
+}
+
+//ответы с ошибками клиенту который ждет text/event-stream отдаются одним событием error
+func EventStreamProducer() runtime.Producer {
+	return runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
+		return writeEvent(w, processors.EventError, data)
+	})
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"github.com/op/go-logging"
+	"github.com/xan-mortum/apimediaservice/processors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventStream(t *testing.T) {
+	tests := []struct {
+		name   string
+		single bool
+		//сколько событий попадет в ответ до закрытия контекста
+		written int
+	}{
+		//подписка на одну задачу закрываеться на итоговом событии
+		{"single task", true, 2},
+		//подписка на все задачи токена продолжаеться после завершения одной из них
+		{"all tasks", false, 3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			events := make(chan processors.TaskEvent, 3)
+			events <- processors.TaskEvent{Execution: "task", Stage: processors.EventResizing}
+			events <- processors.TaskEvent{Execution: "task", Stage: processors.EventDone}
+			events <- processors.TaskEvent{Execution: "other", Stage: processors.EventQueued}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			unsubscribed := false
+			stream := &eventStream{
+				ctx:    ctx,
+				logger: logging.MustGetLogger("test"),
+				events: events,
+				unsubscribe: func() {
+					unsubscribed = true
+				},
+				single: test.single,
+			}
+			recorder := httptest.NewRecorder()
+			stream.WriteResponse(recorder, nil)
+
+			if recorder.Header().Get("Content-Type") != "text/event-stream" {
+				t.Fatalf("Content-Type %s", recorder.Header().Get("Content-Type"))
+			}
+			body := recorder.Body.String()
+			if strings.Count(body, "event: ") != test.written {
+				t.Fatalf("body %q, want %d events", body, test.written)
+			}
+			if !strings.HasPrefix(body, "event: resizing\ndata: {\"execution\":\"task\",\"stage\":\"resizing\"") {
+				t.Fatalf("body %q", body)
+			}
+			if !unsubscribed {
+				t.Fatal("stream is not unsubscribed")
+			}
+		})
+	}
+}
@@ -1,39 +1,39 @@
 package handlers
 
 import (
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"bytes"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/google/uuid"
 	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"github.com/xan-mortum/apimediaservice/components/storage"
 	"github.com/xan-mortum/apimediaservice/gen/models"
 	"github.com/xan-mortum/apimediaservice/gen/restapi/operations"
 	"github.com/xan-mortum/apimediaservice/interfaces"
 	"github.com/xan-mortum/apimediaservice/repositories"
+	"io/ioutil"
 	"path/filepath"
 )
 
 type MockHandler struct {
 	Logger              interfaces.Logger
 	ImageManager        imagemanager.ImageManager
-	S3Session           *session.Session
-	ImageRepository     *repositories.ImageRepository
-	UserImageRepository *repositories.UserImageRepository
+	Storage             storage.BlobStore
+	ImageRepository     repositories.ImageRepository
+	UserImageRepository repositories.UserImageRepository
 }
 
 func NewMockHandler(
 	logger interfaces.Logger,
 	im imagemanager.ImageManager,
-	s3 *session.Session,
-	userImageRepository *repositories.UserImageRepository,
-	imageRepository *repositories.ImageRepository,
+	blobStore storage.BlobStore,
+	userImageRepository repositories.UserImageRepository,
+	imageRepository repositories.ImageRepository,
 ) *MockHandler {
 	return &MockHandler{
-		Logger: logger,
+		Logger:              logger,
 		ImageManager:        im,
-		S3Session:           s3,
+		Storage:             blobStore,
 		UserImageRepository: userImageRepository,
 		ImageRepository:     imageRepository,
 	}
@@ -65,13 +65,27 @@ func (handler *MockHandler) UploadHandler(params operations.UploadParams) middle
 		return operations.NewUploadBadRequest().WithPayload(&models.Error{Detail: fileExt + " id not supported"})
 	}
 
-	//заливаем на S3
-	uploader := s3manager.NewUploader(handler.S3Session)
-	upload, err := uploader.Upload(&s3manager.UploadInput{
-		Bucket: aws.String(Bucket),
-		Key:    aws.String(fileName),
-		Body:   params.Upfile.(*runtime.File),
-	})
+	data, err := ioutil.ReadAll(params.Upfile)
+	if err != nil {
+		return operations.NewUploadInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+
+	//в оригинале могут быть gps координаты и серийник камеры, если так настроено удаляем их до загрузки
+	if handler.ImageManager.Config.SanitizeOriginals {
+		data, err = handler.ImageManager.SanitizeOriginal(data)
+		if err != nil {
+			return operations.NewUploadBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+		}
+	}
+
+	//информацию берем уже из того что будет лежать в хранилище
+	info, err := imagemanager.ExtractInfo(data)
+	if err != nil {
+		return operations.NewUploadBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+
+	//заливаем в хранилище
+	location, err := handler.Storage.Put(fileName, bytes.NewReader(data))
 	if err != nil {
 		return operations.NewUploadInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
@@ -80,7 +94,8 @@ func (handler *MockHandler) UploadHandler(params operations.UploadParams) middle
 	err = handler.ImageRepository.Put(repositories.Image{
 		Uuid:     fileName,
 		FileName: fileName,
-		FilePath: upload.Location,
+		FilePath: location,
+		Info:     info,
 	})
 	if err != nil {
 		return operations.NewUploadInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
@@ -89,7 +104,7 @@ func (handler *MockHandler) UploadHandler(params operations.UploadParams) middle
 	images := []repositories.UserImage{{
 		Uuid:             inputToken,
 		OriginalFileName: fileName,
-		OriginalFilePath: upload.Location,
+		OriginalFilePath: location,
 	}}
 
 	err = handler.UserImageRepository.Append(images, inputToken)
@@ -98,4 +113,4 @@ func (handler *MockHandler) UploadHandler(params operations.UploadParams) middle
 	}
 
 	return operations.NewUploadOK().WithPayload(fileName)
-}
\ No newline at end of file
+}
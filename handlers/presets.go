@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/xan-mortum/apimediaservice/gen/models"
+	"github.com/xan-mortum/apimediaservice/gen/restapi/operations"
+	"github.com/xan-mortum/apimediaservice/interfaces"
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"regexp"
+	"time"
+)
+
+//имя пресета попадает в запросы и записи о ресайзах, поэтому только простые символы
+var presetNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+//управление пресетами. пресеты общие для всех, по имени их можно передать в /v2/resize и /v1/resize_exists
+type PresetHandler struct {
+	Logger           interfaces.Logger
+	PresetRepository repositories.PresetRepository
+}
+
+func NewPresetHandler(
+	logger interfaces.Logger,
+	presetRepository repositories.PresetRepository,
+) *PresetHandler {
+	return &PresetHandler{
+		Logger:           logger,
+		PresetRepository: presetRepository,
+	}
+}
+
+func (handler *PresetHandler) PresetsHandler(params operations.PresetsParams) middleware.Responder {
+	presets, err := handler.PresetRepository.List()
+	if err != nil {
+		return operations.NewPresetsInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	return operations.NewPresetsOK().WithPayload(presets)
+}
+
+func (handler *PresetHandler) PresetHandler(params operations.PresetParams) middleware.Responder {
+	preset, err := handler.PresetRepository.Get(params.Name)
+	if err != nil {
+		return operations.NewPresetInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	if preset == nil {
+		return operations.NewPresetBadRequest().WithPayload(&models.Error{Detail: "preset " + params.Name + " not found"})
+	}
+	return operations.NewPresetOK().WithPayload(preset)
+}
+
+//создает пресет или заменяет существующий. параметры такие же как у /v2/resize
+//задачи которые уже взяли параметры из пресета, его изменение не затрагивает
+func (handler *PresetHandler) PutPresetHandler(params operations.PutPresetParams) middleware.Responder {
+	if !presetNamePattern.MatchString(params.Name) {
+		return operations.NewPutPresetBadRequest().WithPayload(&models.Error{Detail: "preset name must be up to 64 lowercase letters, digits, - and _"})
+	}
+
+	var width int64
+	if params.Resize != nil {
+		width = *params.Resize
+	}
+	options := newResizeOptions(width, params.Height, params.Mode, params.Gravity, params.Background, params.Format, params.Filter)
+	options = withEncoderOptions(options, params.Quality, params.Compression, params.Colors, params.Dither, params.FirstFrame)
+	options, err := withTransformOptions(options, params.Crop, params.Rotate, params.Flip)
+	if err != nil {
+		return operations.NewPutPresetBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	options, err = withAdjustOptions(options, params.Brightness, params.Contrast, params.Gamma, params.Saturation,
+		params.Grayscale, params.Sepia, params.Blur, params.Sharpen)
+	if err != nil {
+		return operations.NewPutPresetBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	options, err = withWatermarkOptions(options, params.Watermark, params.WatermarkText, params.WatermarkColor,
+		params.WatermarkGravity, params.WatermarkScale, params.WatermarkOpacity, params.WatermarkMargin, params.WatermarkTile)
+	if err != nil {
+		return operations.NewPutPresetBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	err = options.Validate()
+	if err != nil {
+		return operations.NewPutPresetBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+
+	preset := repositories.Preset{
+		Name:      params.Name,
+		Options:   options,
+		UpdatedAt: time.Now().UTC(),
+	}
+	err = handler.PresetRepository.Put(preset)
+	if err != nil {
+		return operations.NewPutPresetInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	return operations.NewPutPresetOK().WithPayload(preset)
+}
+
+func (handler *PresetHandler) DeletePresetHandler(params operations.DeletePresetParams) middleware.Responder {
+	deleted, err := handler.PresetRepository.Delete(params.Name)
+	if err != nil {
+		return operations.NewDeletePresetInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	if !deleted {
+		return operations.NewDeletePresetBadRequest().WithPayload(&models.Error{Detail: "preset " + params.Name + " not found"})
+	}
+	return operations.NewDeletePresetOK().WithPayload(params.Name)
+}
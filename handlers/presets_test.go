@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/op/go-logging"
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"github.com/xan-mortum/apimediaservice/components/storage"
+	"github.com/xan-mortum/apimediaservice/gen/restapi/operations"
+	"github.com/xan-mortum/apimediaservice/processors"
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestPresetHandler(t *testing.T) (*PresetHandler, *repositories.Repositories) {
+	repos, err := repositories.NewRepositories(repositories.NewConfig(repositories.DriverMemory, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewPresetHandler(logging.MustGetLogger("test"), repos.PresetRepository), repos
+}
+
+func serve(responder middleware.Responder) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	responder.WriteResponse(recorder, runtime.JSONProducer())
+	return recorder
+}
+
+func TestPutPresetHandler(t *testing.T) {
+	width := int64(300)
+	height := int64(200)
+	mode := imagemanager.ModeFill
+	defaultMode := imagemanager.ModeFit
+	badMode := "zoom"
+	badCrop := "1,2"
+	tests := []struct {
+		name    string
+		params  operations.PutPresetParams
+		code    int
+		options imagemanager.ResizeOptions
+	}{
+		{"resize", operations.PutPresetParams{Name: "product-card", Resize: &width, Height: &height, Mode: &mode}, http.StatusOK,
+			imagemanager.ResizeOptions{Width: 300, Height: 200, Mode: imagemanager.ModeFill}},
+		//значения по умолчанию не сохраняються, как и у ресайзов
+		{"default mode", operations.PutPresetParams{Name: "avatar_2", Resize: &width, Mode: &defaultMode}, http.StatusOK, imagemanager.WidthOptions(300)},
+		{"uppercase name", operations.PutPresetParams{Name: "Avatar", Resize: &width}, http.StatusBadRequest, imagemanager.ResizeOptions{}},
+		{"name with slash", operations.PutPresetParams{Name: "a/b", Resize: &width}, http.StatusBadRequest, imagemanager.ResizeOptions{}},
+		{"nothing to do", operations.PutPresetParams{Name: "empty"}, http.StatusBadRequest, imagemanager.ResizeOptions{}},
+		{"bad mode", operations.PutPresetParams{Name: "bad-mode", Resize: &width, Height: &height, Mode: &badMode}, http.StatusBadRequest, imagemanager.ResizeOptions{}},
+		{"bad crop", operations.PutPresetParams{Name: "bad-crop", Resize: &width, Crop: &badCrop}, http.StatusBadRequest, imagemanager.ResizeOptions{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler, repos := newTestPresetHandler(t)
+			response := serve(handler.PutPresetHandler(test.params))
+			if response.Code != test.code {
+				t.Fatalf("status %d, want %d: %s", response.Code, test.code, response.Body)
+			}
+			preset, err := repos.PresetRepository.Get(test.params.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.code != http.StatusOK {
+				if preset != nil {
+					t.Fatalf("invalid preset is saved: %+v", preset)
+				}
+				return
+			}
+			if preset == nil || preset.Options != test.options || preset.UpdatedAt.IsZero() {
+				t.Fatalf("preset %+v, want options %+v", preset, test.options)
+			}
+		})
+	}
+}
+
+func TestPresetCRUD(t *testing.T) {
+	handler, _ := newTestPresetHandler(t)
+	width := int64(64)
+	get := func() middleware.Responder {
+		return handler.PresetHandler(operations.PresetParams{Name: "avatar"})
+	}
+	put := func() middleware.Responder {
+		return handler.PutPresetHandler(operations.PutPresetParams{Name: "avatar", Resize: &width})
+	}
+	remove := func() middleware.Responder {
+		return handler.DeletePresetHandler(operations.DeletePresetParams{Name: "avatar"})
+	}
+	//шаги выполняються по порядку, каждый зависит от предыдущих
+	steps := []struct {
+		name    string
+		handler func() middleware.Responder
+		code    int
+	}{
+		{"missing", get, http.StatusBadRequest},
+		{"put", put, http.StatusOK},
+		{"get", get, http.StatusOK},
+		{"delete", remove, http.StatusOK},
+		{"delete again", remove, http.StatusBadRequest},
+	}
+	for _, step := range steps {
+		response := serve(step.handler())
+		if response.Code != step.code {
+			t.Fatalf("%s: status %d, want %d: %s", step.name, response.Code, step.code, response.Body)
+		}
+	}
+
+	response := serve(handler.PresetsHandler(operations.PresetsParams{}))
+	var presets []repositories.Preset
+	err := json.Unmarshal(response.Body.Bytes(), &presets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(presets) != 0 {
+		t.Fatalf("presets %+v", presets)
+	}
+}
+
+//пресет подставляет параметры ресайза в задачу /v2/resize
+func TestV2resizePreset(t *testing.T) {
+	repos, err := repositories.NewRepositories(repositories.NewConfig(repositories.DriverMemory, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = repos.PresetRepository.Put(repositories.Preset{
+		Name:    "card",
+		Options: imagemanager.ResizeOptions{Width: 300, Height: 200, Mode: imagemanager.ModeFill, Quality: 80},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//воркеры не запускаються, задача только сохраняеться
+	ip := processors.NewImageProcessor(
+		logging.MustGetLogger("test"),
+		processors.NewConfig(1, 10, time.Minute, processors.RetryPolicy{}, processors.RetryPolicy{}),
+		repos.TaskRepository,
+		repos.ResizeRepository,
+		repos.ImageRepository,
+		repos.DeliveryRepository,
+		storage.NewMemoryStore(),
+		imagemanager.NewImageManager(imagemanager.NewConfig("", "", imagemanager.MetadataStrip, false)),
+		nil,
+	)
+	handler := NewAsynchronousHandler(logging.MustGetLogger("test"), ip, repos.UserImageRepository, repos.ResizeRepository, repos.ImageRepository, repos.PresetRepository)
+
+	card := "card"
+	missing := "missing"
+	width := int64(100)
+	tests := []struct {
+		name   string
+		params operations.V2resizeParams
+		code   int
+	}{
+		{"preset", operations.V2resizeParams{Token: "token", File: "a.png", Preset: &card}, http.StatusOK},
+		{"missing preset", operations.V2resizeParams{Token: "token", File: "a.png", Preset: &missing}, http.StatusBadRequest},
+		//параметры пресета нельзя дополнить или переопределить
+		{"preset with resize", operations.V2resizeParams{Token: "token", File: "a.png", Preset: &card, Resize: &width}, http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := serve(handler.V2resizeHandler(test.params))
+			if response.Code != test.code {
+				t.Fatalf("status %d, want %d: %s", response.Code, test.code, response.Body)
+			}
+			if test.code != http.StatusOK {
+				return
+			}
+			var taskId string
+			err := json.Unmarshal(response.Body.Bytes(), &taskId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			saved, err := repos.TaskRepository.Get(taskId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var task processors.ResizeTask
+			err = json.Unmarshal(saved.Payload, &task)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := imagemanager.ResizeOptions{Width: 300, Height: 200, Mode: imagemanager.ModeFill, Quality: 80}
+			options := task.ResizeOptions()
+			if task.Preset != "card" || len(options) != 1 || options[0] != want {
+				t.Fatalf("task preset %s options %+v, want %+v", task.Preset, options, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"strconv"
+	"strings"
+)
+
+//собирает параметры ресайза из запроса
+//значения по умолчанию не сохраняються, так записи о ресайзах только по ширине остаються такими же как раньше
+func newResizeOptions(width int64, height *int64, mode *string, gravity *string, background *string, format *string, filter *string) imagemanager.ResizeOptions {
+	options := imagemanager.WidthOptions(uint(width))
+	if height != nil {
+		options.Height = uint(*height)
+	}
+	if mode != nil && *mode != imagemanager.ModeFit {
+		options.Mode = *mode
+	}
+	if gravity != nil && *gravity != imagemanager.GravityCenter {
+		options.Gravity = *gravity
+	}
+	if background != nil {
+		options.Background = *background
+	}
+	if format != nil {
+		options.Format = *format
+	}
+	if filter != nil {
+		options.Filter = *filter
+	}
+	return options
+}
+
+//настройки кодировщика. dither по умолчанию включен, поэтому сохраняеться только его отключение
+//firstFrame - сохранять из анимации только первый кадр
+func withEncoderOptions(options imagemanager.ResizeOptions, quality *int64, compression *string, colors *int64, dither *bool, firstFrame *bool) imagemanager.ResizeOptions {
+	if quality != nil {
+		options.Quality = uint(*quality)
+	}
+	if compression != nil && *compression != imagemanager.CompressionDefault {
+		options.Compression = *compression
+	}
+	if colors != nil {
+		options.Colors = uint(*colors)
+	}
+	if dither != nil {
+		options.NoDither = !*dither
+	}
+	if firstFrame != nil {
+		options.FirstFrame = *firstFrame
+	}
+	return options
+}
+
+//обрезка, поворот и отражение, они делаються до ресайза
+func withTransformOptions(options imagemanager.ResizeOptions, crop *string, rotate *float64, flip *string) (imagemanager.ResizeOptions, error) {
+	if crop != nil {
+		cropRect, err := parseCrop(*crop)
+		if err != nil {
+			return options, err
+		}
+		options.Crop = cropRect
+	}
+	if rotate != nil {
+		options.Rotate = *rotate
+	}
+	if flip != nil {
+		options.Flip = *flip
+	}
+	return options, nil
+}
+
+//цветокоррекция, размытие и резкость, они делаються после ресайза
+func withAdjustOptions(
+	options imagemanager.ResizeOptions,
+	brightness *float64,
+	contrast *float64,
+	gamma *float64,
+	saturation *float64,
+	grayscale *bool,
+	sepia *bool,
+	blur *float64,
+	sharpen *string,
+) (imagemanager.ResizeOptions, error) {
+	if brightness != nil {
+		options.Brightness = *brightness
+	}
+	if contrast != nil {
+		options.Contrast = *contrast
+	}
+	if gamma != nil && *gamma != 1 {
+		options.Gamma = *gamma
+	}
+	if saturation != nil {
+		options.Saturation = *saturation
+	}
+	if grayscale != nil {
+		options.Grayscale = *grayscale
+	}
+	if sepia != nil {
+		options.Sepia = *sepia
+	}
+	if blur != nil {
+		options.Blur = *blur
+	}
+	if sharpen != nil {
+		mask, err := parseSharpen(*sharpen)
+		if err != nil {
+			return options, err
+		}
+		options.Sharpen = mask
+	}
+	return options, nil
+}
+
+//водяной знак рисуеться поверх результата. нужен либо watermark (id картинки), либо watermarkText
+//значения по умолчанию не сохраняються, как и у ресайза. остальные параметры без картинки или текста ошибка
+func withWatermarkOptions(
+	options imagemanager.ResizeOptions,
+	image *string,
+	text *string,
+	color *string,
+	gravity *string,
+	scale *float64,
+	opacity *float64,
+	margin *int64,
+	tile *bool,
+) (imagemanager.ResizeOptions, error) {
+	watermark := imagemanager.Watermark{}
+	if image != nil {
+		watermark.Image = *image
+	}
+	if text != nil {
+		watermark.Text = *text
+	}
+	if color != nil && *color != imagemanager.DefaultWatermarkColor {
+		watermark.Color = *color
+	}
+	if gravity != nil && *gravity != imagemanager.DefaultWatermarkGravity {
+		watermark.Gravity = *gravity
+	}
+	if scale != nil && *scale != imagemanager.DefaultWatermarkScale {
+		watermark.Scale = *scale
+	}
+	if opacity != nil && *opacity != 1 {
+		watermark.Opacity = *opacity
+	}
+	if margin != nil {
+		watermark.Margin = uint(*margin)
+	}
+	if tile != nil {
+		watermark.Tile = *tile
+	}
+	if watermark == (imagemanager.Watermark{}) {
+		return options, nil
+	}
+	if watermark.Image == "" && watermark.Text == "" {
+		return options, fmt.Errorf("%w: watermark parameters need watermark or watermark_text", imagemanager.ErrInvalidOptions)
+	}
+	options.Watermark = &watermark
+	return options, nil
+}
+
+//резкость в виде sigma,amount,threshold. amount и threshold можно не указывать
+func parseSharpen(value string) (*imagemanager.UnsharpMask, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("%w: sharpen must be sigma[,amount[,threshold]]", imagemanager.ErrInvalidOptions)
+	}
+	mask := &imagemanager.UnsharpMask{Amount: 1}
+	var err error
+	mask.Sigma, err = strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err == nil && len(parts) > 1 {
+		mask.Amount, err = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	}
+	if err == nil && len(parts) > 2 {
+		var threshold uint64
+		threshold, err = strconv.ParseUint(strings.TrimSpace(parts[2]), 10, 8)
+		mask.Threshold = uint8(threshold)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: sharpen must be sigma[,amount[,threshold]]", imagemanager.ErrInvalidOptions)
+	}
+	return mask, nil
+}
+
+//прямоугольник обрезки в виде x,y,width,height
+func parseCrop(value string) (*imagemanager.CropRect, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("%w: crop must be x,y,width,height", imagemanager.ErrInvalidOptions)
+	}
+	var numbers [4]uint
+	for i, part := range parts {
+		number, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: crop must be x,y,width,height", imagemanager.ErrInvalidOptions)
+		}
+		numbers[i] = uint(number)
+	}
+	return &imagemanager.CropRect{X: numbers[0], Y: numbers[1], Width: numbers[2], Height: numbers[3]}, nil
+}
+
+//конвеер передаеться json массивом операций. неизвестные поля это скорее всего опечатка, поэтому ошибка
+func parsePipeline(value string) (imagemanager.Pipeline, error) {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.DisallowUnknownFields()
+	var pipeline imagemanager.Pipeline
+	err := decoder.Decode(&pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("%w: bad pipeline: %s", imagemanager.ErrInvalidOptions, err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("%w: bad pipeline: unexpected data after the array", imagemanager.ErrInvalidOptions)
+	}
+	return pipeline, pipeline.Validate()
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"reflect"
+	"testing"
+)
+
+func TestParseCrop(t *testing.T) {
+	tests := []struct {
+		value string
+		crop  *imagemanager.CropRect
+	}{
+		{"1,2,30,40", &imagemanager.CropRect{X: 1, Y: 2, Width: 30, Height: 40}},
+		{" 0, 0, 10, 10 ", &imagemanager.CropRect{Width: 10, Height: 10}},
+		{"1,2,30", nil},
+		{"1,2,30,40,50", nil},
+		{"-1,2,30,40", nil},
+		{"a,b,c,d", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			crop, err := parseCrop(test.value)
+			if !reflect.DeepEqual(crop, test.crop) {
+				t.Fatalf("parseCrop() = %+v, want %+v", crop, test.crop)
+			}
+			if test.crop == nil && !errors.Is(err, imagemanager.ErrInvalidOptions) {
+				t.Fatalf("error %v is not %v", err, imagemanager.ErrInvalidOptions)
+			}
+		})
+	}
+}
+
+func TestWithTransformOptions(t *testing.T) {
+	crop := "0,0,10,10"
+	badCrop := "0,0,10"
+	rotate := 90.0
+	flip := imagemanager.FlipHorizontal
+	tests := []struct {
+		name    string
+		crop    *string
+		rotate  *float64
+		flip    *string
+		options imagemanager.ResizeOptions
+		valid   bool
+	}{
+		{"nothing", nil, nil, nil, imagemanager.WidthOptions(100), true},
+		{"all", &crop, &rotate, &flip, imagemanager.ResizeOptions{
+			Width:  100,
+			Crop:   &imagemanager.CropRect{Width: 10, Height: 10},
+			Rotate: 90,
+			Flip:   imagemanager.FlipHorizontal,
+		}, true},
+		{"bad crop", &badCrop, nil, nil, imagemanager.WidthOptions(100), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options, err := withTransformOptions(imagemanager.WidthOptions(100), test.crop, test.rotate, test.flip)
+			if (err == nil) != test.valid || !reflect.DeepEqual(options, test.options) {
+				t.Fatalf("withTransformOptions() = %+v %v, want %+v", options, err, test.options)
+			}
+		})
+	}
+}
+
+func TestParsePipeline(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		pipeline imagemanager.Pipeline
+	}{
+		{"resize and encode", `[{"op":"resize","width":100},{"op":"encode","format":"png"}]`, imagemanager.Pipeline{
+			{Op: imagemanager.OpResize, Width: 100},
+			{Op: imagemanager.OpEncode, Format: imagemanager.FormatPNG},
+		}},
+		//опечатка в имени поля не должна молча давать другой результат
+		{"unknown field", `[{"op":"resize","widht":100}]`, nil},
+		{"data after the array", `[{"op":"flip","direction":"vertical"}] []`, nil},
+		{"not an array", `{"op":"resize","width":100}`, nil},
+		{"invalid pipeline", `[]`, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pipeline, err := parsePipeline(test.value)
+			if test.pipeline == nil {
+				if !errors.Is(err, imagemanager.ErrInvalidOptions) {
+					t.Fatalf("parsePipeline() = %+v %v, want %v", pipeline, err, imagemanager.ErrInvalidOptions)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(pipeline, test.pipeline) {
+				t.Fatalf("parsePipeline() = %+v %v, want %+v", pipeline, err, test.pipeline)
+			}
+		})
+	}
+}
+
+func TestParseSharpen(t *testing.T) {
+	tests := []struct {
+		value string
+		mask  *imagemanager.UnsharpMask
+	}{
+		//amount по умолчанию 1
+		{"1.5", &imagemanager.UnsharpMask{Sigma: 1.5, Amount: 1}},
+		{"1, 2", &imagemanager.UnsharpMask{Sigma: 1, Amount: 2}},
+		{"1,2,10", &imagemanager.UnsharpMask{Sigma: 1, Amount: 2, Threshold: 10}},
+		{"1,2,256", nil},
+		{"1,2,3,4", nil},
+		{"", nil},
+		{"a", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			mask, err := parseSharpen(test.value)
+			if !reflect.DeepEqual(mask, test.mask) {
+				t.Fatalf("parseSharpen() = %+v %v, want %+v", mask, err, test.mask)
+			}
+			if test.mask == nil && !errors.Is(err, imagemanager.ErrInvalidOptions) {
+				t.Fatalf("error %v is not %v", err, imagemanager.ErrInvalidOptions)
+			}
+		})
+	}
+}
+
+func TestWithAdjustOptions(t *testing.T) {
+	brightness := 10.0
+	gamma := 1.0
+	otherGamma := 2.2
+	grayscale := true
+	blur := 1.5
+	sharpen := "1,2"
+	badSharpen := "x"
+	tests := []struct {
+		name       string
+		brightness *float64
+		gamma      *float64
+		grayscale  *bool
+		blur       *float64
+		sharpen    *string
+		options    imagemanager.ResizeOptions
+		valid      bool
+	}{
+		{"nothing", nil, nil, nil, nil, nil, imagemanager.WidthOptions(100), true},
+		{"all", &brightness, &otherGamma, &grayscale, &blur, &sharpen, imagemanager.ResizeOptions{
+			Width:      100,
+			Brightness: 10,
+			Gamma:      2.2,
+			Grayscale:  true,
+			Blur:       1.5,
+			Sharpen:    &imagemanager.UnsharpMask{Sigma: 1, Amount: 2},
+		}, true},
+		//гамма 1 значение по умолчанию и не сохраняеться
+		{"neutral gamma", nil, &gamma, nil, nil, nil, imagemanager.WidthOptions(100), true},
+		{"bad sharpen", nil, nil, nil, nil, &badSharpen, imagemanager.WidthOptions(100), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options, err := withAdjustOptions(imagemanager.WidthOptions(100), test.brightness, nil, test.gamma, nil, test.grayscale, nil, test.blur, test.sharpen)
+			if (err == nil) != test.valid || !reflect.DeepEqual(options, test.options) {
+				t.Fatalf("withAdjustOptions() = %+v %v, want %+v", options, err, test.options)
+			}
+		})
+	}
+}
+
+func TestWithWatermarkOptions(t *testing.T) {
+	logo := "logo.png"
+	text := "shop"
+	defaultColor := imagemanager.DefaultWatermarkColor
+	gravity := imagemanager.GravityNorthWest
+	defaultGravity := imagemanager.DefaultWatermarkGravity
+	scale := 0.5
+	opacity := 1.0
+	margin := int64(10)
+	tile := true
+	tests := []struct {
+		name    string
+		image   *string
+		text    *string
+		color   *string
+		gravity *string
+		scale   *float64
+		opacity *float64
+		margin  *int64
+		tile    *bool
+		options imagemanager.ResizeOptions
+		valid   bool
+	}{
+		{"nothing", nil, nil, nil, nil, nil, nil, nil, nil, imagemanager.WidthOptions(100), true},
+		{"image", &logo, nil, nil, &gravity, &scale, nil, &margin, &tile, imagemanager.ResizeOptions{
+			Width:     100,
+			Watermark: &imagemanager.Watermark{Image: logo, Gravity: gravity, Scale: 0.5, Margin: 10, Tile: true},
+		}, true},
+		//значения по умолчанию не сохраняються и не меняют имя файла
+		{"defaults", nil, &text, &defaultColor, &defaultGravity, nil, &opacity, nil, nil, imagemanager.ResizeOptions{
+			Width:     100,
+			Watermark: &imagemanager.Watermark{Text: text},
+		}, true},
+		{"parameters without watermark", nil, nil, nil, &gravity, &scale, nil, nil, nil, imagemanager.WidthOptions(100), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options, err := withWatermarkOptions(imagemanager.WidthOptions(100), test.image, test.text, test.color, test.gravity, test.scale, test.opacity, test.margin, test.tile)
+			if (err == nil) != test.valid || !reflect.DeepEqual(options, test.options) {
+				t.Fatalf("withWatermarkOptions() = %+v %v, want %+v", options, err, test.options)
+			}
+			if err != nil && !errors.Is(err, imagemanager.ErrInvalidOptions) {
+				t.Fatalf("error %v is not %v", err, imagemanager.ErrInvalidOptions)
+			}
+		})
+	}
+}
@@ -1,49 +1,47 @@
 package handlers
 
 import (
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/google/uuid"
 	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"github.com/xan-mortum/apimediaservice/components/storage"
 	"github.com/xan-mortum/apimediaservice/gen/models"
 	"github.com/xan-mortum/apimediaservice/gen/restapi/operations"
 	"github.com/xan-mortum/apimediaservice/interfaces"
 	"github.com/xan-mortum/apimediaservice/repositories"
+	"io/ioutil"
 	"path/filepath"
 )
 
 type SynchronousHandler struct {
 	Logger              interfaces.Logger
 	ImageManager        imagemanager.ImageManager
-	S3Session           *session.Session
-	UserImageRepository *repositories.UserImageRepository
-	ImageRepository     *repositories.ImageRepository
-	ResizeRepository    *repositories.ResizeRepository
-	bucket           string
+	Storage             storage.BlobStore
+	UserImageRepository repositories.UserImageRepository
+	ImageRepository     repositories.ImageRepository
+	ResizeRepository    repositories.ResizeRepository
+	PresetRepository    repositories.PresetRepository
 }
 
 func NewSynchronousHandler(
 	logger interfaces.Logger,
 	im imagemanager.ImageManager,
-	s3 *session.Session,
-	userImageRepository *repositories.UserImageRepository,
-	imageRepository *repositories.ImageRepository,
-	resizeRepository *repositories.ResizeRepository,
-	bucket           string,
+	blobStore storage.BlobStore,
+	userImageRepository repositories.UserImageRepository,
+	imageRepository repositories.ImageRepository,
+	resizeRepository repositories.ResizeRepository,
+	presetRepository repositories.PresetRepository,
 ) *SynchronousHandler {
 	return &SynchronousHandler{
 		Logger:              logger,
 		//структура которая никапсулирует работу с временными файлами в том числе с ресайзнутыми
 		ImageManager:        im,
-		S3Session:           s3,
+		Storage:             blobStore,
 		UserImageRepository: userImageRepository,
 		ImageRepository:     imageRepository,
 		ResizeRepository:    resizeRepository,
-		bucket: bucket,
+		PresetRepository:    presetRepository,
 	}
 }
 
@@ -70,14 +68,36 @@ func (handler *SynchronousHandler) ResizeHandler(params operations.ResizeParams)
 		return operations.NewResizeBadRequest().WithPayload(&models.Error{Detail: fileExt + " id not supported",})
 	}
 
+	//высота, режим и остальное не обязательны. без них ресайз идет только по ширине
+	options := newResizeOptions(inputResize, params.Height, params.Mode, params.Gravity, params.Background, params.Format, params.Filter)
+	options = withEncoderOptions(options, params.Quality, params.Compression, params.Colors, params.Dither, params.FirstFrame)
+	options = handler.ImageManager.WithDefaults(options)
+	err := options.Validate()
+	if err != nil {
+		return operations.NewResizeBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+
 	//тут создаеться временный файл в файловой системе и возвращаеться структура с его данными
 	file, err := handler.ImageManager.CreateFile(params.Upfile, fileName)
 	if err != nil {
 		return operations.NewResizeInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
 
+	//оригинал чистим так же как в /v2/upload
+	if handler.ImageManager.Config.SanitizeOriginals {
+		err = handler.ImageManager.SanitizeFile(file)
+		if err != nil {
+			return operations.NewResizeBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+		}
+	}
+
+	info, err := handler.ImageManager.ExtractFileInfo(file)
+	if err != nil {
+		return operations.NewResizeBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+
 	//тут создаеться временная картика с измененным размером. возвращаеться структура с данными
-	thumbFile, err := handler.ImageManager.ResizeFile(file, uint(inputResize))
+	thumbFile, err := handler.ImageManager.ResizeFile(file, options)
 	if err != nil {
 		return operations.NewResizeInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
@@ -96,13 +116,8 @@ func (handler *SynchronousHandler) ResizeHandler(params operations.ResizeParams)
 		return operations.NewResizeInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
 
-	//заливаем на S3
-	uploader := s3manager.NewUploader(handler.S3Session)
-	upload, err := uploader.Upload(&s3manager.UploadInput{
-		Bucket: aws.String(handler.bucket),
-		Key:    aws.String(file.Name),
-		Body:   fileToUpload,
-	})
+	//заливаем в хранилище
+	location, err := handler.Storage.Put(file.Name, fileToUpload)
 	if err != nil {
 		return operations.NewResizeInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
@@ -119,12 +134,8 @@ func (handler *SynchronousHandler) ResizeHandler(params operations.ResizeParams)
 		return operations.NewResizeInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
 
-	//заливаем на S3
-	thumbUpload, err := uploader.Upload(&s3manager.UploadInput{
-		Bucket: aws.String(handler.bucket),
-		Key:    aws.String(thumbFile.Name),
-		Body:   thumbToUpload,
-	})
+	//заливаем в хранилище
+	thumbLocation, err := handler.Storage.Put(thumbFile.Name, thumbToUpload)
 	if err != nil {
 		return operations.NewResizeInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
@@ -137,7 +148,8 @@ func (handler *SynchronousHandler) ResizeHandler(params operations.ResizeParams)
 	err = handler.ImageRepository.Put(repositories.Image{
 		Uuid:     imageUuid,
 		FileName: file.Name,
-		FilePath: upload.Location,
+		FilePath: location,
+		Info:     info,
 	})
 	if err != nil {
 		return operations.NewResizeInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
@@ -146,7 +158,7 @@ func (handler *SynchronousHandler) ResizeHandler(params operations.ResizeParams)
 	images := []repositories.UserImage{{
 		Uuid:             imageUuid,
 		OriginalFileName: file.Name,
-		OriginalFilePath: upload.Location,
+		OriginalFilePath: location,
 	}}
 
 	err = handler.UserImageRepository.Append(images, inputToken)
@@ -154,18 +166,16 @@ func (handler *SynchronousHandler) ResizeHandler(params operations.ResizeParams)
 		return operations.NewResizeInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
 
-	err = handler.ResizeRepository.Append([]repositories.ImageResizeInfo{{
-		ResizedFileName: thumbFile.Name,
-		ResizedFilePath: thumbUpload.Location,
-		ResizeParam:     inputResize,
-	}}, imageUuid)
+	err = handler.ResizeRepository.Append([]repositories.ImageResizeInfo{
+		repositories.NewImageResizeInfo(thumbFile.Name, thumbLocation, options),
+	}, imageUuid)
 	if err != nil {
 		return operations.NewResizeInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
 
 	return operations.NewResizeOK().WithPayload(&models.Resize{
-		Original: upload.Location,
-		Resized:  thumbUpload.Location,
+		Original: location,
+		Resized:  thumbLocation,
 	})
 }
 
@@ -199,7 +209,43 @@ func (handler *SynchronousHandler) FilesHandler(params operations.FilesParams) m
 func (handler *SynchronousHandler) ResizeExistsHandler(params operations.ResizeExistsParams) middleware.Responder {
 	//inputToken := params.Token
 	inputFile := params.File
-	inputResize := params.Resize
+	var inputResize int64
+	if params.Resize != nil {
+		inputResize = *params.Resize
+	}
+
+	options := newResizeOptions(inputResize, params.Height, params.Mode, params.Gravity, params.Background, params.Format, params.Filter)
+	options = withEncoderOptions(options, params.Quality, params.Compression, params.Colors, params.Dither, params.FirstFrame)
+	options, err := withAdjustOptions(options, params.Brightness, params.Contrast, params.Gamma, params.Saturation,
+		params.Grayscale, params.Sepia, params.Blur, params.Sharpen)
+	if err != nil {
+		return operations.NewResizeExistsBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	//вместо параметров можно передать имя пресета
+	presetName := ""
+	if params.Preset != nil {
+		if options != (imagemanager.ResizeOptions{}) {
+			return operations.NewResizeExistsBadRequest().WithPayload(&models.Error{Detail: "preset cannot be combined with other resize parameters"})
+		}
+		preset, err := handler.PresetRepository.Get(*params.Preset)
+		if err != nil {
+			return operations.NewResizeExistsInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+		}
+		if preset == nil {
+			return operations.NewResizeExistsBadRequest().WithPayload(&models.Error{Detail: "preset " + *params.Preset + " not found"})
+		}
+		//картинки водяных знаков скачивает только воркер, синхронно можно только текст
+		if preset.Options.Watermark != nil && preset.Options.Watermark.Image != "" {
+			return operations.NewResizeExistsBadRequest().WithPayload(&models.Error{Detail: "preset " + preset.Name + " has an image watermark, use /v2/resize"})
+		}
+		options = preset.Options
+		presetName = preset.Name
+	}
+	options = handler.ImageManager.WithDefaults(options)
+	err = options.Validate()
+	if err != nil {
+		return operations.NewResizeExistsBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
 
 	//получаем картинку из базы
 	fileInfo, err := handler.ImageRepository.Get(inputFile)
@@ -207,25 +253,25 @@ func (handler *SynchronousHandler) ResizeExistsHandler(params operations.ResizeE
 		return operations.NewResizeExistsBadRequest().WithPayload(&models.Error{Detail: err.Error()})
 	}
 
-	//скачиваем картинку с S3
-	buf := aws.NewWriteAtBuffer([]byte{})
-	downloader := s3manager.NewDownloader(handler.S3Session)
-	_, err = downloader.Download(buf, &s3.GetObjectInput{
-		Bucket: aws.String(handler.bucket),
-		Key:    aws.String(fileInfo.FileName),
-	})
+	//скачиваем картинку из хранилища
+	object, err := handler.Storage.Get(fileInfo.FileName)
+	if err != nil {
+		return operations.NewResizeExistsInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	data, err := ioutil.ReadAll(object)
+	_ = object.Close()
 	if err != nil {
 		return operations.NewResizeExistsInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
 
 	//сохраняем файл во временную папку
-	downloadedFile, err := handler.ImageManager.SaveFile(fileInfo.FileName, buf.Bytes())
+	downloadedFile, err := handler.ImageManager.SaveFile(fileInfo.FileName, data)
 	if err != nil {
 		return operations.NewResizeExistsInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
 
 	//ресайзим картинку
-	thumbFile, err := handler.ImageManager.ResizeFile(downloadedFile, uint(inputResize))
+	thumbFile, err := handler.ImageManager.ResizeFile(downloadedFile, options)
 	if err != nil {
 		return operations.NewResizeInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
@@ -242,13 +288,8 @@ func (handler *SynchronousHandler) ResizeExistsHandler(params operations.ResizeE
 		return operations.NewResizeInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
 
-	//загруаем на S3
-	uploader := s3manager.NewUploader(handler.S3Session)
-	thumbUpload, err := uploader.Upload(&s3manager.UploadInput{
-		Bucket: aws.String(handler.bucket),
-		Key:    aws.String(thumbFile.Name),
-		Body:   thumbToUpload,
-	})
+	//загруаем в хранилище
+	thumbLocation, err := handler.Storage.Put(thumbFile.Name, thumbToUpload)
 	if err != nil {
 		return operations.NewResizeInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
@@ -257,17 +298,15 @@ func (handler *SynchronousHandler) ResizeExistsHandler(params operations.ResizeE
 	handler.ImageManager.Clear()
 
 	//сохраняем в базу
-	err = handler.ResizeRepository.Append([]repositories.ImageResizeInfo{{
-		ResizedFileName: thumbFile.Name,
-		ResizedFilePath: thumbUpload.Location,
-		ResizeParam:     inputResize,
-	}}, inputFile)
+	resizeInfo := repositories.NewImageResizeInfo(thumbFile.Name, thumbLocation, options)
+	resizeInfo.Preset = presetName
+	err = handler.ResizeRepository.Append([]repositories.ImageResizeInfo{resizeInfo}, inputFile)
 	if err != nil {
 		return operations.NewResizeInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
 	}
 
 	return operations.NewResizeExistsOK().WithPayload(&models.Resize{
 		Original: fileInfo.FilePath,
-		Resized:  thumbUpload.Location,
+		Resized:  thumbLocation,
 	})
 }
@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"github.com/xan-mortum/apimediaservice/components/storage"
+	"github.com/xan-mortum/apimediaservice/components/urlsign"
+	"github.com/xan-mortum/apimediaservice/gen/models"
+	"github.com/xan-mortum/apimediaservice/gen/restapi/operations"
+	"github.com/xan-mortum/apimediaservice/interfaces"
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//ресайзы на лету по ссылке /img/{options}/{image}
+//первый запрос делает ресайз и сохраняет его в хранилище и ResizeRepository, следующие отдают готовый файл
+//если в Signing задан ключ, ссылка должна быть подписана, подписанные ссылки собирает пакет client
+type TransformHandler struct {
+	Logger           interfaces.Logger
+	ImageManager     imagemanager.ImageManager
+	Storage          storage.BlobStore
+	ImageRepository  repositories.ImageRepository
+	ResizeRepository repositories.ResizeRepository
+	Signing          urlsign.Config
+	//одинаковые ресайзы одной картинки делаються по очереди, иначе при наплыве запросов каждый сделает свой
+	locks keyedMutex
+}
+
+func NewTransformHandler(
+	logger interfaces.Logger,
+	imageManager imagemanager.ImageManager,
+	storage storage.BlobStore,
+	imageRepository repositories.ImageRepository,
+	resizeRepository repositories.ResizeRepository,
+	signing urlsign.Config,
+) *TransformHandler {
+	return &TransformHandler{
+		Logger:           logger,
+		ImageManager:     imageManager,
+		Storage:          storage,
+		ImageRepository:  imageRepository,
+		ResizeRepository: resizeRepository,
+		Signing:          signing,
+	}
+}
+
+func (handler *TransformHandler) TransformHandler(params operations.TransformParams) middleware.Responder {
+	responder := handler.transform(params)
+	if _, ok := responder.(*imageResponse); ok {
+		return responder
+	}
+	return jsonResponse{responder}
+}
+
+func (handler *TransformHandler) transform(params operations.TransformParams) middleware.Responder {
+	//подпись проверяеться до всего остального, неподписанный запрос не должен стоить ничего
+	var expires int64
+	if params.Expires != nil {
+		expires = *params.Expires
+	}
+	var signature string
+	if params.Signature != nil {
+		signature = *params.Signature
+	}
+	err := urlsign.Verify(handler.Signing, params.Options, params.Image, expires, signature, time.Now())
+	if err != nil {
+		return operations.NewTransformForbidden().WithPayload(&models.Error{Detail: err.Error()})
+	}
+
+	options, err := parseTransformOptions(params.Options)
+	if err != nil {
+		return operations.NewTransformBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	options = handler.ImageManager.WithDefaults(options)
+	err = options.Validate()
+	if err != nil {
+		return operations.NewTransformBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+
+	image, err := handler.ImageRepository.Get(params.Image)
+	if err != nil {
+		return operations.NewTransformInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	if image.Uuid == "" {
+		return operations.NewTransformBadRequest().WithPayload(&models.Error{Detail: "image " + params.Image + " not found"})
+	}
+
+	//имя ресайза зависит только от параметров, по нему и ищем готовый
+	thumbFileName := options.FileName(image.FileName)
+	contentType := imagemanager.ContentType(imagemanager.FormatByExtension(filepath.Ext(thumbFileName)))
+	unlock := handler.locks.lock(thumbFileName)
+	defer unlock()
+
+	//ресайзы картинки лежат под ее id, так же как у v1 и v2 задач
+	resizes, err := handler.ResizeRepository.Get(image.Uuid)
+	if err != nil {
+		return operations.NewTransformInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	cached := false
+	for _, resize := range resizes {
+		if resize.ResizedFileName == thumbFileName {
+			cached = true
+			break
+		}
+	}
+	if cached {
+		response, err := handler.stored(thumbFileName, contentType)
+		if err == nil {
+			return response
+		}
+		//файл могли удалить из хранилища, тогда делаем ресайз заново
+		if err != storage.ErrNotFound {
+			return operations.NewTransformInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+		}
+	}
+
+	//ресайз сразу уходит в хранилище, а клиенту отдаеться уже оттуда. так ни оригинал ни ресайз не держаться в памяти целиком
+	location, err := handler.resize(image, options, thumbFileName)
+	if errors.Is(err, imagemanager.ErrImageTooLarge) {
+		return operations.NewTransformBadRequest().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	if err != nil {
+		return operations.NewTransformInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	if !cached {
+		info := repositories.NewImageResizeInfo(thumbFileName, location, options)
+		err = handler.ResizeRepository.Append([]repositories.ImageResizeInfo{info}, image.Uuid)
+		if err != nil {
+			return operations.NewTransformInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+		}
+	}
+	response, err := handler.stored(thumbFileName, contentType)
+	if err != nil {
+		return operations.NewTransformInternalServerError().WithPayload(&models.Error{Detail: err.Error()})
+	}
+	return response
+}
+
+//открывает готовый ресайз в хранилище. размер берется из Stat, так Content-Length известен до чтения файла
+func (handler *TransformHandler) stored(key string, contentType string) (*imageResponse, error) {
+	info, err := handler.Storage.Stat(key)
+	if err != nil {
+		return nil, err
+	}
+	object, err := handler.Storage.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return &imageResponse{contentType: contentType, size: info.Size, object: object}, nil
+}
+
+//скачивает оригинал, ресайзит его и сохраняет ресайз в хранилище под key. у каждого запроса своя временная папка,
+//так параллельные запросы не удаляют файлы друг друга
+func (handler *TransformHandler) resize(image repositories.Image, options imagemanager.ResizeOptions, key string) (string, error) {
+	config := handler.ImageManager.Config
+	tmpDir, err := ioutil.TempDir(config.TmpDir, "img")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	config.TmpDir = tmpDir + string(filepath.Separator)
+	im := imagemanager.NewImageManager(config)
+	defer im.Clear()
+
+	object, err := handler.Storage.Get(image.FileName)
+	if err != nil {
+		return "", err
+	}
+	file, err := im.CreateFile(object, filepath.Base(image.FileName))
+	_ = object.Close()
+	if err != nil {
+		return "", err
+	}
+	thumbFile, err := im.ResizeFile(file, options)
+	if err != nil {
+		return "", err
+	}
+	thumb, err := im.GetFileResource(thumbFile)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = thumb.Close()
+	}()
+	return handler.Storage.Put(key, thumb)
+}
+
+//картинка из хранилища. сгенерированный ответ отдает payload через выбранный по Accept producer,
+//а картинку надо отдавать как есть с ее Content-Type
+type imageResponse struct {
+	contentType string
+	size        int64
+	object      io.ReadCloser
+}
+
+func (r *imageResponse) WriteResponse(rw http.ResponseWriter, _ runtime.Producer) {
+	defer func() {
+		_ = r.object.Close()
+	}()
+	rw.Header().Set("Content-Type", r.contentType)
+	rw.Header().Set("Content-Length", strconv.FormatInt(r.size, 10))
+	rw.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(rw, r.object)
+}
+
+//ошибки всегда отдаються в json. формат ответа выбираеться по Accept, и для Accept: image/* ошибку отдал бы
+//BinProducer с Content-Type картинки
+type jsonResponse struct {
+	middleware.Responder
+}
+
+func (r jsonResponse) WriteResponse(rw http.ResponseWriter, _ runtime.Producer) {
+	rw.Header().Set("Content-Type", runtime.JSONMime)
+	r.Responder.WriteResponse(rw, runtime.JSONProducer())
+}
+
+//параметры в пути через запятую, у каждого ключ и значения через двоеточие, например w:200,h:100,m:fill,f:jpeg,q:80
+//w - ширина, h - высота, m - режим, g - gravity, bg - фон, fi - фильтр
+//c - обрезка x:y:width:height, r - поворот, fl - отражение, f - формат, q - качество jpeg
+func parseTransformOptions(value string) (imagemanager.ResizeOptions, error) {
+	options := imagemanager.ResizeOptions{}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		values := strings.Split(part, ":")
+		key := values[0]
+		values = values[1:]
+		if seen[key] {
+			return options, fmt.Errorf("%w: option %q is repeated", imagemanager.ErrInvalidOptions, key)
+		}
+		seen[key] = true
+		if key == "c" {
+			if len(values) != 4 {
+				return options, fmt.Errorf("%w: option c must be c:x:y:width:height", imagemanager.ErrInvalidOptions)
+			}
+			crop, err := parseCrop(strings.Join(values, ","))
+			if err != nil {
+				return options, err
+			}
+			options.Crop = crop
+			continue
+		}
+		if len(values) != 1 || values[0] == "" {
+			return options, fmt.Errorf("%w: option %q must be %s:value", imagemanager.ErrInvalidOptions, key, key)
+		}
+		var err error
+		switch key {
+		case "w", "h", "q":
+			var number uint64
+			number, err = strconv.ParseUint(values[0], 10, 32)
+			switch key {
+			case "w":
+				options.Width = uint(number)
+			case "h":
+				options.Height = uint(number)
+			case "q":
+				options.Quality = uint(number)
+			}
+		case "r":
+			options.Rotate, err = strconv.ParseFloat(values[0], 64)
+		case "m":
+			if values[0] != imagemanager.ModeFit {
+				options.Mode = values[0]
+			}
+		case "g":
+			if values[0] != imagemanager.GravityCenter {
+				options.Gravity = values[0]
+			}
+		case "bg":
+			options.Background = values[0]
+		case "fi":
+			options.Filter = values[0]
+		case "fl":
+			options.Flip = values[0]
+		case "f":
+			options.Format = values[0]
+		default:
+			return options, fmt.Errorf("%w: unknown option %q", imagemanager.ErrInvalidOptions, key)
+		}
+		if err != nil {
+			return options, fmt.Errorf("%w: option %q must be a number", imagemanager.ErrInvalidOptions, key)
+		}
+	}
+	return options, nil
+}
+
+//мьютекс на каждый ключ. запись удаляеться когда ключ никто не держит, так карта не растет
+type keyedMutex struct {
+	mx    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	sync.Mutex
+	waiters int
+}
+
+func (m *keyedMutex) lock(key string) func() {
+	m.mx.Lock()
+	if m.locks == nil {
+		m.locks = make(map[string]*keyedLock)
+	}
+	l, ok := m.locks[key]
+	if !ok {
+		l = &keyedLock{}
+		m.locks[key] = l
+	}
+	l.waiters++
+	m.mx.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		m.mx.Lock()
+		l.waiters--
+		if l.waiters == 0 {
+			delete(m.locks, key)
+		}
+		m.mx.Unlock()
+	}
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"github.com/op/go-logging"
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"github.com/xan-mortum/apimediaservice/components/storage"
+	"github.com/xan-mortum/apimediaservice/components/urlsign"
+	"github.com/xan-mortum/apimediaservice/gen/restapi/operations"
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+//хендлер на памяти с одной картинкой a (файл a.png 40x20)
+func newTestTransformHandler(t *testing.T) (*TransformHandler, storage.BlobStore, *repositories.Repositories) {
+	repos, err := repositories.NewRepositories(repositories.NewConfig(repositories.DriverMemory, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpDir, err := ioutil.TempDir("", "transform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(tmpDir)
+	})
+	store := storage.NewMemoryStore()
+
+	var data bytes.Buffer
+	err = png.Encode(&data, image.NewRGBA(image.Rect(0, 0, 40, 20)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.Put("a.png", &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = repos.ImageRepository.Put(repositories.Image{Uuid: "a", FileName: "a.png"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := NewTransformHandler(
+		logging.MustGetLogger("test"),
+		imagemanager.NewImageManager(imagemanager.NewConfig(tmpDir+string(os.PathSeparator), "", imagemanager.MetadataStrip, false)),
+		store,
+		repos.ImageRepository,
+		repos.ResizeRepository,
+		urlsign.Config{},
+	)
+	return handler, store, repos
+}
+
+func serveTransform(handler *TransformHandler, options string, image string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	responder := handler.TransformHandler(operations.TransformParams{
+		HTTPRequest: httptest.NewRequest(http.MethodGet, "/img/"+options+"/"+image, nil),
+		Options:     options,
+		Image:       image,
+	})
+	responder.WriteResponse(recorder, nil)
+	return recorder
+}
+
+func TestTransformStoresResize(t *testing.T) {
+	handler, store, repos := newTestTransformHandler(t)
+
+	response := serveTransform(handler, "w:10", "a")
+	if response.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", response.Code, response.Body)
+	}
+	if response.Header().Get("Content-Length") != strconv.Itoa(response.Body.Len()) {
+		t.Fatalf("Content-Length %s, body %d bytes", response.Header().Get("Content-Length"), response.Body.Len())
+	}
+	config, err := png.DecodeConfig(bytes.NewReader(response.Body.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Width != 10 || config.Height != 5 {
+		t.Fatalf("size %dx%d, want 10x5", config.Width, config.Height)
+	}
+	resizes, err := repos.ResizeRepository.Get("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resizes) != 1 {
+		t.Fatalf("resizes %+v", resizes)
+	}
+
+	//следующий запрос отдает файл из хранилища как есть
+	key := resizes[0].ResizedFileName
+	_, err = store.Put(key, bytes.NewReader([]byte("stored")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response = serveTransform(handler, "w:10", "a")
+	if response.Code != http.StatusOK || response.Body.String() != "stored" {
+		t.Fatalf("cached response %d %q", response.Code, response.Body)
+	}
+
+	//удаленный из хранилища ресайз делаеться заново, запись о нем не дублируеться
+	err = store.Delete(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response = serveTransform(handler, "w:10", "a")
+	if response.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", response.Code, response.Body)
+	}
+	_, err = png.DecodeConfig(bytes.NewReader(response.Body.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resizes, err = repos.ResizeRepository.Get("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resizes) != 1 {
+		t.Fatalf("resizes after delete %+v", resizes)
+	}
+}
+
+func TestTransformErrors(t *testing.T) {
+	handler, _, _ := newTestTransformHandler(t)
+	tests := []struct {
+		name    string
+		options string
+		image   string
+		code    int
+	}{
+		{"unknown image", "w:10", "b", http.StatusBadRequest},
+		{"invalid options", "w:x", "a", http.StatusBadRequest},
+		{"too large", "w:" + strconv.Itoa(imagemanager.MaxDimension+1), "a", http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := serveTransform(handler, test.options, test.image)
+			if response.Code != test.code {
+				t.Fatalf("status %d, want %d: %s", response.Code, test.code, response.Body)
+			}
+			if response.Header().Get("Content-Type") != "application/json" {
+				t.Fatalf("Content-Type %s", response.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestParseTransformOptions(t *testing.T) {
+	tests := []struct {
+		value   string
+		options imagemanager.ResizeOptions
+		valid   bool
+	}{
+		{"w:200", imagemanager.WidthOptions(200), true},
+		{"w:200,h:100,m:fill,g:north,f:jpeg,q:80", imagemanager.ResizeOptions{
+			Width:   200,
+			Height:  100,
+			Mode:    imagemanager.ModeFill,
+			Gravity: imagemanager.GravityNorth,
+			Format:  imagemanager.FormatJPEG,
+			Quality: 80,
+		}, true},
+		{"w:50,bg:ffffff,fi:box", imagemanager.ResizeOptions{Width: 50, Background: "ffffff", Filter: "box"}, true},
+		//значения по умолчанию не сохраняються, как и у ресайзов
+		{"w:200,m:fit,g:center", imagemanager.WidthOptions(200), true},
+		{"c:1:2:30:40", imagemanager.ResizeOptions{Crop: &imagemanager.CropRect{X: 1, Y: 2, Width: 30, Height: 40}}, true},
+		{"r:90,fl:vertical", imagemanager.ResizeOptions{Rotate: 90, Flip: imagemanager.FlipVertical}, true},
+		{"r:-45.5", imagemanager.ResizeOptions{Rotate: -45.5}, true},
+		//проверка значений делаеться позже в Validate, тут только разбор
+		{"q:500,fl:diagonal", imagemanager.ResizeOptions{Quality: 500, Flip: "diagonal"}, true},
+		{"c:1:2:30", imagemanager.ResizeOptions{}, false},
+		{"c:-1:2:30:40", imagemanager.ResizeOptions{}, false},
+		{"w:200,w:100", imagemanager.ResizeOptions{}, false},
+		{"w:-1", imagemanager.ResizeOptions{}, false},
+		{"w", imagemanager.ResizeOptions{}, false},
+		{"w:", imagemanager.ResizeOptions{}, false},
+		{"w:1:2", imagemanager.ResizeOptions{}, false},
+		{"r:left", imagemanager.ResizeOptions{}, false},
+		{"zoom:2", imagemanager.ResizeOptions{}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			options, err := parseTransformOptions(test.value)
+			if (err == nil) != test.valid {
+				t.Fatalf("parseTransformOptions() = %v, valid %v", err, test.valid)
+			}
+			if err != nil {
+				if !errors.Is(err, imagemanager.ErrInvalidOptions) {
+					t.Fatalf("error %v is not %v", err, imagemanager.ErrInvalidOptions)
+				}
+				return
+			}
+			if !reflect.DeepEqual(options, test.options) {
+				t.Fatalf("parseTransformOptions() = %+v, want %+v", options, test.options)
+			}
+		})
+	}
+}
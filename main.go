@@ -6,14 +6,17 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/go-openapi/loads"
 	"github.com/op/go-logging"
-	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"github.com/xan-mortum/apimediaservice/components/storage"
+	"github.com/xan-mortum/apimediaservice/components/urlsign"
+	"github.com/xan-mortum/apimediaservice/components/webhook"
 	"github.com/xan-mortum/apimediaservice/gen/restapi"
 	"github.com/xan-mortum/apimediaservice/gen/restapi/operations"
 	"github.com/xan-mortum/apimediaservice/handlers"
 	"github.com/xan-mortum/apimediaservice/processors"
 	"github.com/xan-mortum/apimediaservice/repositories"
 	"os"
+	"time"
 )
 
 const Port = 8085
@@ -22,6 +25,56 @@ const ID = "ID"
 const Secret = "Secret"
 const Bucket = "Bucket"
 
+//где хранить файлы: storage.DriverS3, storage.DriverFilesystem или storage.DriverMemory
+//для запуска на локальной машине без AWS достаточно поставить storage.DriverFilesystem
+const StorageDriver = storage.DriverS3
+const StorageDir = "./storage/"
+const StorageBaseURL = ""
+
+//какую базу использовать: repositories.DriverLevelDB, repositories.DriverSQLite или repositories.DriverMemory
+//DatabasePath это папка для leveldb или файл для sqlite
+const DatabaseDriver = repositories.DriverLevelDB
+const DatabasePath = "db"
+
+//фильтр ресайза когда в запросе он не указан
+//nearest и bilinear быстрее, lanczos3 медленнее но дает лучшую картинку
+const ImageDefaultFilter = imagemanager.FilterLanczos3
+
+//какие метаданные оставлять в ресайзах: strip - никаких, copyright - только icc профиль и копирайт, keep - все
+//если ImageSanitizeOriginals включен, по этой же политике чистяться оригиналы при загрузке (тег Orientation в них остаеться)
+const ImageMetadataPolicy = imagemanager.MetadataStrip
+const ImageSanitizeOriginals = true
+
+//сколько задач v2 обрабатываеться параллельно и сколько может ждать в очереди
+//ProcessorLeaseDuration это время через которое задачу упавшего воркера подберет другой
+const ProcessorWorkers = 4
+const ProcessorQueueSize = 100
+const ProcessorLeaseDuration = time.Minute
+
+//сколько раз повторять задачу при временных ошибках и с какими задержками
+const ProcessorMaxAttempts = 5
+const ProcessorInitialBackoff = time.Second
+const ProcessorMaxBackoff = 5 * time.Minute
+
+//вебхуки подписываются ключом WebhookSecret, получатель проверяет заголовок X-Signature
+//недоставленный вебхук повторяеться WebhookMaxAttempts раз с растущей задержкой
+const WebhookSecret = "WebhookSecret"
+const WebhookTimeout = 10 * time.Second
+const WebhookMaxAttempts = 5
+const WebhookInitialBackoff = time.Second
+const WebhookMaxBackoff = time.Minute
+
+//уведомления на localhost и адреса внутренних сетей запрещены, разрешать только для локального запуска
+const WebhookAllowPrivateNetworks = false
+
+//ссылки /img подписываються ключом ImageURLKey с солью ImageURLSalt, без подписи ресайз на лету не делаеться
+//пустой ImageURLKey отключает проверку, так можно только если сервис не виден снаружи
+const ImageURLKey = "ImageURLKey"
+const ImageURLSalt = "ImageURLSalt"
+
+//сколько клиенты могут не перепроверять файлы отданные через /v2/download
+const DownloadMaxAge = time.Hour
+
 var log = logging.MustGetLogger("apimediaservice")
 var format = logging.MustStringFormatter(
 	`%{color}%{time:15:04:05.000} %{shortfunc} ▶ %{level:.4s} %{id:03x}%{color:reset} %{message}`,
@@ -42,17 +95,15 @@ func main() {
 
 	logging.SetBackend(backend1Leveled, backend2Formatter)
 
-	//первая попавшаяся под руку база которую не надо отдельно устанавливать
-	//для того что бы начать использовать другую базу нужно будет изменить репозитории
-	//и исползование репозиториев в коде
-	//на то что бы инкапсулировать это нужно потратить довольно много времени
-	db, err := leveldb.OpenFile("db", nil)
+	//все базы встроенные и не требуют отдельной установки
+	//хендлеры и процессор работают с интерфейсами репозиториев, так что база меняеться только константой DatabaseDriver
+	repos, err := repositories.NewRepositories(repositories.NewConfig(DatabaseDriver, DatabasePath))
 	if err != nil {
 		log.Debug("open db")
 		log.Fatal(err)
 	}
 	defer func() {
-		err := db.Close()
+		err := repos.Close()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -71,29 +122,44 @@ func main() {
 		}
 	}()
 
-	imageManagerConfig := imagemanager.Config{TmpDir: "./tmp/"}
+	imageManagerConfig := imagemanager.NewConfig("./tmp/", ImageDefaultFilter, ImageMetadataPolicy, ImageSanitizeOriginals)
 	imageManager := imagemanager.NewImageManager(imageManagerConfig)
 
-	sess, err := session.NewSession(
-		&aws.Config{
-			Region:      aws.String(Region),
-			Credentials: credentials.NewStaticCredentials(ID, Secret, ""),
-		},
-	)
+	var storageConfig storage.Config
+	switch StorageDriver {
+	case storage.DriverS3:
+		sess, err := session.NewSession(
+			&aws.Config{
+				Region:      aws.String(Region),
+				Credentials: credentials.NewStaticCredentials(ID, Secret, ""),
+			},
+		)
+		if err != nil {
+			log.Fatal(err)
+		}
+		storageConfig = storage.NewS3Config(sess, Bucket)
+	case storage.DriverFilesystem:
+		storageConfig = storage.NewFilesystemConfig(StorageDir, StorageBaseURL)
+	default:
+		storageConfig = storage.NewMemoryConfig()
+	}
+	blobStore, err := storage.NewBlobStore(storageConfig)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	userImageRepository := repositories.NewUserImageRepository(db)
-	imageRepository := repositories.NewImageRepository(db)
-	resizeRepository := repositories.NewResizeRepository(db)
-	taskRepository := repositories.NewTaskRepository(db)
+	userImageRepository := repos.UserImageRepository
+	imageRepository := repos.ImageRepository
+	resizeRepository := repos.ResizeRepository
+	taskRepository := repos.TaskRepository
+	deliveryRepository := repos.DeliveryRepository
+	presetRepository := repos.PresetRepository
 
 	//тут храняться хандлеры которых не должно быть вообще. то есть, созданные только для этого
 	mockHandler := handlers.NewMockHandler(
 		log,
 		imageManager,
-		sess,
+		blobStore,
 		userImageRepository,
 		imageRepository,
 	)
@@ -121,14 +187,35 @@ func main() {
 	//token - стока.
 	//resize - число.
 	//file - uuid файла. его можно получить в ответе вызова http://localhost:8085/v1/files?token={token}
+	//вместо resize и остальных параметров можно передать preset - имя пресета из /v2/presets
+	//
+	//у всех ресайзов (v1 и v2) есть необязательные параметры:
+	//height - высота. если задана только одна сторона, вторая считаеться пропорционально (resize=0 значит только по высоте)
+	//mode - как вписывать в рамку width x height: fit (по умолчанию), fill (с обрезкой), stretch или pad (с заливкой)
+	//gravity - к какому краю прижимать при обрезке и заливке: center, north, south, east, west, northeast и тд
+	//background - цвет заливки для pad, например ffffff или ffffff00 для прозрачного
+	//format - формат результата: jpeg, png, gif, bmp или tiff. по умолчанию как у исходника
+	//filter - фильтр ресайза: nearest, bilinear, bicubic, mitchell, lanczos2 или lanczos3. по умолчанию ImageDefaultFilter
+	//quality - качество jpeg от 1 до 100
+	//compression - сжатие png: default, none, speed или best
+	//colors и dither - размер палитры gif от 1 до 256 и дизеринг (по умолчанию включен)
+	//first_frame - из анимированного gif взять только первый кадр. без него анимация сохраняеться, если результат тоже gif
+	//jpeg с телефонов поворачиваються по exif тегу Orientation до ресайза, в ресайзах этого тега уже нет
+	//
+	//в /v2/resize и /v1/resize_exists после ресайза можно поправить цвет и резкость:
+	//brightness, contrast, saturation - от -100 до 100 процентов, gamma - от 0.1 до 10 (больше 1 светлее)
+	//grayscale, sepia - перевести в серый или сепию
+	//blur - размытие по гауссу, радиус в пикселях результата
+	//sharpen - резкость нерезким маскированием sigma,amount,threshold, например 1,1.5 или 0.8,1,3
+	//порядок всегда такой: яркость, контраст, гамма, насыщенность, серый, сепия, размытие, резкость
 	synchronousHandler := handlers.NewSynchronousHandler(
 		log,
 		imageManager,
-		sess,
+		blobStore,
 		userImageRepository,
 		imageRepository,
 		resizeRepository,
-		Bucket,
+		presetRepository,
 	)
 
 	api.TokenHandler = operations.TokenHandlerFunc(mockHandler.TokenHandler)
@@ -140,14 +227,25 @@ func main() {
 	//основная работа по манипуляциям с фото переложенна на этот процессор
 	imageProcessor := processors.NewImageProcessor(
 		log,
+		processors.NewConfig(
+			ProcessorWorkers,
+			ProcessorQueueSize,
+			ProcessorLeaseDuration,
+			processors.NewRetryPolicy(ProcessorMaxAttempts, ProcessorInitialBackoff, ProcessorMaxBackoff),
+			processors.NewRetryPolicy(WebhookMaxAttempts, WebhookInitialBackoff, WebhookMaxBackoff),
+		),
 		taskRepository,
 		resizeRepository,
 		imageRepository,
-		sess,
+		deliveryRepository,
+		blobStore,
 		imageManager,
-		Bucket,
+		webhook.NewClient(webhook.NewConfig(WebhookSecret, WebhookTimeout, WebhookAllowPrivateNetworks)),
 	)
-	imageProcessor.Start()
+	err = imageProcessor.Start()
+	if err != nil {
+		log.Fatal(err)
+	}
 	defer imageProcessor.Stop()
 
 	//POST http://localhost:8085/v2/upload?token={token}- загрузка файла на сервер
@@ -163,22 +261,139 @@ func main() {
 	//token - сторка
 	//file - строка которую вернул upload
 	//resize - число
+	//sizes - несколько ширин через запятую, например 320,640,1280. оригинал скачиваеться один раз на все размеры
+	//нужно передать resize или sizes, результаты по каждому размеру приходят в sizes ответа /v2/result
+	//до ресайза картинку можно обрезать, повернуть и отразить, именно в таком порядке:
+	//crop - прямоугольник x,y,width,height в пикселях оригинала
+	//rotate - угол по часовой стрелке в градусах. если он не кратен 90, углы заливаються цветом background
+	//flip - horizontal, vertical или both
+	//если есть хоть одна из этих операций, resize и sizes не обязательны
+	//pipeline - вместо всех параметров выше можно передать json массив операций, они выполняються по порядку
+	//операции: crop (x, y, width, height), rotate (angle, background), flip (direction),
+	//resize (width, height, mode, gravity, background, filter), adjust (brightness, contrast, gamma, saturation, grayscale, sepia),
+	//blur (sigma), sharpen (sigma, amount, threshold), watermark, encode (format, quality, compression, colors, noDither, firstFrame)
+	//encode может быть только последней, например
+	//[{"op":"crop","x":0,"y":0,"width":500,"height":500},{"op":"rotate","angle":90},{"op":"resize","width":200},{"op":"encode","format":"jpeg","quality":80}]
+	//если такой же конвеер для этой картинки уже выполняли, задача сразу завершаеться с готовым результатом
+	//водяной знак рисуеться поверх результата последним. watermark - id загруженной картинки (например логотипа),
+	//или watermark_text - текст встроенным шрифтом Go Bold цветом watermark_color
+	//watermark_gravity - угол или сторона, по умолчанию southeast, watermark_margin - отступ от краев в пикселях
+	//watermark_scale - ширина знака относительно ширины результата (по умолчанию 0.25), watermark_opacity - от 0 до 1
+	//watermark_tile - замостить знаком всю картинку
+	//в конвеере это операция watermark (image, text, color, gravity, scale, opacity, margin, tile)
 	//
 	//http://localhost:8085/v2/result?token={token}&execution={uuid}
 	//получаем результат
 	//execution - это uuid задачи который возвращал предыдущий вызов
+	//status - in_progress пока задача в очереди, выполняеться или ждет повтора, потом done, error, dead или cancelled
+	//error - ошибка последней неудачной попытки, attempts - сколько раз задачу запускали
 	//http://localhost:8085/v2/files?token={token} - получаем список файлов по токену
+	//
+	//задача которая падает с временной ошибкой повторяеться с растущей задержкой
+	//количество попыток можно передать в /v2/resize параметром max_attempts
+	//после последней неудачной попытки задача получает статус dead
+	//http://localhost:8085/v2/dead?token={token} - список таких задач
+	//POST http://localhost:8085/v2/requeue - возвращает задачу в очередь
+	//параметры:
+	//token - строка
+	//execution - uuid задачи
+	//
+	//если в /v2/resize передать callback_url, то когда задача завершиться (done, error или dead)
+	//на этот адрес придет POST с задачей в json и подписью HMAC-SHA256 тела в заголовке X-Signature
+	//http://localhost:8085/v2/deliveries?token={token}&execution={uuid} - журнал попыток доставки
+	//
+	//http://localhost:8085/v2/events?token={token}&execution={uuid} - события задач в формате Server-Sent Events
+	//execution не обязательный, без него приходят события всех задач токена
+	//события: queued, downloading, resizing, uploading, done, error, dead, cancelled
+	//поток по одной задаче закрываеться после done, error, dead или cancelled
+	//
+	//DELETE http://localhost:8085/v2/result?token={token}&execution={uuid} - отменяет задачу
+	//задача в очереди не запуститься, выполняемая остановиться, а уже загруженный результат удалиться
+	//задача получает статус cancelled
+	//
+	//http://localhost:8085/v2/images/{id}/metadata - размеры, формат, цветовая модель, количество кадров, размер файла
+	//и exif (камера, время съемки, gps) картинки загруженной через /v2/upload или /v1/resize
+	//считаеться при загрузке из того что легло в хранилище, так что при очистке оригиналов gps там не будет
 	asynchronousHandler := handlers.NewAsynchronousHandler(
 		log,
 		imageProcessor,
 		userImageRepository,
 		resizeRepository,
+		imageRepository,
+		presetRepository,
 	)
 
 	api.UploadHandler = operations.UploadHandlerFunc(mockHandler.UploadHandler)
 	api.V2resizeHandler = operations.V2resizeHandlerFunc(asynchronousHandler.V2resizeHandler)
 	api.ResultHandler = operations.ResultHandlerFunc(asynchronousHandler.ResultHandler)
 	api.V2filesHandler = operations.V2filesHandlerFunc(asynchronousHandler.V2filesHandler)
+	api.DeadHandler = operations.DeadHandlerFunc(asynchronousHandler.DeadHandler)
+	api.RequeueHandler = operations.RequeueHandlerFunc(asynchronousHandler.RequeueHandler)
+	api.CancelHandler = operations.CancelHandlerFunc(asynchronousHandler.CancelHandler)
+	api.DeliveriesHandler = operations.DeliveriesHandlerFunc(asynchronousHandler.DeliveriesHandler)
+	api.EventsHandler = operations.EventsHandlerFunc(asynchronousHandler.EventsHandler)
+	api.ImageMetadataHandler = operations.ImageMetadataHandlerFunc(asynchronousHandler.ImageMetadataHandler)
+
+	//пресеты - именованные наборы параметров ресайза, например avatar-small, что бы не писать размеры в каждом клиенте
+	//GET http://localhost:8085/v2/presets - список пресетов
+	//GET http://localhost:8085/v2/presets/{name} - один пресет
+	//PUT http://localhost:8085/v2/presets/{name} - создает или заменяет пресет
+	//параметры формы такие же как у /v2/resize: resize, height, mode, gravity, background, format, filter,
+	//quality, compression, colors, dither, first_frame, crop, rotate, flip, brightness, contrast, gamma, saturation,
+	//grayscale, sepia, blur, sharpen и параметры водяного знака
+	//пресет с картинкой водяного знака работает только в /v2/resize, картинку скачивает воркер
+	//DELETE http://localhost:8085/v2/presets/{name} - удаляет пресет
+	//имя пресета передаеться в /v2/resize и /v1/resize_exists параметром preset вместо остальных параметров ресайза
+	//в записи о ресайзе сохраняеться имя пресета и сами параметры, так что изменение пресета старые ресайзы не трогает
+	presetHandler := handlers.NewPresetHandler(
+		log,
+		presetRepository,
+	)
+
+	api.PresetsHandler = operations.PresetsHandlerFunc(presetHandler.PresetsHandler)
+	api.PresetHandler = operations.PresetHandlerFunc(presetHandler.PresetHandler)
+	api.PutPresetHandler = operations.PutPresetHandlerFunc(presetHandler.PutPresetHandler)
+	api.DeletePresetHandler = operations.DeletePresetHandlerFunc(presetHandler.DeletePresetHandler)
+
+	//ресайзы на лету, без задачи. параметры прямо в ссылке, картинка сразу в ответе
+	//GET http://localhost:8085/img/{options}/{image}, например http://localhost:8085/img/w:200,h:200,m:fill,f:jpeg/a.png
+	//options - параметры через запятую, у каждого ключ и значения через двоеточие:
+	//w - ширина, h - высота, m - режим (fit, fill, stretch, pad), g - gravity, bg - фон, fi - фильтр,
+	//c - обрезка c:x:y:width:height, r - поворот в градусах, fl - отражение, f - формат, q - качество jpeg
+	//первый запрос делает ресайз и сохраняет его в хранилище и в ресайзы картинки, следующие отдают готовый файл
+	//имя ресайза такое же как у /v2/resize с теми же параметрами, так что ресайзы сделанные задачами тоже подхватываються
+	//ссылка должна быть подписана: ?signature=<подпись>, и если нужен срок жизни, &expires=<unix время>
+	//подпись - base64url без паддинга от HMAC-SHA256 ключом ImageURLKey полей ImageURLSalt, options, image и expires
+	//(0 если срока нет), перед каждым полем его длина 8 байтами big endian. проверка идет до декодирования картинки
+	//на go ссылки собирает client.NewImageURLBuilder(адрес сервиса, ключ, соль).URL(options, image)
+	transformHandler := handlers.NewTransformHandler(
+		log,
+		imageManager,
+		blobStore,
+		imageRepository,
+		resizeRepository,
+		urlsign.NewConfig(ImageURLKey, ImageURLSalt),
+	)
+
+	api.TransformHandler = operations.TransformHandlerFunc(transformHandler.TransformHandler)
+
+	//GET http://localhost:8085/v2/download/{key}?token={token} - отдает оригинал или ресайз из хранилища, бакет при этом может быть закрытым
+	//key - имя файла, как в fileName картинки или resizedFileName ресайза. файл должен быть картинкой пользователя или ее ресайзом
+	//вместо токена можно передать подпись ?signature=<подпись>&expires=<unix время>, ее собирает
+	//client.NewImageURLBuilder(адрес сервиса, ключ, соль).DownloadURL(key). на чужие и неизвестные ключи ответ 404
+	//в ответе Content-Type, Content-Length, ETag по содержимому, Last-Modified и Cache-Control
+	//на If-None-Match и If-Modified-Since отвечает 304, если файл не менялся, на Range - 206 с куском файла
+	downloadHandler := handlers.NewDownloadHandler(
+		log,
+		blobStore,
+		userImageRepository,
+		resizeRepository,
+		urlsign.NewConfig(ImageURLKey, ImageURLSalt),
+		DownloadMaxAge,
+	)
+
+	api.DownloadHandler = operations.DownloadHandlerFunc(downloadHandler.DownloadHandler)
+	api.TextEventStreamProducer = handlers.EventStreamProducer()
 
 	server.Port = Port
 	err = server.Serve()
@@ -0,0 +1,104 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"github.com/xan-mortum/apimediaservice/components/storage"
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"io"
+	"time"
+)
+
+var ErrTaskNotCancellable = errors.New("only queued or running tasks can be cancelled")
+
+//отменяет задачу пользователя
+//задача в очереди просто не будет захвачена воркером, у выполняемой отменяеться контекст
+//если задачу выполняет другой экземпляр сервиса, он узнает об отмене при следующем продлении аренды
+func (ip *ImageProcessor) CancelTask(taskId string, token string) error {
+	dbTask, err := ip.taskRepository.Get(taskId)
+	if err != nil {
+		return err
+	}
+	if dbTask == nil || dbTask.Token != token {
+		return ErrTaskNotFound
+	}
+	if dbTask.Status != repositories.StatusInProgress {
+		return ErrTaskNotCancellable
+	}
+
+	dbTask.Status = repositories.StatusCancelled
+	dbTask.NextAttemptAt = time.Time{}
+	dbTask.LeaseOwner = ""
+	dbTask.LeaseExpires = time.Time{}
+	//задача могла завершиться между чтением и записью, тогда отменять уже нечего
+	saved, err := ip.taskRepository.PutIfStatus(*dbTask, taskId, repositories.StatusInProgress)
+	if err != nil {
+		return err
+	}
+	if !saved {
+		return ErrTaskNotCancellable
+	}
+
+	ip.runningMx.Lock()
+	cancel, ok := ip.running[taskId]
+	ip.runningMx.Unlock()
+	if ok {
+		cancel()
+	}
+	ip.emit(taskId, token, EventCancelled, nil)
+	return nil
+}
+
+func (ip *ImageProcessor) setRunning(taskId string, cancel context.CancelFunc) {
+	ip.runningMx.Lock()
+	defer ip.runningMx.Unlock()
+	ip.running[taskId] = cancel
+}
+
+func (ip *ImageProcessor) unsetRunning(taskId string) {
+	ip.runningMx.Lock()
+	defer ip.runningMx.Unlock()
+	delete(ip.running, taskId)
+}
+
+//задачу отменили, пока она выполнялась
+func (ip *ImageProcessor) isCancelled(ctx context.Context, taskId string) (bool, error) {
+	if ctx.Err() != nil {
+		return true, nil
+	}
+	dbTask, err := ip.taskRepository.Get(taskId)
+	if err != nil {
+		return false, err
+	}
+	return dbTask == nil || dbTask.Status != repositories.StatusInProgress, nil
+}
+
+//удаляет из хранилища файл который отмененная задача успела загрузить
+func (ip *ImageProcessor) removeObject(key string) {
+	err := ip.storage.Delete(key)
+	if err != nil && err != storage.ErrNotFound {
+		ip.Logger.Warning(err)
+	}
+}
+
+//прерывает чтение как только контекст отменен
+//так скачивание и загрузка в хранилище останавливаються без поддержки контекста в BlobStore
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func newContextReader(ctx context.Context, reader io.Reader) *contextReader {
+	return &contextReader{
+		ctx:    ctx,
+		reader: reader,
+	}
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	err := r.ctx.Err()
+	if err != nil {
+		return 0, err
+	}
+	return r.reader.Read(p)
+}
@@ -0,0 +1,158 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"github.com/xan-mortum/apimediaservice/components/storage"
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestCancelTask(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		taskId  string
+		token   string
+		running bool
+		err     error
+		want    string
+	}{
+		{"queued", repositories.StatusInProgress, "task", "token", false, nil, repositories.StatusCancelled},
+		{"running", repositories.StatusInProgress, "task", "token", true, nil, repositories.StatusCancelled},
+		{"done", repositories.StatusDone, "task", "token", false, ErrTaskNotCancellable, repositories.StatusDone},
+		{"dead", repositories.StatusDead, "task", "token", false, ErrTaskNotCancellable, repositories.StatusDead},
+		{"other token", repositories.StatusInProgress, "task", "other", false, ErrTaskNotFound, repositories.StatusInProgress},
+		{"missing task", repositories.StatusInProgress, "missing", "token", false, ErrTaskNotFound, repositories.StatusInProgress},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repos := newTestRepositories(t)
+			ip, _ := newTestProcessor(t, repos, NewConfig(1, 10, time.Minute, RetryPolicy{}, RetryPolicy{}))
+			err := repos.TaskRepository.Put(repositories.Task{
+				Token:         "token",
+				Status:        test.status,
+				LeaseOwner:    "self/1",
+				LeaseExpires:  time.Now().Add(time.Minute),
+				NextAttemptAt: time.Now().Add(time.Minute),
+			}, "task")
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if test.running {
+				ip.setRunning("task", cancel)
+			}
+
+			err = ip.CancelTask(test.taskId, test.token)
+			if err != test.err {
+				t.Fatalf("error %v, want %v", err, test.err)
+			}
+			saved, err := repos.TaskRepository.Get("task")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if saved.Status != test.want {
+				t.Fatalf("status %s, want %s", saved.Status, test.want)
+			}
+			if test.want == repositories.StatusCancelled && (saved.LeaseOwner != "" || !saved.NextAttemptAt.IsZero()) {
+				t.Fatalf("cancelled task %+v", saved)
+			}
+			//контекст выполняемой задачи отменяеться сразу
+			if (ctx.Err() != nil) != test.running {
+				t.Fatalf("context error %v, running %v", ctx.Err(), test.running)
+			}
+		})
+	}
+}
+
+//отмененную в очереди задачу воркер не берет
+func TestCancelledTaskIsNotRun(t *testing.T) {
+	repos := newTestRepositories(t)
+	ip, store := newTestProcessor(t, repos, NewConfig(1, 10, time.Minute, RetryPolicy{}, RetryPolicy{}))
+	task := ResizeTask{UUID: "task", Token: "token", Image: "a.png", Resize: 10}
+	err := ip.AddTask(task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ip.CancelTask("task", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	im := ip.im
+	ip.runLeased(&im, "self/1", <-ip.getTasksIn)
+
+	saved, err := repos.TaskRepository.Get("task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Status != repositories.StatusCancelled || saved.Attempts != 0 {
+		t.Fatalf("task %+v", saved)
+	}
+	objects, err := store.List("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 1 {
+		t.Fatalf("objects %+v, want only the original", objects)
+	}
+}
+
+//задачу отменили пока она выполнялась, загруженный ресайз удаляеться и задача не становиться done
+func TestCompleteCancelledTask(t *testing.T) {
+	repos := newTestRepositories(t)
+	ip, store := newTestProcessor(t, repos, NewConfig(1, 10, time.Minute, RetryPolicy{}, RetryPolicy{}))
+	err := repos.TaskRepository.Put(repositories.Task{Token: "token", Status: repositories.StatusCancelled}, "task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"thumb10_a.png", "thumb20_a.png"} {
+		_, err = store.Put(key, bytes.NewReader([]byte("thumb")))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	uploaded := repositories.ImageResizeInfo{ResizedFileName: "thumb10_a.png", ResizeParam: 10}
+	//такой же ресайз уже был в хранилище до задачи, он остаеться
+	existed := repositories.ImageResizeInfo{ResizedFileName: "thumb20_a.png", ResizeParam: 20}
+	err = ip.completeTask(
+		ResizeTask{UUID: "task", Token: "token", Image: "a.png", Sizes: []uint{10, 20}},
+		[]repositories.ImageResizeInfo{uploaded, existed},
+		[]uploadedThumb{{info: uploaded}, {info: existed, existed: true}},
+	)
+	if err != ErrTaskNotInProgress {
+		t.Fatalf("error %v, want %v", err, ErrTaskNotInProgress)
+	}
+	_, err = store.Stat("thumb10_a.png")
+	if err != storage.ErrNotFound {
+		t.Fatalf("uploaded thumb: %v, want %v", err, storage.ErrNotFound)
+	}
+	_, err = store.Stat("thumb20_a.png")
+	if err != nil {
+		t.Fatalf("existing thumb: %v", err)
+	}
+	resizes, err := repos.ResizeRepository.Get("a.png")
+	if err != nil || len(resizes) != 0 {
+		t.Fatalf("resizes %+v %v", resizes, err)
+	}
+}
+
+func TestContextReader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	reader := newContextReader(ctx, bytes.NewReader([]byte("data")))
+	buffer := make([]byte, 2)
+	n, err := reader.Read(buffer)
+	if n != 2 || err != nil {
+		t.Fatalf("read %d %v", n, err)
+	}
+	cancel()
+	_, err = ioutil.ReadAll(reader)
+	if err != context.Canceled {
+		t.Fatalf("read after cancel: %v, want %v", err, context.Canceled)
+	}
+}
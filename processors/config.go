@@ -0,0 +1,29 @@
+package processors
+
+import "time"
+
+const DefaultLeaseDuration = time.Minute
+
+type Config struct {
+	//сколько задач обрабатываеться одновременно
+	Workers int
+	//сколько задач может ждать в очереди. когда очередь заполнена AddTask возвращает ErrQueueFull и задача не сохраняеться
+	QueueSize int
+	//на сколько воркер захватывает задачу. пока воркер жив аренда продлеваеться
+	//если процесс упал, задачу после истечения аренды подберет этот же или другой экземпляр сервиса
+	LeaseDuration time.Duration
+	//политика повторов для задач у которых она не указана
+	Retry RetryPolicy
+	//политика повторов доставки вебхуков
+	Webhook RetryPolicy
+}
+
+func NewConfig(workers int, queueSize int, leaseDuration time.Duration, retry RetryPolicy, webhook RetryPolicy) Config {
+	return Config{
+		Workers:       workers,
+		QueueSize:     queueSize,
+		LeaseDuration: leaseDuration,
+		Retry:         retry,
+		Webhook:       webhook,
+	}
+}
@@ -0,0 +1,142 @@
+package processors
+
+import (
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"sync"
+	"time"
+)
+
+//этапы задачи о которых сообщаеться подписчикам
+const EventQueued = "queued"
+const EventDownloading = "downloading"
+const EventResizing = "resizing"
+const EventUploading = "uploading"
+const EventDone = "done"
+const EventError = "error"
+const EventDead = "dead"
+const EventCancelled = "cancelled"
+
+//сколько событий может ждать медленный подписчик. лишние события ему не доставляються
+const eventBufferSize = 64
+
+type TaskEvent struct {
+	Execution string    `json:"execution"`
+	Stage     string    `json:"stage"`
+	Error     string    `json:"error,omitempty"`
+	Time      time.Time `json:"time"`
+	token     string
+}
+
+//раздает события задач подписчикам
+//события есть только у задач которые выполняет этот экземпляр сервиса
+type eventBus struct {
+	mx          sync.Mutex
+	lastId      int
+	subscribers map[int]*subscriber
+}
+
+type subscriber struct {
+	token     string
+	execution string
+	events    chan TaskEvent
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{
+		subscribers: make(map[int]*subscriber),
+	}
+}
+
+//подписывает на события задач токена, или только одной задачи если execution не пустой
+//функцию отписки нужно вызвать когда события больше не нужны
+func (b *eventBus) subscribe(token string, execution string) (chan TaskEvent, func()) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	b.lastId++
+	id := b.lastId
+	s := &subscriber{
+		token:     token,
+		execution: execution,
+		events:    make(chan TaskEvent, eventBufferSize),
+	}
+	b.subscribers[id] = s
+
+	var once sync.Once
+	return s.events, func() {
+		once.Do(func() {
+			b.mx.Lock()
+			defer b.mx.Unlock()
+			delete(b.subscribers, id)
+		})
+	}
+}
+
+//воркер не должен ждать подписчиков, поэтому если буфер подписчика заполнен событие пропускаеться
+func (b *eventBus) publish(event TaskEvent) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	for _, s := range b.subscribers {
+		if s.token != event.token {
+			continue
+		}
+		if s.execution != "" && s.execution != event.Execution {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+		}
+	}
+}
+
+//после этого события задача больше не меняеться
+func (e TaskEvent) Final() bool {
+	return e.Stage == EventDone || e.Stage == EventError || e.Stage == EventDead || e.Stage == EventCancelled
+}
+
+//подписка на события задач пользователя
+//если задача execution уже завершилась, первым придет событие с ее итоговым статусом
+func (ip *ImageProcessor) SubscribeEvents(token string, execution string) (<-chan TaskEvent, func(), error) {
+	events, unsubscribe := ip.events.subscribe(token, execution)
+	if execution == "" {
+		return events, unsubscribe, nil
+	}
+
+	//подписываемся до проверки статуса, иначе можно пропустить завершение задачи между ними
+	dbTask, err := ip.taskRepository.Get(execution)
+	if err != nil {
+		unsubscribe()
+		return nil, nil, err
+	}
+	if dbTask == nil || dbTask.Token != token {
+		unsubscribe()
+		return nil, nil, ErrTaskNotFound
+	}
+	//названия итоговых статусов задачи совпадают с названиями событий
+	if dbTask.Status != repositories.StatusInProgress {
+		select {
+		case events <- TaskEvent{
+			Execution: execution,
+			Stage:     dbTask.Status,
+			Error:     dbTask.Error,
+			Time:      time.Now(),
+			token:     token,
+		}:
+		default:
+		}
+	}
+	return events, unsubscribe, nil
+}
+
+func (ip *ImageProcessor) emit(taskId string, token string, stage string, err error) {
+	event := TaskEvent{
+		Execution: taskId,
+		Stage:     stage,
+		Time:      time.Now(),
+		token:     token,
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	ip.events.publish(event)
+}
@@ -0,0 +1,117 @@
+package processors
+
+import (
+	"errors"
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"testing"
+	"time"
+)
+
+func TestEventBusFilters(t *testing.T) {
+	tests := []struct {
+		name      string
+		token     string
+		execution string
+		delivered bool
+	}{
+		{"all tasks of the token", "token", "", true},
+		{"this task", "token", "task", true},
+		{"other task", "token", "other", false},
+		{"other token", "other", "", false},
+		{"same task with other token", "other", "task", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bus := newEventBus()
+			events, unsubscribe := bus.subscribe(test.token, test.execution)
+			defer unsubscribe()
+
+			bus.publish(TaskEvent{Execution: "task", Stage: EventResizing, token: "token"})
+
+			select {
+			case event := <-events:
+				if !test.delivered {
+					t.Fatalf("event %+v is delivered", event)
+				}
+				if event.Execution != "task" || event.Stage != EventResizing {
+					t.Fatalf("event %+v", event)
+				}
+			default:
+				if test.delivered {
+					t.Fatal("event is not delivered")
+				}
+			}
+		})
+	}
+}
+
+func TestEventBusSlowSubscriber(t *testing.T) {
+	bus := newEventBus()
+	events, unsubscribe := bus.subscribe("token", "")
+
+	//события сверх буфера пропускаються, publish не ждет подписчика
+	for i := 0; i < eventBufferSize+10; i++ {
+		bus.publish(TaskEvent{Execution: "task", Stage: EventQueued, token: "token"})
+	}
+	if len(events) != eventBufferSize {
+		t.Fatalf("%d events buffered, want %d", len(events), eventBufferSize)
+	}
+
+	unsubscribe()
+	//повторная отписка ничего не ломает
+	unsubscribe()
+	for len(events) > 0 {
+		<-events
+	}
+	bus.publish(TaskEvent{Execution: "task", Stage: EventDone, token: "token"})
+	if len(events) != 0 {
+		t.Fatal("event is delivered after unsubscribe")
+	}
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		token  string
+		err    error
+		first  string
+	}{
+		{"running task", repositories.StatusInProgress, "token", nil, ""},
+		{"finished task", repositories.StatusDone, "token", nil, EventDone},
+		{"dead task", repositories.StatusDead, "token", nil, EventDead},
+		{"other token", repositories.StatusInProgress, "other", ErrTaskNotFound, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repos := newTestRepositories(t)
+			ip, _ := newTestProcessor(t, repos, NewConfig(1, 10, time.Minute, RetryPolicy{}, RetryPolicy{}))
+			err := repos.TaskRepository.Put(repositories.Task{Token: "token", Status: test.status, Error: "failed"}, "task")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			events, unsubscribe, err := ip.SubscribeEvents(test.token, "task")
+			if err != test.err {
+				t.Fatalf("error %v, want %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+			defer unsubscribe()
+
+			//у завершенной задачи первым приходит ее итоговый статус
+			if test.first != "" {
+				event := <-events
+				if event.Stage != test.first || event.Execution != "task" || event.Error != "failed" || !event.Final() {
+					t.Fatalf("first event %+v", event)
+				}
+			}
+			ip.emit("task", "token", EventUploading, errors.New("slow"))
+			event := <-events
+			if event.Stage != EventUploading || event.Error != "slow" || event.Final() {
+				t.Fatalf("event %+v", event)
+			}
+		})
+	}
+}
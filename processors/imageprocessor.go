@@ -1,197 +1,709 @@
 package processors
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/google/uuid"
 	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"github.com/xan-mortum/apimediaservice/components/storage"
+	"github.com/xan-mortum/apimediaservice/components/webhook"
 	"github.com/xan-mortum/apimediaservice/interfaces"
 	"github.com/xan-mortum/apimediaservice/repositories"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
 )
 
+var ErrQueueFull = errors.New("task queue is full, try again later")
+var ErrTaskNotFound = errors.New("task not found")
+var ErrTaskNotDead = errors.New("only dead tasks can be requeued")
+
+//задачу отменили или завершили пока она выполнялась, ее результат уже не нужен
+var ErrTaskNotInProgress = errors.New("task is no longer in progress")
+
 //штука которая асинхронно обрабатывает файлы
+//задачи разбирает пул воркеров, у каждого своя временная папка что бы они не удаляли файлы друг друга
+//очередь в памяти только ускоряет работу, источник правды это TaskRepository:
+//задачи в статусе in_progress с истекшей арендой периодически возвращаються в очередь, в том числе после перезапуска
 type ImageProcessor struct {
-	done             chan bool
-	getTasksIn       chan ResizeTask
-	Logger           interfaces.Logger
-	taskRepository   *repositories.TaskRepository
-	resizeRepository *repositories.ResizeRepository
-	imageRepository  *repositories.ImageRepository
-	s3Session        *session.Session
-	im               imagemanager.ImageManager
-	bucket           string
+	config             Config
+	owner              string
+	done               chan bool
+	wg                 sync.WaitGroup
+	getTasksIn         chan ResizeTask
+	queuedMx           sync.Mutex
+	queued             map[string]bool
+	runningMx          sync.Mutex
+	running            map[string]context.CancelFunc
+	events             *eventBus
+	Logger             interfaces.Logger
+	taskRepository     repositories.TaskRepository
+	resizeRepository   repositories.ResizeRepository
+	imageRepository    repositories.ImageRepository
+	deliveryRepository repositories.DeliveryRepository
+	storage            storage.BlobStore
+	im                 imagemanager.ImageManager
+	webhook            *webhook.Client
 }
 
 type ResizeTask struct {
-	UUID   string
-	Image  string
-	Resize uint
+	UUID   string      `json:"uuid"`
+	Token  string      `json:"token"`
+	Image  string      `json:"image"`
+	Resize uint        `json:"resize"`
+	Retry  RetryPolicy `json:"retry"`
+	//несколько ширин за одно скачивание. если пусто, используеться Resize
+	Sizes []uint `json:"sizes,omitempty"`
+	//высота, режим и остальные параметры общие для всех ширин. ширина в Options не используеться
+	Options imagemanager.ResizeOptions `json:"options"`
+	//куда отправить уведомление когда задача завершиться. пустая строка если уведомление не нужно
+	CallbackURL string `json:"callbackUrl,omitempty"`
+	//операции над картинкой по порядку. если задан, Resize, Sizes и Options не используються
+	Pipeline imagemanager.Pipeline `json:"pipeline,omitempty"`
+	//имя пресета из которого взяли Resize и Options. параметры уже подставлены, пресет нужен только для записи о ресайзе
+	Preset string `json:"preset,omitempty"`
 }
 
 func NewImageProcessor(
 	logger interfaces.Logger,
-	tr *repositories.TaskRepository,
-	rr *repositories.ResizeRepository,
-	ir *repositories.ImageRepository,
-	s3 *session.Session,
+	config Config,
+	tr repositories.TaskRepository,
+	rr repositories.ResizeRepository,
+	ir repositories.ImageRepository,
+	dr repositories.DeliveryRepository,
+	blobStore storage.BlobStore,
 	im imagemanager.ImageManager,
-	bucket string,
+	webhookClient *webhook.Client,
 ) *ImageProcessor {
+	if config.Workers < 1 {
+		config.Workers = 1
+	}
+	if config.LeaseDuration <= 0 {
+		config.LeaseDuration = DefaultLeaseDuration
+	}
+	config.Retry = config.Retry.withDefaults(NewRetryPolicy(DefaultMaxAttempts, DefaultInitialBackoff, DefaultMaxBackoff))
+	config.Webhook = config.Webhook.withDefaults(NewRetryPolicy(DefaultWebhookMaxAttempts, DefaultWebhookInitialBackoff, DefaultWebhookMaxBackoff))
 	return &ImageProcessor{
-		done:             make(chan bool),
-		getTasksIn:       make(chan ResizeTask, 100),
-		Logger:           logger,
-		taskRepository:   tr,
-		resizeRepository: rr,
-		imageRepository:  ir,
-		s3Session:        s3,
-		im:               im,
-		bucket:           bucket,
+		config:             config,
+		owner:              uuid.New().String(),
+		done:               make(chan bool),
+		getTasksIn:         make(chan ResizeTask, config.QueueSize),
+		queued:             make(map[string]bool),
+		running:            make(map[string]context.CancelFunc),
+		events:             newEventBus(),
+		Logger:             logger,
+		taskRepository:     tr,
+		resizeRepository:   rr,
+		imageRepository:    ir,
+		deliveryRepository: dr,
+		storage:            blobStore,
+		im:                 im,
+		webhook:            webhookClient,
 	}
 }
 
-func (ip *ImageProcessor) Start() {
+func (ip *ImageProcessor) Start() error {
+	for i := 1; i <= ip.config.Workers; i++ {
+		im, err := ip.newWorkerImageManager(i)
+		if err != nil {
+			return err
+		}
+		//аренда с тем же владельцем продлеваеться, поэтому владелец общий на процесс позволил бы
+		//двум воркерам одного процесса взять одну задачу
+		owner := ip.owner + "/" + strconv.Itoa(i)
+
+		ip.wg.Add(1)
+		go func() {
+			defer ip.wg.Done()
+			for {
+				select {
+				case task := <-ip.getTasksIn:
+					ip.runLeased(&im, owner, task)
+				case <-ip.done:
+					return
+				}
+			}
+		}()
+	}
+
+	//сразу после запуска подбираем задачи которые остались от прошлого запуска, потом проверяем периодически
+	ip.wg.Add(1)
 	go func() {
+		defer ip.wg.Done()
+		ticker := time.NewTicker(ip.config.LeaseDuration / 2)
+		defer ticker.Stop()
 		for {
+			ip.requeueStale()
 			select {
-			case task := <-ip.getTasksIn:
-				ip.runTusk(task)
+			case <-ticker.C:
 			case <-ip.done:
 				return
 			}
 		}
 	}()
+	return nil
 }
 
+//останавливает воркеров и ждет пока они закончат текущие задачи
 func (ip *ImageProcessor) Stop() {
-	go func() {
-		ip.done <- true
-	}()
+	close(ip.done)
+	ip.wg.Wait()
+}
+
+//папка воркера создаеться внутри временной папки общего ImageManager
+func (ip *ImageProcessor) newWorkerImageManager(worker int) (imagemanager.ImageManager, error) {
+	tmpDir := filepath.Join(ip.im.Config.TmpDir, "worker"+strconv.Itoa(worker)) + string(filepath.Separator)
+	err := os.MkdirAll(tmpDir, 0755)
+	if err != nil {
+		return imagemanager.ImageManager{}, err
+	}
+	config := ip.im.Config
+	config.TmpDir = tmpDir
+	return imagemanager.NewImageManager(config), nil
 }
 
 func (ip *ImageProcessor) AddTask(task ResizeTask) error {
-	err := ip.taskRepository.Put(repositories.Task{
-		Status: repositories.StatusInProgress,
+	task.Retry = task.Retry.withDefaults(ip.config.Retry)
+	payload, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	err = ip.taskRepository.Put(repositories.Task{
+		Token:   task.Token,
+		Status:  repositories.StatusInProgress,
+		Payload: payload,
 	}, task.UUID)
 	if err != nil {
 		return err
 	}
 
-	wait := make(chan bool)
+	if !ip.enqueue(task) {
+		//задача не попала в очередь, клиент получит ошибку и повторит запрос с новой задачей
+		//поэтому эту удаляем, иначе requeueStale позже запустит ее без ведома клиента
+		err = ip.taskRepository.Delete(task.UUID)
+		if err != nil {
+			ip.Logger.Warning(err)
+		}
+		return ErrQueueFull
+	}
+	ip.emit(task.UUID, task.Token, EventQueued, nil)
+	return nil
+}
+
+//не ждем пока освободиться место в очереди, иначе хендлер будет висеть вместе с соединением
+//задача которая уже лежит в очереди второй раз не добавляеться
+func (ip *ImageProcessor) enqueue(task ResizeTask) bool {
+	ip.queuedMx.Lock()
+	defer ip.queuedMx.Unlock()
+	if ip.queued[task.UUID] {
+		return true
+	}
+	select {
+	case ip.getTasksIn <- task:
+		ip.queued[task.UUID] = true
+		return true
+	default:
+		return false
+	}
+}
+
+func (ip *ImageProcessor) unmarkQueued(taskId string) {
+	ip.queuedMx.Lock()
+	defer ip.queuedMx.Unlock()
+	delete(ip.queued, taskId)
+}
+
+//возвращает в очередь задачи in_progress которые никто не обрабатывает
+func (ip *ImageProcessor) requeueStale() {
+	tasks, err := ip.taskRepository.GetByStatus(repositories.StatusInProgress)
+	if err != nil {
+		ip.Logger.Warning(err)
+		return
+	}
+
+	now := time.Now()
+	for _, dbTask := range tasks {
+		if dbTask.LeaseOwner != "" && dbTask.LeaseExpires.After(now) {
+			continue
+		}
+		if dbTask.NextAttemptAt.After(now) {
+			continue
+		}
+		//задачи созданные до того как стали сохраняться параметры восстановить нельзя
+		if len(dbTask.Payload) == 0 {
+			ip.handleError(errors.New("task payload is lost"), dbTask.UUID)
+			continue
+		}
+		var task ResizeTask
+		err := json.Unmarshal(dbTask.Payload, &task)
+		if err != nil {
+			ip.handleError(err, dbTask.UUID)
+			continue
+		}
+		//очередь заполнена, остальные задачи подберем при следующей проверке
+		if !ip.enqueue(task) {
+			return
+		}
+	}
+}
+
+//захватывает задачу и держит аренду пока задача выполняеться
+//если задачу уже обрабатывает кто-то другой, она пропускаеться
+func (ip *ImageProcessor) runLeased(im *imagemanager.ImageManager, owner string, task ResizeTask) {
+	acquired, err := ip.taskRepository.AcquireLease(task.UUID, owner, time.Now().Add(ip.config.LeaseDuration))
+	//отметка об очереди снимаеться только после захвата, до него аренды еще нет
+	//и requeueStale положил бы задачу в очередь второй раз
+	ip.unmarkQueued(task.UUID)
+	if err != nil {
+		ip.Logger.Warning(err)
+		return
+	}
+	if !acquired {
+		ip.Logger.Debugf("task %s is already taken", task.UUID)
+		return
+	}
+
+	//попытка считаеться с момента захвата, так задача которая роняет процесс тоже когда-нибудь закончится
+	dbTask, err := ip.taskRepository.Get(task.UUID)
+	if err != nil {
+		ip.Logger.Warning(err)
+		return
+	}
+	if dbTask == nil {
+		return
+	}
+	dbTask.Attempts++
+	saved, err := ip.taskRepository.PutIfStatus(*dbTask, task.UUID, repositories.StatusInProgress)
+	if err != nil {
+		ip.Logger.Warning(err)
+		return
+	}
+	if !saved {
+		ip.Logger.Debugf("task %s is no longer in progress", task.UUID)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ip.setRunning(task.UUID, cancel)
+	defer ip.unsetRunning(task.UUID)
+
+	stopHeartbeat := make(chan bool)
+	heartbeatDone := make(chan bool)
 	go func() {
-		ip.getTasksIn <- task
-		close(wait)
+		defer close(heartbeatDone)
+		ticker := time.NewTicker(ip.config.LeaseDuration / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				acquired, err := ip.taskRepository.AcquireLease(task.UUID, owner, time.Now().Add(ip.config.LeaseDuration))
+				if err != nil {
+					ip.Logger.Warning(err)
+					continue
+				}
+				//задачу отменили или аренду перехватил кто-то другой, дальше выполнять ее нет смысла
+				if !acquired {
+					cancel()
+				}
+			case <-stopHeartbeat:
+				return
+			}
+		}
 	}()
-	<-wait
-	return nil
+
+	err = ip.runTusk(ctx, im, task)
+
+	//аренду нужно перестать продлевать до того как задача вернеться в очередь, иначе ее никто не сможет взять
+	close(stopHeartbeat)
+	<-heartbeatDone
+
+	if err != nil && (ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, ErrTaskNotInProgress)) {
+		ip.Logger.Infof("task %s is stopped: %s", task.UUID, err)
+		return
+	}
+	if err != nil {
+		ip.handleTaskError(err, task)
+	}
 }
 
-func (ip *ImageProcessor) GetTask(taskId string) (*repositories.Task, error) {
+func (ip *ImageProcessor) GetTask(taskId string, token string) (*repositories.Task, error) {
 	dbTask, err := ip.taskRepository.Get(taskId)
 	if err != nil {
 		return nil, err
 	}
+	if dbTask == nil || dbTask.Token != token {
+		return nil, ErrTaskNotFound
+	}
 	return dbTask, nil
 }
 
-func (ip *ImageProcessor) runTusk(task ResizeTask) {
-	//скачиваем картинку с S3
-	buf := aws.NewWriteAtBuffer([]byte{})
-	downloader := s3manager.NewDownloader(ip.s3Session)
-	_, err := downloader.Download(buf, &s3.GetObjectInput{
-		Bucket: aws.String(ip.bucket),
-		Key:    aws.String(task.Image),
-	})
+//задачи которые исчерпали все попытки
+func (ip *ImageProcessor) GetDeadTasks(token string) ([]repositories.Task, error) {
+	tasks, err := ip.taskRepository.GetByStatus(repositories.StatusDead)
 	if err != nil {
-		ip.handleError(err, task.UUID)
+		return nil, err
+	}
+	result := []repositories.Task{}
+	for _, task := range tasks {
+		if task.Token == token {
+			result = append(result, task)
+		}
 	}
+	return result, nil
+}
 
-	//сохраняем файл во временную папку
-	downloadedFile, err := ip.im.SaveFile(task.Image, buf.Bytes())
+//возвращает мертвую задачу в очередь с новым счетчиком попыток. история ошибок сохраняеться
+func (ip *ImageProcessor) RequeueTask(taskId string, token string) error {
+	dbTask, err := ip.taskRepository.Get(taskId)
 	if err != nil {
-		ip.handleError(err, task.UUID)
+		return err
+	}
+	if dbTask == nil || dbTask.Token != token {
+		return ErrTaskNotFound
+	}
+	if dbTask.Status != repositories.StatusDead {
+		return ErrTaskNotDead
+	}
+	var task ResizeTask
+	err = json.Unmarshal(dbTask.Payload, &task)
+	if err != nil {
+		return err
 	}
 
-	//ресайзим картинку
-	thumbFile, err := ip.im.ResizeFile(downloadedFile, task.Resize)
+	dbTask.Status = repositories.StatusInProgress
+	dbTask.Error = ""
+	dbTask.Attempts = 0
+	dbTask.NextAttemptAt = time.Time{}
+	saved, err := ip.taskRepository.PutIfStatus(*dbTask, taskId, repositories.StatusDead)
 	if err != nil {
-		ip.handleError(err, task.UUID)
+		return err
 	}
-	if thumbFile == nil {
-		ip.handleError(errors.New("new file is not created"), task.UUID)
+	if !saved {
+		return ErrTaskNotDead
 	}
 
-	//получаем ссылку на файл
-	thumbToUpload, err := ip.im.GetFileResource(thumbFile)
-	defer func() {
-		err := thumbToUpload.Close()
+	//если очередь заполнена задачу подберет requeueStale
+	ip.enqueue(task)
+	ip.emit(taskId, token, EventQueued, nil)
+	return nil
+}
+
+func (ip *ImageProcessor) runTusk(ctx context.Context, im *imagemanager.ImageManager, task ResizeTask) error {
+	//чистим папку воркера при любом исходе
+	defer im.Clear()
+
+	//задачу могли отменить пока она ждала в очереди
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	//такой же конвеер для этой картинки уже выполняли, отдаем готовый результат без скачивания
+	var pipeline imagemanager.Pipeline
+	if len(task.Pipeline) != 0 {
+		pipeline = im.PipelineWithDefaults(task.Pipeline)
+		existing, err := ip.findPipelineResize(task.Image, pipeline)
 		if err != nil {
-			ip.handleError(err, task.UUID)
+			return err
 		}
-	}()
+		if existing != nil {
+			cancelled, err := ip.isCancelled(ctx, task.UUID)
+			if err != nil {
+				return err
+			}
+			if cancelled {
+				return context.Canceled
+			}
+			return ip.completeTask(task, []repositories.ImageResizeInfo{*existing}, nil)
+		}
+	}
+
+	//скачиваем картинку из хранилища
+	ip.emit(task.UUID, task.Token, EventDownloading, nil)
+	object, err := ip.storage.Get(task.Image)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadAll(newContextReader(ctx, object))
+	_ = object.Close()
 	if err != nil {
-		ip.handleError(err, task.UUID)
+		return err
 	}
 
-	//загруаем на S3
-	uploader := s3manager.NewUploader(ip.s3Session)
-	thumbUpload, err := uploader.Upload(&s3manager.UploadInput{
-		Bucket: aws.String(ip.bucket),
-		Key:    aws.String(thumbFile.Name),
-		Body:   thumbToUpload,
-	})
+	//сохраняем файл во временную папку
+	downloadedFile, err := im.SaveFile(task.Image, data)
 	if err != nil {
-		ip.handleError(err, task.UUID)
+		return err
 	}
 
-	//чистим папку
-	ip.im.Clear()
+	//водяные знаки тоже лежат в хранилище
+	err = ip.loadWatermarks(ctx, im, task)
+	if err != nil {
+		return err
+	}
+
+	//декодируем один раз и делаем из него все размеры
+	err = ctx.Err()
+	if err != nil {
+		return err
+	}
+	ip.emit(task.UUID, task.Token, EventResizing, nil)
+	decodedImage, err := im.DecodeFile(downloadedFile)
+	if err != nil {
+		return err
+	}
+	var thumbFiles []*imagemanager.File
+	var infos []repositories.ImageResizeInfo
+	if pipeline != nil {
+		thumbFile, err := im.RunPipeline(decodedImage, downloadedFile, pipeline)
+		if err != nil {
+			return err
+		}
+		thumbFiles = []*imagemanager.File{thumbFile}
+		infos = []repositories.ImageResizeInfo{repositories.NewPipelineResizeInfo(thumbFile.Name, "", pipeline)}
+	} else {
+		options := task.ResizeOptions()
+		for i := range options {
+			options[i] = im.WithDefaults(options[i])
+		}
+		for _, sizeOptions := range options {
+			err = ctx.Err()
+			if err != nil {
+				return err
+			}
+			thumbFile, err := im.ResizeImage(decodedImage, downloadedFile, sizeOptions)
+			if err != nil {
+				return err
+			}
+			if thumbFile == nil {
+				return errors.New("new file is not created")
+			}
+			info := repositories.NewImageResizeInfo(thumbFile.Name, "", sizeOptions)
+			info.Preset = task.Preset
+			thumbFiles = append(thumbFiles, thumbFile)
+			infos = append(infos, info)
+		}
+	}
+
+	//загруаем в хранилище
+	err = ctx.Err()
+	if err != nil {
+		return err
+	}
+	ip.emit(task.UUID, task.Token, EventUploading, nil)
+	thumbs, err := ip.uploadThumbs(ctx, im, thumbFiles, infos)
+	if err != nil {
+		return err
+	}
+
+	//после этого места задача считаеться выполненной
+	cancelled, err := ip.isCancelled(ctx, task.UUID)
+	if err != nil {
+		return err
+	}
+	if cancelled {
+		ip.removeThumbs(thumbs)
+		return context.Canceled
+	}
 
 	//сохраняем в базу
-	err = ip.resizeRepository.Append([]repositories.ImageResizeInfo{{
-		ResizedFileName: thumbFile.Name,
-		ResizedFilePath: thumbUpload.Location,
-		ResizeParam:     int64(task.Resize),
-	}}, task.Image)
+	resized := make([]repositories.ImageResizeInfo, 0, len(thumbs))
+	for _, thumb := range thumbs {
+		resized = append(resized, thumb.info)
+	}
+	return ip.completeTask(task, resized, thumbs)
+}
+
+//ищет среди ресайзов картинки результат такого же конвеера
+func (ip *ImageProcessor) findPipelineResize(image string, pipeline imagemanager.Pipeline) (*repositories.ImageResizeInfo, error) {
+	resizes, err := ip.resizeRepository.Get(image)
 	if err != nil {
-		ip.handleError(err, task.UUID)
+		return nil, err
+	}
+	canonical := pipeline.Canonical()
+	for _, resize := range resizes {
+		if resize.Pipeline == canonical {
+			return &resize, nil
+		}
 	}
+	return nil, nil
+}
 
+//помечает задачу выполненной с результатами resized и отправляет уведомления
+//uploaded это ресайзы которые задача загрузила сама, записи о них сохраняються только если задача еще in_progress
+//если задачу успели отменить, загруженные файлы удаляються и возвращаеться ErrTaskNotInProgress
+func (ip *ImageProcessor) completeTask(task ResizeTask, resized []repositories.ImageResizeInfo, uploaded []uploadedThumb) error {
 	image, err := ip.imageRepository.Get(task.Image)
 	if err != nil {
-		ip.handleError(err, task.UUID)
+		return err
 	}
 
 	dbTask, err := ip.taskRepository.Get(task.UUID)
 	if err != nil {
-		ip.handleError(err, task.UUID)
+		return err
+	}
+	if dbTask == nil {
+		return Permanent(ErrTaskNotFound)
 	}
 
 	dbTask.Status = repositories.StatusDone
+	dbTask.Error = ""
 	dbTask.FilePath = image.FilePath
 	dbTask.FileName = task.Image
-	dbTask.ResizedFilePath = thumbUpload.Location
-	dbTask.ResizedFileName = thumbFile.Name
+	dbTask.ResizedFilePath = resized[0].ResizedFilePath
+	dbTask.ResizedFileName = resized[0].ResizedFileName
+	dbTask.Resized = resized
+	dbTask.LeaseOwner = ""
+	dbTask.LeaseExpires = time.Time{}
 
-	err = ip.taskRepository.Put(*dbTask, task.UUID)
+	//отмена между проверкой и этой записью не должна превратиться в done
+	saved, err := ip.taskRepository.PutIfStatus(*dbTask, task.UUID, repositories.StatusInProgress)
 	if err != nil {
-		ip.handleError(err, task.UUID)
+		return err
+	}
+	if !saved {
+		ip.removeThumbs(uploaded)
+		return ErrTaskNotInProgress
+	}
+
+	//задача уже выполнена, поэтому ошибка тут только пишеться в лог, результат есть в самой задаче
+	if len(uploaded) != 0 {
+		err = ip.resizeRepository.Append(resized, task.Image)
+		if err != nil {
+			ip.Logger.Warning(err)
+		}
+	}
+	ip.emit(task.UUID, dbTask.Token, EventDone, nil)
+	ip.notify(*dbTask, task.UUID)
+	return nil
+}
+
+//параметры каждого ресайза задачи, без повторов ширины и в том порядке в котором их передали
+func (t ResizeTask) ResizeOptions() []imagemanager.ResizeOptions {
+	widths := t.Sizes
+	if len(widths) == 0 {
+		widths = []uint{t.Resize}
+	}
+	seen := make(map[uint]bool, len(widths))
+	result := make([]imagemanager.ResizeOptions, 0, len(widths))
+	for _, width := range widths {
+		if seen[width] {
+			continue
+		}
+		seen[width] = true
+		options := t.Options
+		options.Width = width
+		result = append(result, options)
 	}
+	return result
 }
 
+//решает что делать с упавшей задачей: повторить позже, пометить как мертвую или как ошибочную
+func (ip *ImageProcessor) handleTaskError(inErr error, task ResizeTask) {
+	dbTask, err := ip.taskRepository.Get(task.UUID)
+	if err != nil {
+		ip.Logger.Warning(err)
+		return
+	}
+	if dbTask == nil {
+		ip.Logger.Warningf("task %s not found: %s", task.UUID, inErr)
+		return
+	}
+	//задачу отменили пока она падала
+	if dbTask.Status != repositories.StatusInProgress {
+		return
+	}
+
+	now := time.Now()
+	dbTask.Error = inErr.Error()
+	dbTask.Errors = append(dbTask.Errors, repositories.TaskError{
+		Attempt: dbTask.Attempts,
+		Error:   inErr.Error(),
+		Time:    now,
+	})
+	if len(dbTask.Errors) > maxErrorHistory {
+		dbTask.Errors = dbTask.Errors[len(dbTask.Errors)-maxErrorHistory:]
+	}
+	dbTask.LeaseOwner = ""
+	dbTask.LeaseExpires = time.Time{}
+
+	retry := task.Retry.withDefaults(ip.config.Retry)
+	var delay time.Duration
+	switch {
+	case !IsRetryable(inErr):
+		dbTask.Status = repositories.StatusError
+	case dbTask.Attempts >= retry.MaxAttempts:
+		dbTask.Status = repositories.StatusDead
+	default:
+		delay = retry.Backoff(dbTask.Attempts)
+		dbTask.NextAttemptAt = now.Add(delay)
+	}
+
+	saved, err := ip.taskRepository.PutIfStatus(*dbTask, task.UUID, repositories.StatusInProgress)
+	if err != nil {
+		ip.Logger.Warning(err)
+		return
+	}
+	if !saved {
+		return
+	}
+
+	if dbTask.Status == repositories.StatusInProgress {
+		ip.Logger.Infof("task %s failed on attempt %d, retry in %s: %s", task.UUID, dbTask.Attempts, delay, inErr)
+		ip.scheduleRetry(task, delay)
+		ip.emit(task.UUID, dbTask.Token, EventQueued, inErr)
+		return
+	}
+	if dbTask.Status == repositories.StatusDead {
+		ip.emit(task.UUID, dbTask.Token, EventDead, inErr)
+	} else {
+		ip.emit(task.UUID, dbTask.Token, EventError, inErr)
+	}
+	ip.notify(*dbTask, task.UUID)
+}
+
+//после рестарта запланированные повторы подберет requeueStale
+func (ip *ImageProcessor) scheduleRetry(task ResizeTask, delay time.Duration) {
+	time.AfterFunc(delay, func() {
+		select {
+		case <-ip.done:
+			return
+		default:
+		}
+		ip.enqueue(task)
+	})
+}
+
+//помечает задачу ошибочной без повторов
 func (ip *ImageProcessor) handleError(inErr error, taskId string) {
 	dbTask, err := ip.taskRepository.Get(taskId)
 	if err != nil {
 		ip.Logger.Warning(err)
+		return
+	}
+	if dbTask == nil {
+		ip.Logger.Warningf("task %s not found: %s", taskId, inErr)
+		return
 	}
 
 	dbTask.Status = repositories.StatusError
 	dbTask.Error = inErr.Error()
+	dbTask.LeaseOwner = ""
+	dbTask.LeaseExpires = time.Time{}
 
-	err = ip.taskRepository.Put(*dbTask, taskId)
+	saved, err := ip.taskRepository.PutIfStatus(*dbTask, taskId, repositories.StatusInProgress)
 	if err != nil {
 		ip.Logger.Warning(err)
+		return
+	}
+	if !saved {
+		return
 	}
+	ip.emit(taskId, dbTask.Token, EventError, inErr)
+	ip.notify(*dbTask, taskId)
 }
@@ -0,0 +1,371 @@
+package processors
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/op/go-logging"
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"github.com/xan-mortum/apimediaservice/components/storage"
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+//процессор на памяти с картинкой a.png 40x20 в хранилище. воркеры не запущены
+func newTestProcessor(t *testing.T, repos *repositories.Repositories, config Config) (*ImageProcessor, storage.BlobStore) {
+	tmpDir, err := ioutil.TempDir("", "processor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(tmpDir)
+	})
+	store := storage.NewMemoryStore()
+	var data bytes.Buffer
+	err = png.Encode(&data, image.NewRGBA(image.Rect(0, 0, 40, 20)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.Put("a.png", &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ip := NewImageProcessor(
+		logging.MustGetLogger("test"),
+		config,
+		repos.TaskRepository,
+		repos.ResizeRepository,
+		repos.ImageRepository,
+		repos.DeliveryRepository,
+		store,
+		imagemanager.NewImageManager(imagemanager.NewConfig(tmpDir+string(os.PathSeparator), "", imagemanager.MetadataStrip, false)),
+		nil,
+	)
+	return ip, store
+}
+
+func newTestRepositories(t *testing.T) *repositories.Repositories {
+	repos, err := repositories.NewRepositories(repositories.NewConfig(repositories.DriverMemory, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repos
+}
+
+//ждет пока задача перейдет в status
+func waitTaskStatus(t *testing.T, repos *repositories.Repositories, taskId string, status string) *repositories.Task {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		task, err := repos.TaskRepository.Get(taskId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if task != nil && task.Status == status {
+			return task
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("task %s is %+v, want status %s", taskId, task, status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func testPayload(t *testing.T, task ResizeTask) json.RawMessage {
+	payload, err := json.Marshal(task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return payload
+}
+
+func TestAddTaskQueueFull(t *testing.T) {
+	//воркеры не запущены, очередь на одну задачу
+	ip, repos := newWebhookTestProcessor(t)
+
+	err := ip.AddTask(ResizeTask{UUID: "first", Token: "token", Image: "a.png", Resize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ip.AddTask(ResizeTask{UUID: "second", Token: "token", Image: "a.png", Resize: 10})
+	if err != ErrQueueFull {
+		t.Fatalf("error %v, want %v", err, ErrQueueFull)
+	}
+
+	tests := []struct {
+		taskId string
+		exists bool
+	}{
+		{"first", true},
+		//клиент получил ошибку, задача не должна потом запуститься сама
+		{"second", false},
+	}
+	for _, test := range tests {
+		task, err := repos.TaskRepository.Get(test.taskId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (task != nil) != test.exists {
+			t.Fatalf("task %s: %+v, exists %v", test.taskId, task, test.exists)
+		}
+		if task != nil && task.Status != repositories.StatusInProgress {
+			t.Fatalf("task %s status %s", test.taskId, task.Status)
+		}
+	}
+}
+
+func TestRequeueStale(t *testing.T) {
+	now := time.Now()
+	payload := testPayload(t, ResizeTask{UUID: "task", Token: "token", Image: "a.png", Resize: 10})
+	tests := []struct {
+		name   string
+		task   repositories.Task
+		queued bool
+		status string
+	}{
+		{"not leased", repositories.Task{Status: repositories.StatusInProgress, Payload: payload}, true, repositories.StatusInProgress},
+		{"lease expired", repositories.Task{Status: repositories.StatusInProgress, Payload: payload, LeaseOwner: "other/1", LeaseExpires: now.Add(-time.Second)}, true, repositories.StatusInProgress},
+		{"leased by other process", repositories.Task{Status: repositories.StatusInProgress, Payload: payload, LeaseOwner: "other/1", LeaseExpires: now.Add(time.Minute)}, false, repositories.StatusInProgress},
+		{"waits for retry", repositories.Task{Status: repositories.StatusInProgress, Payload: payload, NextAttemptAt: now.Add(time.Minute)}, false, repositories.StatusInProgress},
+		{"done", repositories.Task{Status: repositories.StatusDone, Payload: payload}, false, repositories.StatusDone},
+		//задачи без параметров остались от старых версий, восстановить их нельзя
+		{"payload is lost", repositories.Task{Status: repositories.StatusInProgress}, false, repositories.StatusError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repos := newTestRepositories(t)
+			ip, _ := newTestProcessor(t, repos, NewConfig(1, 10, time.Minute, RetryPolicy{}, RetryPolicy{}))
+			task := test.task
+			task.Token = "token"
+			err := repos.TaskRepository.Put(task, "task")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ip.requeueStale()
+
+			queued := len(ip.getTasksIn) == 1
+			if queued != test.queued || ip.queued["task"] != test.queued {
+				t.Fatalf("queued %d %v, want %v", len(ip.getTasksIn), ip.queued["task"], test.queued)
+			}
+			//задача уже в очереди второй раз туда не попадает
+			ip.requeueStale()
+			if test.queued && len(ip.getTasksIn) != 1 {
+				t.Fatalf("task is queued %d times", len(ip.getTasksIn))
+			}
+			saved, err := repos.TaskRepository.Get("task")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if saved.Status != test.status {
+				t.Fatalf("status %s, want %s", saved.Status, test.status)
+			}
+		})
+	}
+}
+
+//задача которую другой процесс оставил в in_progress, подбираеться после перезапуска
+func TestTaskSurvivesRestart(t *testing.T) {
+	repos := newTestRepositories(t)
+	config := NewConfig(1, 10, time.Minute, RetryPolicy{}, RetryPolicy{})
+
+	stopped, _ := newTestProcessor(t, repos, config)
+	err := stopped.AddTask(ResizeTask{UUID: "task", Token: "token", Image: "a.png", Resize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ip, store := newTestProcessor(t, repos, config)
+	err = ip.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ip.Stop()
+
+	task := waitTaskStatus(t, repos, "task", repositories.StatusDone)
+	if task.Attempts != 1 || task.LeaseOwner != "" || len(task.Resized) != 1 {
+		t.Fatalf("task %+v", task)
+	}
+	object, err := store.Get(task.ResizedFileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	thumb, err := png.DecodeConfig(object)
+	_ = object.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if thumb.Width != 10 || thumb.Height != 5 {
+		t.Fatalf("resized %dx%d, want 10x5", thumb.Width, thumb.Height)
+	}
+}
+
+//задачу с действующей арендой другого воркера не запускаем, попытка не считаеться
+func TestRunLeasedSkipsTakenTask(t *testing.T) {
+	repos := newTestRepositories(t)
+	ip, _ := newTestProcessor(t, repos, NewConfig(1, 10, time.Minute, RetryPolicy{}, RetryPolicy{}))
+	task := ResizeTask{UUID: "task", Token: "token", Image: "a.png", Resize: 10}
+	err := repos.TaskRepository.Put(repositories.Task{
+		Token:        "token",
+		Status:       repositories.StatusInProgress,
+		Payload:      testPayload(t, task),
+		LeaseOwner:   "other/1",
+		LeaseExpires: time.Now().Add(time.Minute),
+	}, "task")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	im := ip.im
+	ip.runLeased(&im, "self/1", task)
+
+	saved, err := repos.TaskRepository.Get("task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Status != repositories.StatusInProgress || saved.Attempts != 0 || saved.LeaseOwner != "other/1" {
+		t.Fatalf("task %+v", saved)
+	}
+}
+
+func TestResizeTaskOptions(t *testing.T) {
+	options := imagemanager.ResizeOptions{Height: 50, Mode: imagemanager.ModeFill}
+	tests := []struct {
+		name   string
+		task   ResizeTask
+		widths []uint
+	}{
+		{"one width", ResizeTask{Resize: 100, Options: options}, []uint{100}},
+		{"sizes in given order", ResizeTask{Sizes: []uint{300, 100, 200}, Options: options}, []uint{300, 100, 200}},
+		{"repeated widths", ResizeTask{Sizes: []uint{100, 200, 100}, Options: options}, []uint{100, 200}},
+		{"sizes win over resize", ResizeTask{Resize: 50, Sizes: []uint{100}, Options: options}, []uint{100}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := test.task.ResizeOptions()
+			if len(result) != len(test.widths) {
+				t.Fatalf("options %+v, want widths %v", result, test.widths)
+			}
+			for i, width := range test.widths {
+				want := options
+				want.Width = width
+				if result[i] != want {
+					t.Fatalf("options %d are %+v, want %+v", i, result[i], want)
+				}
+			}
+		})
+	}
+}
+
+//несколько ширин делаються одной задачей из одного скачивания
+func TestBatchResize(t *testing.T) {
+	repos := newTestRepositories(t)
+	ip, store := newTestProcessor(t, repos, NewConfig(1, 10, time.Minute, RetryPolicy{}, RetryPolicy{}))
+	err := ip.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ip.Stop()
+
+	err = ip.AddTask(ResizeTask{UUID: "task", Token: "token", Image: "a.png", Sizes: []uint{20, 10, 20}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := waitTaskStatus(t, repos, "task", repositories.StatusDone)
+
+	want := []struct {
+		width  int
+		height int
+	}{
+		{20, 10},
+		{10, 5},
+	}
+	if len(task.Resized) != len(want) {
+		t.Fatalf("resized %+v", task.Resized)
+	}
+	if task.ResizedFileName != task.Resized[0].ResizedFileName {
+		t.Fatalf("first resize %s, want %s", task.ResizedFileName, task.Resized[0].ResizedFileName)
+	}
+	for i, resized := range task.Resized {
+		if resized.ResizeParam != int64(want[i].width) {
+			t.Fatalf("resize %d is %+v", i, resized)
+		}
+		object, err := store.Get(resized.ResizedFileName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		thumb, err := png.DecodeConfig(object)
+		_ = object.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if thumb.Width != want[i].width || thumb.Height != want[i].height {
+			t.Fatalf("resize %d is %dx%d, want %dx%d", i, thumb.Width, thumb.Height, want[i].width, want[i].height)
+		}
+	}
+	//записи о ресайзах картинки добавляються все сразу
+	resizes, err := repos.ResizeRepository.Get("a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resizes) != len(want) {
+		t.Fatalf("resizes of the image %+v", resizes)
+	}
+}
+
+//такой же по смыслу конвеер для той же картинки не выполняеться второй раз, задача получает готовый результат
+func TestPipelineReusesResult(t *testing.T) {
+	repos := newTestRepositories(t)
+	ip, store := newTestProcessor(t, repos, NewConfig(1, 10, time.Minute, RetryPolicy{}, RetryPolicy{}))
+	err := ip.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ip.Stop()
+
+	err = ip.AddTask(ResizeTask{UUID: "first", Token: "token", Image: "a.png", Pipeline: imagemanager.Pipeline{
+		{Op: imagemanager.OpRotate, Angle: 90},
+		{Op: imagemanager.OpResize, Width: 10},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := waitTaskStatus(t, repos, "first", repositories.StatusDone)
+
+	//исходника больше нет, значит вторая задача может выполниться только без скачивания
+	err = store.Delete("a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ip.AddTask(ResizeTask{UUID: "second", Token: "token", Image: "a.png", Pipeline: imagemanager.Pipeline{
+		{Op: imagemanager.OpRotate, Angle: -270},
+		{Op: imagemanager.OpResize, Width: 10, Mode: imagemanager.ModeFill},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second := waitTaskStatus(t, repos, "second", repositories.StatusDone)
+	if second.ResizedFileName != first.ResizedFileName {
+		t.Fatalf("second result %s, want %s", second.ResizedFileName, first.ResizedFileName)
+	}
+
+	//другой конвеер делаеться заново и падает без исходника
+	err = ip.AddTask(ResizeTask{UUID: "third", Token: "token", Image: "a.png", Pipeline: imagemanager.Pipeline{
+		{Op: imagemanager.OpResize, Width: 20},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitTaskStatus(t, repos, "third", repositories.StatusError)
+
+	resizes, err := repos.ResizeRepository.Get("a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resizes) != 1 {
+		t.Fatalf("resizes of the image %+v", resizes)
+	}
+}
@@ -0,0 +1,110 @@
+package processors
+
+import (
+	"errors"
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"github.com/xan-mortum/apimediaservice/components/storage"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const DefaultMaxAttempts = 5
+const DefaultInitialBackoff = time.Second
+const DefaultMaxBackoff = 5 * time.Minute
+
+//сколько последних ошибок храниться в задаче
+const maxErrorHistory = 10
+
+//политика повторов сохраняеться вместе с задачей, так что у каждой задачи она может быть своя
+type RetryPolicy struct {
+	MaxAttempts    int           `json:"maxAttempts"`
+	InitialBackoff time.Duration `json:"initialBackoff"`
+	MaxBackoff     time.Duration `json:"maxBackoff"`
+}
+
+func NewRetryPolicy(maxAttempts int, initialBackoff time.Duration, maxBackoff time.Duration) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: initialBackoff,
+		MaxBackoff:     maxBackoff,
+	}
+}
+
+//незаполненные поля берутся из defaults
+func (p RetryPolicy) withDefaults(defaults RetryPolicy) RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaults.MaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = defaults.InitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = defaults.MaxBackoff
+	}
+	return p
+}
+
+//задержка перед следующей попыткой: экспоненциально растет с номером попытки
+//и случайно уменьшаеться до половины, что бы упавшие одновременно задачи не повторялись тоже одновременно
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	half := int64(backoff / 2)
+	return time.Duration(half + jitter(half+1))
+}
+
+var randMx sync.Mutex
+var random = rand.New(rand.NewSource(time.Now().UnixNano()))
+
+func jitter(n int64) int64 {
+	randMx.Lock()
+	defer randMx.Unlock()
+	return random.Int63n(n)
+}
+
+//ошибка после которой повторять задачу нет смысла
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
+
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+//все что не удалось классифицировать считаеться временной ошибкой сети или диска
+func IsRetryable(err error) bool {
+	var permanent permanentError
+	if errors.As(err, &permanent) {
+		return false
+	}
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, image.ErrFormat) || errors.Is(err, imagemanager.ErrNotSupported) ||
+		errors.Is(err, imagemanager.ErrInvalidOptions) {
+		return false
+	}
+	var jpegFormatError jpeg.FormatError
+	var jpegUnsupportedError jpeg.UnsupportedError
+	var pngFormatError png.FormatError
+	var pngUnsupportedError png.UnsupportedError
+	if errors.As(err, &jpegFormatError) || errors.As(err, &jpegUnsupportedError) ||
+		errors.As(err, &pngFormatError) || errors.As(err, &pngUnsupportedError) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,183 @@
+package processors
+
+import (
+	"errors"
+	"fmt"
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"github.com/xan-mortum/apimediaservice/components/storage"
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"image"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	policy := NewRetryPolicy(10, time.Second, 10*time.Second)
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		//дальше задержка упираеться в MaxBackoff
+		{5, 10 * time.Second},
+		{20, 10 * time.Second},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.attempt), func(t *testing.T) {
+			//случайная часть не больше половины задержки
+			for i := 0; i < 100; i++ {
+				backoff := policy.Backoff(test.attempt)
+				if backoff < test.max/2 || backoff > test.max {
+					t.Fatalf("Backoff(%d) = %s, want from %s to %s", test.attempt, backoff, test.max/2, test.max)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDefaults(t *testing.T) {
+	defaults := NewRetryPolicy(5, time.Second, time.Minute)
+	policy := RetryPolicy{MaxAttempts: 2}.withDefaults(defaults)
+	if policy != NewRetryPolicy(2, time.Second, time.Minute) {
+		t.Fatalf("policy %+v", policy)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"network error", errors.New("connection reset by peer"), true},
+		{"permanent", Permanent(errors.New("bad task")), false},
+		{"wrapped permanent", fmt.Errorf("task: %w", Permanent(errors.New("bad task"))), false},
+		{"missing object", fmt.Errorf("download: %w", storage.ErrNotFound), false},
+		{"unknown format", image.ErrFormat, false},
+		{"unsupported extension", imagemanager.ErrNotSupported, false},
+		{"invalid options", fmt.Errorf("%w: width is too large", imagemanager.ErrInvalidOptions), false},
+		{"broken png", png.FormatError("bad header"), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if IsRetryable(test.err) != test.retryable {
+				t.Fatalf("IsRetryable(%v) = %v, want %v", test.err, !test.retryable, test.retryable)
+			}
+		})
+	}
+}
+
+func TestHandleTaskError(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		err      error
+		status   string
+		retry    bool
+	}{
+		{"retried", 1, errors.New("timeout"), repositories.StatusInProgress, true},
+		{"attempts exhausted", 3, errors.New("timeout"), repositories.StatusDead, false},
+		{"permanent error", 1, Permanent(errors.New("bad task")), repositories.StatusError, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repos := newTestRepositories(t)
+			ip, _ := newTestProcessor(t, repos, NewConfig(1, 10, time.Minute, RetryPolicy{}, RetryPolicy{}))
+			task := ResizeTask{
+				UUID:   "task",
+				Token:  "token",
+				Image:  "a.png",
+				Resize: 10,
+				Retry:  NewRetryPolicy(3, time.Hour, time.Hour),
+			}
+			err := repos.TaskRepository.Put(repositories.Task{
+				Token:        "token",
+				Status:       repositories.StatusInProgress,
+				Payload:      testPayload(t, task),
+				Attempts:     test.attempts,
+				LeaseOwner:   "self/1",
+				LeaseExpires: time.Now().Add(time.Minute),
+			}, "task")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ip.handleTaskError(test.err, task)
+
+			saved, err := repos.TaskRepository.Get("task")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if saved.Status != test.status || saved.Error != test.err.Error() || saved.LeaseOwner != "" {
+				t.Fatalf("task %+v", saved)
+			}
+			if len(saved.Errors) != 1 || saved.Errors[0].Attempt != test.attempts || saved.Errors[0].Error != test.err.Error() {
+				t.Fatalf("errors %+v", saved.Errors)
+			}
+			//повтор запланирован не раньше половины задержки
+			if saved.NextAttemptAt.After(time.Now().Add(time.Hour/2-time.Minute)) != test.retry {
+				t.Fatalf("next attempt at %s", saved.NextAttemptAt)
+			}
+		})
+	}
+}
+
+func TestDeadTaskRequeue(t *testing.T) {
+	repos := newTestRepositories(t)
+	ip, _ := newTestProcessor(t, repos, NewConfig(1, 10, time.Minute, RetryPolicy{}, RetryPolicy{}))
+	task := ResizeTask{UUID: "task", Token: "token", Image: "a.png", Resize: 10}
+	err := repos.TaskRepository.Put(repositories.Task{
+		Token:    "token",
+		Status:   repositories.StatusDead,
+		Payload:  testPayload(t, task),
+		Error:    "timeout",
+		Attempts: 5,
+		Errors:   []repositories.TaskError{{Attempt: 5, Error: "timeout"}},
+	}, "task")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dead, err := ip.GetDeadTasks("token")
+	if err != nil || len(dead) != 1 {
+		t.Fatalf("dead tasks %+v %v", dead, err)
+	}
+	dead, err = ip.GetDeadTasks("other")
+	if err != nil || len(dead) != 0 {
+		t.Fatalf("dead tasks of other token %+v %v", dead, err)
+	}
+
+	tests := []struct {
+		name   string
+		taskId string
+		token  string
+		err    error
+	}{
+		{"other token", "task", "other", ErrTaskNotFound},
+		{"missing task", "missing", "token", ErrTaskNotFound},
+		{"dead task", "task", "token", nil},
+		{"already requeued", "task", "token", ErrTaskNotDead},
+	}
+	for _, test := range tests {
+		err := ip.RequeueTask(test.taskId, test.token)
+		if err != test.err {
+			t.Fatalf("%s: error %v, want %v", test.name, err, test.err)
+		}
+	}
+
+	saved, err := repos.TaskRepository.Get("task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	//счетчик попыток начинаеться заново, история ошибок остаеться
+	if saved.Status != repositories.StatusInProgress || saved.Attempts != 0 || saved.Error != "" || len(saved.Errors) != 1 {
+		t.Fatalf("task %+v", saved)
+	}
+	if len(ip.getTasksIn) != 1 {
+		t.Fatalf("%d tasks in the queue, want 1", len(ip.getTasksIn))
+	}
+}
@@ -0,0 +1,104 @@
+package processors
+
+import (
+	"context"
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"sync"
+)
+
+//ресайз загруженный в хранилище
+type uploadedThumb struct {
+	info repositories.ImageResizeInfo
+	//файл с таким именем уже был в хранилище до загрузки, при отмене задачи его удалять нельзя
+	existed bool
+}
+
+//загружает все ресайзы параллельно. если одна загрузка упала, остальные прерываются
+//при отмене задачи уже загруженные файлы удаляються
+//infos это записи о ресайзах без пути в хранилище, путь заполняеться после загрузки
+func (ip *ImageProcessor) uploadThumbs(ctx context.Context, im *imagemanager.ImageManager, thumbFiles []*imagemanager.File, infos []repositories.ImageResizeInfo) ([]uploadedThumb, error) {
+	uploadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	thumbs := make([]uploadedThumb, len(thumbFiles))
+	errs := make([]error, len(thumbFiles))
+	var wg sync.WaitGroup
+	for i := range thumbFiles {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			thumbs[i], errs[i] = ip.uploadThumb(uploadCtx, im, thumbFiles[i], infos[i])
+			if errs[i] != nil {
+				cancel()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	//остальные загрузки падают с context.Canceled, настоящая причина это первая другая ошибка
+	var firstErr error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if firstErr == nil || (firstErr == context.Canceled && err != context.Canceled) {
+			firstErr = err
+		}
+	}
+	if firstErr == nil {
+		return thumbs, nil
+	}
+
+	if ctx.Err() != nil {
+		var uploaded []uploadedThumb
+		for i, thumb := range thumbs {
+			if errs[i] == nil {
+				uploaded = append(uploaded, thumb)
+			}
+		}
+		ip.removeThumbs(uploaded)
+	}
+	return nil, firstErr
+}
+
+func (ip *ImageProcessor) uploadThumb(ctx context.Context, im *imagemanager.ImageManager, thumbFile *imagemanager.File, info repositories.ImageResizeInfo) (uploadedThumb, error) {
+	//получаем ссылку на файл
+	thumbToUpload, err := im.GetFileResource(thumbFile)
+	if err != nil {
+		return uploadedThumb{}, err
+	}
+	defer func() {
+		err := thumbToUpload.Close()
+		if err != nil {
+			ip.Logger.Warning(err)
+		}
+	}()
+
+	//файл с таким именем мог загрузить кто-то до нас, при отмене удалять его нельзя
+	_, err = ip.storage.Stat(thumbFile.Name)
+	existed := err == nil
+
+	thumbLocation, err := ip.storage.Put(thumbFile.Name, newContextReader(ctx, thumbToUpload))
+	if err != nil {
+		if ctx.Err() != nil && !existed {
+			ip.removeObject(thumbFile.Name)
+		}
+		return uploadedThumb{}, err
+	}
+
+	info.ResizedFilePath = thumbLocation
+	return uploadedThumb{
+		info:    info,
+		existed: existed,
+	}, nil
+}
+
+//удаляет ресайзы которые загрузила отмененная задача
+func (ip *ImageProcessor) removeThumbs(thumbs []uploadedThumb) {
+	for _, thumb := range thumbs {
+		if !thumb.existed {
+			ip.removeObject(thumb.info.ResizedFileName)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package processors
+
+import (
+	"context"
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"io/ioutil"
+)
+
+//id картинок водяных знаков нужных задаче, без повторов
+func (t ResizeTask) WatermarkImages() []string {
+	var images []string
+	if len(t.Pipeline) != 0 {
+		images = t.Pipeline.WatermarkImages()
+	} else if t.Options.Watermark != nil && t.Options.Watermark.Image != "" {
+		images = []string{t.Options.Watermark.Image}
+	}
+	seen := make(map[string]bool, len(images))
+	result := make([]string, 0, len(images))
+	for _, image := range images {
+		if !seen[image] {
+			seen[image] = true
+			result = append(result, image)
+		}
+	}
+	return result
+}
+
+//скачивает и декодирует картинки водяных знаков, дальше im рисует их при ресайзе
+//файлы лежат во временной папке воркера и удаляються вместе с остальными в im.Clear
+func (ip *ImageProcessor) loadWatermarks(ctx context.Context, im *imagemanager.ImageManager, task ResizeTask) error {
+	for _, image := range task.WatermarkImages() {
+		object, err := ip.storage.Get(image)
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadAll(newContextReader(ctx, object))
+		_ = object.Close()
+		if err != nil {
+			return err
+		}
+		//префикс что бы файл не совпал с исходником, если водяной знак рисуют на нем самом
+		file, err := im.SaveFile("watermark_"+image, data)
+		if err != nil {
+			return err
+		}
+		decodedImage, err := im.DecodeFile(file)
+		if err != nil {
+			return err
+		}
+		im.AddWatermarkImage(image, decodedImage)
+	}
+	return nil
+}
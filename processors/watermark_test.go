@@ -0,0 +1,104 @@
+package processors
+
+import (
+	"bytes"
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWatermarkImages(t *testing.T) {
+	tests := []struct {
+		name   string
+		task   ResizeTask
+		images []string
+	}{
+		{"no watermark", ResizeTask{Resize: 10}, []string{}},
+		{"text", ResizeTask{Resize: 10, Options: imagemanager.ResizeOptions{Watermark: &imagemanager.Watermark{Text: "shop"}}}, []string{}},
+		{"options", ResizeTask{Resize: 10, Options: imagemanager.ResizeOptions{Watermark: &imagemanager.Watermark{Image: "logo.png"}}}, []string{"logo.png"}},
+		//одна картинка в нескольких шагах скачиваеться один раз
+		{"pipeline", ResizeTask{Pipeline: imagemanager.Pipeline{
+			{Op: imagemanager.OpWatermark, Image: "logo.png"},
+			{Op: imagemanager.OpResize, Width: 10},
+			{Op: imagemanager.OpWatermark, Text: "shop"},
+			{Op: imagemanager.OpWatermark, Image: "badge.png"},
+			{Op: imagemanager.OpWatermark, Image: "logo.png"},
+		}}, []string{"logo.png", "badge.png"}},
+		//при конвеере Options не используються
+		{"pipeline ignores options", ResizeTask{
+			Options:  imagemanager.ResizeOptions{Watermark: &imagemanager.Watermark{Image: "logo.png"}},
+			Pipeline: imagemanager.Pipeline{{Op: imagemanager.OpResize, Width: 10}},
+		}, []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			images := test.task.WatermarkImages()
+			if !reflect.DeepEqual(images, test.images) {
+				t.Fatalf("WatermarkImages() = %v, want %v", images, test.images)
+			}
+		})
+	}
+}
+
+func TestResizeWithWatermark(t *testing.T) {
+	repos := newTestRepositories(t)
+	ip, store := newTestProcessor(t, repos, NewConfig(1, 10, time.Minute, RetryPolicy{}, RetryPolicy{}))
+	green := color.RGBA{G: 255, A: 255}
+	logo := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	draw.Draw(logo, logo.Bounds(), image.NewUniform(green), image.Point{}, draw.Src)
+	var data bytes.Buffer
+	err := png.Encode(&data, logo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.Put("logo.png", &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ip.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ip.Stop()
+
+	err = ip.AddTask(ResizeTask{UUID: "logo", Token: "token", Image: "a.png", Resize: 40, Options: imagemanager.ResizeOptions{
+		Watermark: &imagemanager.Watermark{Image: "logo.png"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := waitTaskStatus(t, repos, "logo", repositories.StatusDone)
+	object, err := store.Get(task.ResizedFileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer object.Close()
+	result, err := png.Decode(object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//исходник прозрачный, водяной знак в правом нижнем углу
+	r, g, b, a := result.At(39, 19).RGBA()
+	if r != 0 || g != 0xffff || b != 0 || a != 0xffff {
+		t.Fatalf("watermark point %v", result.At(39, 19))
+	}
+	_, _, _, a = result.At(0, 0).RGBA()
+	if a != 0 {
+		t.Fatalf("source point %v", result.At(0, 0))
+	}
+
+	//без картинки водяного знака задача падает
+	err = ip.AddTask(ResizeTask{UUID: "missing", Token: "token", Image: "a.png", Resize: 40, Options: imagemanager.ResizeOptions{
+		Watermark: &imagemanager.Watermark{Image: "missing.png"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitTaskStatus(t, repos, "missing", repositories.StatusError)
+}
@@ -0,0 +1,137 @@
+package processors
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/xan-mortum/apimediaservice/components/webhook"
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"time"
+)
+
+const DefaultWebhookMaxAttempts = 5
+const DefaultWebhookInitialBackoff = time.Second
+const DefaultWebhookMaxBackoff = time.Minute
+
+//тело уведомления, повторяет ответ /v2/result. задачу целиком не отправляем,
+//в ней токен пользователя, параметры задачи и служебные поля аренды
+type WebhookPayload struct {
+	ID       string        `json:"id"`
+	Status   string        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Attempts int           `json:"attempts"`
+	Original string        `json:"original,omitempty"`
+	Resized  string        `json:"resized,omitempty"`
+	Sizes    []WebhookSize `json:"sizes,omitempty"`
+}
+
+type WebhookSize struct {
+	Resize  int64  `json:"resize"`
+	Height  int64  `json:"height,omitempty"`
+	Resized string `json:"resized"`
+}
+
+func newWebhookPayload(dbTask repositories.Task, taskId string) WebhookPayload {
+	payload := WebhookPayload{
+		ID:       taskId,
+		Status:   dbTask.Status,
+		Error:    dbTask.Error,
+		Attempts: dbTask.Attempts,
+		Original: dbTask.FilePath,
+		Resized:  dbTask.ResizedFilePath,
+	}
+	for _, resized := range dbTask.Resized {
+		payload.Sizes = append(payload.Sizes, WebhookSize{
+			Resize:  resized.ResizeParam,
+			Height:  resized.Height,
+			Resized: resized.ResizedFilePath,
+		})
+	}
+	return payload
+}
+
+//сообщает о завершении задачи на адрес который передали при ее создании
+//доставка идет в фоне и не задерживает воркера, каждая попытка записываеться в DeliveryRepository
+//если сервис остановили, недоставленные уведомления теряются, их можно увидеть в журнале доставки
+func (ip *ImageProcessor) notify(dbTask repositories.Task, taskId string) {
+	if ip.webhook == nil || len(dbTask.Payload) == 0 {
+		return
+	}
+	var task ResizeTask
+	err := json.Unmarshal(dbTask.Payload, &task)
+	if err != nil {
+		ip.Logger.Warning(err)
+		return
+	}
+	if task.CallbackURL == "" {
+		return
+	}
+
+	body, err := json.Marshal(newWebhookPayload(dbTask, taskId))
+	if err != nil {
+		ip.Logger.Warning(err)
+		return
+	}
+
+	ip.wg.Add(1)
+	go func() {
+		defer ip.wg.Done()
+		ip.deliver(task.CallbackURL, taskId, dbTask.Status, body)
+	}()
+}
+
+func (ip *ImageProcessor) deliver(callbackURL string, taskId string, status string, body []byte) {
+	retry := ip.config.Webhook
+	for attempt := 1; ; attempt++ {
+		delivery := repositories.WebhookDelivery{
+			URL:        callbackURL,
+			Attempt:    attempt,
+			TaskStatus: status,
+			Time:       time.Now(),
+		}
+		statusCode, err := ip.webhook.Post(callbackURL, taskId, attempt, body)
+		delivery.StatusCode = statusCode
+		delivery.Delivered = err == nil
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		appendErr := ip.deliveryRepository.Append(delivery, taskId)
+		if appendErr != nil {
+			ip.Logger.Warning(appendErr)
+		}
+		if err == nil {
+			return
+		}
+
+		var webhookErr *webhook.Error
+		if (errors.As(err, &webhookErr) && webhookErr.Permanent()) || attempt >= retry.MaxAttempts {
+			ip.Logger.Warningf("webhook for task %s is not delivered after %d attempts: %s", taskId, attempt, err)
+			return
+		}
+
+		select {
+		case <-time.After(retry.Backoff(attempt)):
+		case <-ip.done:
+			return
+		}
+	}
+}
+
+//проверяет адрес для уведомлений до того как задача будет создана
+func (ip *ImageProcessor) ValidateCallbackURL(callbackURL string) error {
+	if ip.webhook == nil {
+		return webhook.ValidateURL(callbackURL, false)
+	}
+	return ip.webhook.ValidateURL(callbackURL)
+}
+
+//журнал доставки уведомлений задачи пользователя
+func (ip *ImageProcessor) GetDeliveries(taskId string, token string) ([]repositories.WebhookDelivery, error) {
+	dbTask, err := ip.taskRepository.Get(taskId)
+	if err != nil {
+		return nil, err
+	}
+	if dbTask == nil || dbTask.Token != token {
+		return nil, ErrTaskNotFound
+	}
+	return ip.deliveryRepository.Get(taskId)
+}
@@ -0,0 +1,225 @@
+package processors
+
+import (
+	"encoding/json"
+	"github.com/op/go-logging"
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"github.com/xan-mortum/apimediaservice/components/storage"
+	"github.com/xan-mortum/apimediaservice/components/webhook"
+	"github.com/xan-mortum/apimediaservice/repositories"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testWebhookSecret = "secret"
+
+var testWebhookRetry = NewRetryPolicy(3, 20*time.Millisecond, 40*time.Millisecond)
+
+//получатель который отвечает кодами из statusCodes по очереди, последний код повторяеться
+type webhookReceiver struct {
+	mx          sync.Mutex
+	statusCodes []int
+	requests    []*http.Request
+	bodies      [][]byte
+	times       []time.Time
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, request *http.Request) {
+	body, _ := ioutil.ReadAll(request.Body)
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	statusCode := r.statusCodes[len(r.statusCodes)-1]
+	if len(r.requests) < len(r.statusCodes) {
+		statusCode = r.statusCodes[len(r.requests)]
+	}
+	r.requests = append(r.requests, request)
+	r.bodies = append(r.bodies, body)
+	r.times = append(r.times, time.Now())
+	w.WriteHeader(statusCode)
+}
+
+func newWebhookTestProcessor(t *testing.T) (*ImageProcessor, *repositories.Repositories) {
+	repos, err := repositories.NewRepositories(repositories.NewConfig(repositories.DriverMemory, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ip := NewImageProcessor(
+		logging.MustGetLogger("test"),
+		NewConfig(1, 1, time.Minute, RetryPolicy{}, testWebhookRetry),
+		repos.TaskRepository,
+		repos.ResizeRepository,
+		repos.ImageRepository,
+		repos.DeliveryRepository,
+		storage.NewMemoryStore(),
+		imagemanager.ImageManager{},
+		webhook.NewClient(webhook.NewConfig(testWebhookSecret, time.Second, true)),
+	)
+	err = repos.TaskRepository.Put(repositories.Task{Token: "token", Status: repositories.StatusDone}, "task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ip, repos
+}
+
+func TestDeliverRetries(t *testing.T) {
+	ip, _ := newWebhookTestProcessor(t)
+	receiver := &webhookReceiver{statusCodes: []int{http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusOK}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	ip.deliver(server.URL, "task", repositories.StatusDone, []byte(`{"uuid":"task"}`))
+
+	if len(receiver.requests) != 3 {
+		t.Fatalf("%d requests, want 3", len(receiver.requests))
+	}
+	for i, request := range receiver.requests {
+		if request.Header.Get(webhook.AttemptHeader) != strconv.Itoa(i+1) {
+			t.Fatalf("request %d has attempt %q", i, request.Header.Get(webhook.AttemptHeader))
+		}
+		if !webhook.Verify(testWebhookSecret, receiver.bodies[i], request.Header.Get(webhook.SignatureHeader)) {
+			t.Fatalf("request %d has invalid signature", i)
+		}
+	}
+	//задержка растет с номером попытки и уменьшаеться случайно не больше чем вдвое
+	for attempt := 1; attempt < len(receiver.times); attempt++ {
+		gap := receiver.times[attempt].Sub(receiver.times[attempt-1])
+		minBackoff := testWebhookRetry.InitialBackoff << uint(attempt-1) / 2
+		if gap < minBackoff {
+			t.Fatalf("attempt %d started %s after the previous one, want at least %s", attempt+1, gap, minBackoff)
+		}
+	}
+
+	deliveries, err := ip.GetDeliveries("task", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		attempt    int
+		statusCode int
+		delivered  bool
+	}{
+		{3, http.StatusOK, true},
+		{2, http.StatusServiceUnavailable, false},
+		{1, http.StatusInternalServerError, false},
+	}
+	if len(deliveries) != len(want) {
+		t.Fatalf("%d deliveries, want %d", len(deliveries), len(want))
+	}
+	for i, delivery := range deliveries {
+		if delivery.Attempt != want[i].attempt || delivery.StatusCode != want[i].statusCode || delivery.Delivered != want[i].delivered {
+			t.Fatalf("delivery %d is %+v, want %+v", i, delivery, want[i])
+		}
+		if delivery.URL != server.URL || delivery.TaskStatus != repositories.StatusDone {
+			t.Fatalf("delivery %d is %+v", i, delivery)
+		}
+		if delivery.Delivered != (delivery.Error == "") {
+			t.Fatalf("delivery %d has error %q", i, delivery.Error)
+		}
+	}
+}
+
+func TestDeliverStops(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		attempts   int
+	}{
+		{"permanent error", http.StatusBadRequest, 1},
+		{"attempts exhausted", http.StatusInternalServerError, testWebhookRetry.MaxAttempts},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ip, _ := newWebhookTestProcessor(t)
+			receiver := &webhookReceiver{statusCodes: []int{test.statusCode}}
+			server := httptest.NewServer(receiver)
+			defer server.Close()
+
+			ip.deliver(server.URL, "task", repositories.StatusDone, []byte("{}"))
+
+			if len(receiver.requests) != test.attempts {
+				t.Fatalf("%d requests, want %d", len(receiver.requests), test.attempts)
+			}
+			deliveries, err := ip.GetDeliveries("task", "token")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(deliveries) != test.attempts {
+				t.Fatalf("%d deliveries, want %d", len(deliveries), test.attempts)
+			}
+			for _, delivery := range deliveries {
+				if delivery.Delivered || delivery.StatusCode != test.statusCode {
+					t.Fatalf("delivery %+v", delivery)
+				}
+			}
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	ip, repos := newWebhookTestProcessor(t)
+	receiver := &webhookReceiver{statusCodes: []int{http.StatusOK}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	payload, err := json.Marshal(ResizeTask{UUID: "task", Token: "token", CallbackURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbTask := repositories.Task{
+		Token:           "token",
+		Status:          repositories.StatusDone,
+		Payload:         payload,
+		FilePath:        "a.png",
+		ResizedFilePath: "thumb_a.png",
+		Resized:         []repositories.ImageResizeInfo{{ResizeParam: 10, ResizedFilePath: "thumb_a.png"}},
+		Attempts:        1,
+		LeaseOwner:      "process/1",
+		LeaseExpires:    time.Now().Add(time.Minute),
+		Errors:          []repositories.TaskError{{Attempt: 1, Error: "timeout"}},
+	}
+	err = repos.TaskRepository.Put(dbTask, "task")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ip.notify(dbTask, "task")
+	ip.wg.Wait()
+
+	if len(receiver.requests) != 1 {
+		t.Fatalf("%d requests, want 1", len(receiver.requests))
+	}
+	if !webhook.Verify(testWebhookSecret, receiver.bodies[0], receiver.requests[0].Header.Get(webhook.SignatureHeader)) {
+		t.Fatal("invalid signature")
+	}
+	var received WebhookPayload
+	err = json.Unmarshal(receiver.bodies[0], &received)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if received.ID != "task" || received.Status != repositories.StatusDone || received.Attempts != 1 ||
+		received.Original != "a.png" || received.Resized != "thumb_a.png" ||
+		len(received.Sizes) != 1 || received.Sizes[0].Resize != 10 || received.Sizes[0].Resized != "thumb_a.png" {
+		t.Fatalf("received %+v", received)
+	}
+	//токен, параметры и аренда задачи наружу не уходят
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(receiver.bodies[0], &fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{"token", "payload", "leaseOwner", "leaseExpires", "errors"} {
+		if _, ok := fields[field]; ok {
+			t.Fatalf("field %s is sent: %s", field, receiver.bodies[0])
+		}
+	}
+
+	_, err = ip.GetDeliveries("task", "other")
+	if err != ErrTaskNotFound {
+		t.Fatalf("deliveries with other token: %v, want %v", err, ErrTaskNotFound)
+	}
+}
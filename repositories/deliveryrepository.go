@@ -0,0 +1,24 @@
+package repositories
+
+import "time"
+
+//журнал отправки вебхуков по задачам
+type DeliveryRepository interface {
+	//последние попытки идут первыми. если попыток не было возвращаеться пустой список
+	Get(taskId string) ([]WebhookDelivery, error)
+	//добавляет попытку в начало списка
+	Append(delivery WebhookDelivery, taskId string) error
+}
+
+//одна попытка отправить вебхук
+type WebhookDelivery struct {
+	URL     string `json:"url"`
+	Attempt int    `json:"attempt"`
+	//статус задачи о котором сообщали
+	TaskStatus string `json:"taskStatus"`
+	//код ответа получателя, 0 если ответа не было
+	StatusCode int       `json:"statusCode"`
+	Error      string    `json:"error,omitempty"`
+	Time       time.Time `json:"time"`
+	Delivered  bool      `json:"delivered"`
+}
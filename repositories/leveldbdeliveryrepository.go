@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"encoding/json"
+	"github.com/syndtr/goleveldb/leveldb"
+	"sync"
+)
+
+const deliveryKey = "delivery"
+
+type LevelDBDeliveryRepository struct {
+	mx sync.Mutex
+	db *leveldb.DB
+}
+
+func NewLevelDBDeliveryRepository(db *leveldb.DB) *LevelDBDeliveryRepository {
+	return &LevelDBDeliveryRepository{
+		db: db,
+	}
+}
+
+func (r *LevelDBDeliveryRepository) Get(taskId string) ([]WebhookDelivery, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return r.get(taskId)
+}
+
+func (r *LevelDBDeliveryRepository) Append(delivery WebhookDelivery, taskId string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	deliveries, err := r.get(taskId)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(append([]WebhookDelivery{delivery}, deliveries...))
+	if err != nil {
+		return err
+	}
+	return r.db.Put([]byte(deliveryKey+":"+taskId), data, nil)
+}
+
+func (r *LevelDBDeliveryRepository) get(taskId string) ([]WebhookDelivery, error) {
+	data, err := r.db.Get([]byte(deliveryKey+":"+taskId), nil)
+	if err == leveldb.ErrNotFound {
+		return []WebhookDelivery{}, nil
+	}
+	if err != nil {
+		return []WebhookDelivery{}, err
+	}
+	var result []WebhookDelivery
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return []WebhookDelivery{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"encoding/json"
+	"github.com/syndtr/goleveldb/leveldb"
+	"sync"
+)
+
+const imagesKey = "images"
+
+type LevelDBImageRepository struct {
+	mx sync.Mutex
+	db *leveldb.DB
+}
+
+func NewLevelDBImageRepository(db *leveldb.DB) *LevelDBImageRepository {
+	return &LevelDBImageRepository{
+		db: db,
+	}
+}
+
+func (r *LevelDBImageRepository) Get(image string) (Image, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	has, err := r.db.Has([]byte(imagesKey+":"+image), nil)
+	if err != nil {
+		return Image{}, err
+	}
+	if !has {
+		return Image{}, nil
+	}
+	data, err := r.db.Get([]byte(imagesKey+":"+image), nil)
+	var result Image
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return Image{}, err
+	}
+	return result, nil
+}
+
+func (r *LevelDBImageRepository) Put(image Image) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	data, err := json.Marshal(image)
+	if err != nil {
+		return err
+	}
+	err = r.db.Put([]byte(imagesKey+":"+image.Uuid), data, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
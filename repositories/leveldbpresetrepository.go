@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"encoding/json"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"sync"
+)
+
+const presetKey = "preset"
+
+type LevelDBPresetRepository struct {
+	mx sync.Mutex
+	db *leveldb.DB
+}
+
+func NewLevelDBPresetRepository(db *leveldb.DB) *LevelDBPresetRepository {
+	return &LevelDBPresetRepository{
+		db: db,
+	}
+}
+
+func (r *LevelDBPresetRepository) Get(name string) (*Preset, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	data, err := r.db.Get([]byte(presetKey+":"+name), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var result Preset
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+//ключи в leveldb отсортированы, поэтому пресеты сразу идут по алфавиту
+func (r *LevelDBPresetRepository) List() ([]Preset, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	result := []Preset{}
+	iter := r.db.NewIterator(util.BytesPrefix([]byte(presetKey+":")), nil)
+	for iter.Next() {
+		var preset Preset
+		err := json.Unmarshal(iter.Value(), &preset)
+		if err != nil {
+			iter.Release()
+			return []Preset{}, err
+		}
+		result = append(result, preset)
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil {
+		return []Preset{}, err
+	}
+	return result, nil
+}
+
+func (r *LevelDBPresetRepository) Put(preset Preset) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	data, err := json.Marshal(preset)
+	if err != nil {
+		return err
+	}
+	return r.db.Put([]byte(presetKey+":"+preset.Name), data, nil)
+}
+
+func (r *LevelDBPresetRepository) Delete(name string) (bool, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	has, err := r.db.Has([]byte(presetKey+":"+name), nil)
+	if err != nil || !has {
+		return false, err
+	}
+	return true, r.db.Delete([]byte(presetKey+":"+name), nil)
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"encoding/json"
+	"github.com/syndtr/goleveldb/leveldb"
+	"sync"
+)
+
+const resizeKey = "resize"
+
+type LevelDBResizeRepository struct {
+	mx sync.Mutex
+	db *leveldb.DB
+}
+
+func NewLevelDBResizeRepository(db *leveldb.DB) *LevelDBResizeRepository {
+	return &LevelDBResizeRepository{
+		db: db,
+	}
+}
+
+func (r *LevelDBResizeRepository) Get(image string) ([]ImageResizeInfo, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	has, err := r.db.Has([]byte(resizeKey+":"+image), nil)
+	if err != nil {
+		return []ImageResizeInfo{}, err
+	}
+	if !has {
+		return []ImageResizeInfo{}, nil
+	}
+	data, err := r.db.Get([]byte(resizeKey+":"+image), nil)
+	var result []ImageResizeInfo
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return []ImageResizeInfo{}, err
+	}
+	return result, nil
+}
+
+func (r *LevelDBResizeRepository) Put(resize []ImageResizeInfo, image string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	data, err := json.Marshal(resize)
+	if err != nil {
+		return err
+	}
+	err = r.db.Put([]byte(resizeKey+":"+image), data, nil)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *LevelDBResizeRepository) Append(resize []ImageResizeInfo, image string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	var imageResizeInfos []ImageResizeInfo
+	has, err := r.db.Has([]byte(resizeKey+":"+image), nil)
+	if err != nil {
+		return err
+	}
+	if has {
+		oldResize, err := r.db.Get([]byte(resizeKey+":"+image), nil)
+		err = json.Unmarshal(oldResize, &imageResizeInfos)
+		if err != nil {
+			return err
+		}
+	}
+
+	resize = append(resize, imageResizeInfos...)
+
+	allResizeJson, err := json.Marshal(resize)
+	err = r.db.Put([]byte(resizeKey+":"+image), allResizeJson, nil)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"encoding/json"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"strings"
+	"sync"
+	"time"
+)
+
+const taskKey = "task"
+
+type LevelDBTaskRepository struct {
+	mx sync.Mutex
+	db *leveldb.DB
+}
+
+func NewLevelDBTaskRepository(db *leveldb.DB) *LevelDBTaskRepository {
+	return &LevelDBTaskRepository{
+		db: db,
+	}
+}
+
+func (r *LevelDBTaskRepository) Get(taskId string) (*Task, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return r.get(taskId)
+}
+
+func (r *LevelDBTaskRepository) Put(task Task, taskId string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return r.put(task, taskId)
+}
+
+func (r *LevelDBTaskRepository) PutIfStatus(task Task, taskId string, status string) (bool, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	current, err := r.get(taskId)
+	if err != nil {
+		return false, err
+	}
+	if current == nil || current.Status != status {
+		return false, nil
+	}
+	err = r.put(task, taskId)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *LevelDBTaskRepository) GetByStatus(status string) ([]Task, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	var result []Task
+	iter := r.db.NewIterator(util.BytesPrefix([]byte(taskKey+":")), nil)
+	for iter.Next() {
+		var task Task
+		err := json.Unmarshal(iter.Value(), &task)
+		if err != nil {
+			iter.Release()
+			return nil, err
+		}
+		if task.Status != status {
+			continue
+		}
+		task.UUID = strings.TrimPrefix(string(iter.Key()), taskKey+":")
+		result = append(result, task)
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *LevelDBTaskRepository) AcquireLease(taskId string, owner string, until time.Time) (bool, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	task, err := r.get(taskId)
+	if err != nil {
+		return false, err
+	}
+	if task == nil || !task.canBeLeased(owner, time.Now()) {
+		return false, nil
+	}
+	task.LeaseOwner = owner
+	task.LeaseExpires = until
+	err = r.put(*task, taskId)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *LevelDBTaskRepository) Delete(taskId string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return r.db.Delete([]byte(taskKey+":"+taskId), nil)
+}
+
+func (r *LevelDBTaskRepository) get(taskId string) (*Task, error) {
+	has, err := r.db.Has([]byte(taskKey+":"+taskId), nil)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	data, err := r.db.Get([]byte(taskKey+":"+taskId), nil)
+	if err != nil {
+		return nil, err
+	}
+	var result Task
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	result.UUID = taskId
+	return &result, nil
+}
+
+func (r *LevelDBTaskRepository) put(task Task, taskId string) error {
+	task.UUID = taskId
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	err = r.db.Put([]byte(taskKey+":"+taskId), data, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"encoding/json"
+	"github.com/syndtr/goleveldb/leveldb"
+	"sync"
+)
+
+const userImagesKey = "userImages"
+
+type LevelDBUserImageRepository struct {
+	mx sync.Mutex
+	db *leveldb.DB
+}
+
+func NewLevelDBUserImageRepository(db *leveldb.DB) *LevelDBUserImageRepository {
+	return &LevelDBUserImageRepository{
+		db: db,
+	}
+}
+
+func (r *LevelDBUserImageRepository) Get(userToken string) ([]UserImage, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	has, err := r.db.Has([]byte(userImagesKey+":"+userToken), nil)
+	if err != nil {
+		return []UserImage{}, err
+	}
+	if !has {
+		return []UserImage{}, nil
+	}
+	data, err := r.db.Get([]byte(userImagesKey+":"+userToken), nil)
+	var userImages []UserImage
+	err = json.Unmarshal(data, &userImages)
+	if err != nil {
+		return []UserImage{}, err
+	}
+	return userImages, nil
+}
+
+func (r *LevelDBUserImageRepository) Put(userImages []UserImage, userToken string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	data, err := json.Marshal(userImages)
+	if err != nil {
+		return err
+	}
+	err = r.db.Put([]byte(userImagesKey+":"+userToken), data, nil)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *LevelDBUserImageRepository) Append(userImages []UserImage, userToken string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	var images []UserImage
+	has, err := r.db.Has([]byte(userImagesKey+":"+userToken), nil)
+	if err != nil {
+		return err
+	}
+	if has {
+		oldImages, err := r.db.Get([]byte(userImagesKey+":"+userToken), nil)
+		err = json.Unmarshal(oldImages, &images)
+		if err != nil {
+			return err
+		}
+	}
+
+	userImages = append(userImages, images...)
+
+	allImagesJson, err := json.Marshal(userImages)
+	err = r.db.Put([]byte(userImagesKey+":"+userToken), allImagesJson, nil)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+type MemoryDeliveryRepository struct {
+	mx         sync.Mutex
+	deliveries map[string][]byte
+}
+
+func NewMemoryDeliveryRepository() *MemoryDeliveryRepository {
+	return &MemoryDeliveryRepository{
+		deliveries: make(map[string][]byte),
+	}
+}
+
+func (r *MemoryDeliveryRepository) Get(taskId string) ([]WebhookDelivery, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return r.get(taskId)
+}
+
+func (r *MemoryDeliveryRepository) Append(delivery WebhookDelivery, taskId string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	deliveries, err := r.get(taskId)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(append([]WebhookDelivery{delivery}, deliveries...))
+	if err != nil {
+		return err
+	}
+	r.deliveries[taskId] = data
+	return nil
+}
+
+func (r *MemoryDeliveryRepository) get(taskId string) ([]WebhookDelivery, error) {
+	data, ok := r.deliveries[taskId]
+	if !ok {
+		return []WebhookDelivery{}, nil
+	}
+	var result []WebhookDelivery
+	err := json.Unmarshal(data, &result)
+	if err != nil {
+		return []WebhookDelivery{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+//репозитории в памяти нужны для тестов и запуска без папки с базой
+//данные хранятся в json что бы снаружи нельзя было изменить их через общие слайсы
+type MemoryImageRepository struct {
+	mx     sync.Mutex
+	images map[string][]byte
+}
+
+func NewMemoryImageRepository() *MemoryImageRepository {
+	return &MemoryImageRepository{
+		images: make(map[string][]byte),
+	}
+}
+
+func (r *MemoryImageRepository) Get(image string) (Image, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	data, ok := r.images[image]
+	if !ok {
+		return Image{}, nil
+	}
+	var result Image
+	err := json.Unmarshal(data, &result)
+	if err != nil {
+		return Image{}, err
+	}
+	return result, nil
+}
+
+func (r *MemoryImageRepository) Put(image Image) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	data, err := json.Marshal(image)
+	if err != nil {
+		return err
+	}
+	r.images[image.Uuid] = data
+	return nil
+}
@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+type MemoryPresetRepository struct {
+	mx      sync.Mutex
+	presets map[string][]byte
+}
+
+func NewMemoryPresetRepository() *MemoryPresetRepository {
+	return &MemoryPresetRepository{
+		presets: make(map[string][]byte),
+	}
+}
+
+func (r *MemoryPresetRepository) Get(name string) (*Preset, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	data, ok := r.presets[name]
+	if !ok {
+		return nil, nil
+	}
+	var result Preset
+	err := json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *MemoryPresetRepository) List() ([]Preset, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	result := []Preset{}
+	for _, data := range r.presets {
+		var preset Preset
+		err := json.Unmarshal(data, &preset)
+		if err != nil {
+			return []Preset{}, err
+		}
+		result = append(result, preset)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func (r *MemoryPresetRepository) Put(preset Preset) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	data, err := json.Marshal(preset)
+	if err != nil {
+		return err
+	}
+	r.presets[preset.Name] = data
+	return nil
+}
+
+func (r *MemoryPresetRepository) Delete(name string) (bool, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	_, ok := r.presets[name]
+	delete(r.presets, name)
+	return ok, nil
+}
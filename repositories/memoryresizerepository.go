@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+type MemoryResizeRepository struct {
+	mx      sync.Mutex
+	resizes map[string][]byte
+}
+
+func NewMemoryResizeRepository() *MemoryResizeRepository {
+	return &MemoryResizeRepository{
+		resizes: make(map[string][]byte),
+	}
+}
+
+func (r *MemoryResizeRepository) Get(image string) ([]ImageResizeInfo, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return r.get(image)
+}
+
+func (r *MemoryResizeRepository) Put(resize []ImageResizeInfo, image string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return r.put(resize, image)
+}
+
+func (r *MemoryResizeRepository) Append(resize []ImageResizeInfo, image string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	imageResizeInfos, err := r.get(image)
+	if err != nil {
+		return err
+	}
+	return r.put(append(resize, imageResizeInfos...), image)
+}
+
+func (r *MemoryResizeRepository) get(image string) ([]ImageResizeInfo, error) {
+	data, ok := r.resizes[image]
+	if !ok {
+		return []ImageResizeInfo{}, nil
+	}
+	var result []ImageResizeInfo
+	err := json.Unmarshal(data, &result)
+	if err != nil {
+		return []ImageResizeInfo{}, err
+	}
+	return result, nil
+}
+
+func (r *MemoryResizeRepository) put(resize []ImageResizeInfo, image string) error {
+	data, err := json.Marshal(resize)
+	if err != nil {
+		return err
+	}
+	r.resizes[image] = data
+	return nil
+}
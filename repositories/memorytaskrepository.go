@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+)
+
+type MemoryTaskRepository struct {
+	mx    sync.Mutex
+	tasks map[string][]byte
+}
+
+func NewMemoryTaskRepository() *MemoryTaskRepository {
+	return &MemoryTaskRepository{
+		tasks: make(map[string][]byte),
+	}
+}
+
+func (r *MemoryTaskRepository) Get(taskId string) (*Task, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return r.get(taskId)
+}
+
+func (r *MemoryTaskRepository) Put(task Task, taskId string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return r.put(task, taskId)
+}
+
+func (r *MemoryTaskRepository) PutIfStatus(task Task, taskId string, status string) (bool, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	current, err := r.get(taskId)
+	if err != nil {
+		return false, err
+	}
+	if current == nil || current.Status != status {
+		return false, nil
+	}
+	err = r.put(task, taskId)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *MemoryTaskRepository) GetByStatus(status string) ([]Task, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	var result []Task
+	for _, data := range r.tasks {
+		var task Task
+		err := json.Unmarshal(data, &task)
+		if err != nil {
+			return nil, err
+		}
+		if task.Status == status {
+			result = append(result, task)
+		}
+	}
+	//порядок как у leveldb, по ключу
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].UUID < result[j].UUID
+	})
+	return result, nil
+}
+
+func (r *MemoryTaskRepository) Delete(taskId string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	delete(r.tasks, taskId)
+	return nil
+}
+
+func (r *MemoryTaskRepository) AcquireLease(taskId string, owner string, until time.Time) (bool, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	task, err := r.get(taskId)
+	if err != nil {
+		return false, err
+	}
+	if task == nil || !task.canBeLeased(owner, time.Now()) {
+		return false, nil
+	}
+	task.LeaseOwner = owner
+	task.LeaseExpires = until
+	err = r.put(*task, taskId)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *MemoryTaskRepository) get(taskId string) (*Task, error) {
+	data, ok := r.tasks[taskId]
+	if !ok {
+		return nil, nil
+	}
+	var result Task
+	err := json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *MemoryTaskRepository) put(task Task, taskId string) error {
+	task.UUID = taskId
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	r.tasks[taskId] = data
+	return nil
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+type MemoryUserImageRepository struct {
+	mx         sync.Mutex
+	userImages map[string][]byte
+}
+
+func NewMemoryUserImageRepository() *MemoryUserImageRepository {
+	return &MemoryUserImageRepository{
+		userImages: make(map[string][]byte),
+	}
+}
+
+func (r *MemoryUserImageRepository) Get(userToken string) ([]UserImage, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return r.get(userToken)
+}
+
+func (r *MemoryUserImageRepository) Put(userImages []UserImage, userToken string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return r.put(userImages, userToken)
+}
+
+func (r *MemoryUserImageRepository) Append(userImages []UserImage, userToken string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	images, err := r.get(userToken)
+	if err != nil {
+		return err
+	}
+	return r.put(append(userImages, images...), userToken)
+}
+
+func (r *MemoryUserImageRepository) get(userToken string) ([]UserImage, error) {
+	data, ok := r.userImages[userToken]
+	if !ok {
+		return []UserImage{}, nil
+	}
+	var userImages []UserImage
+	err := json.Unmarshal(data, &userImages)
+	if err != nil {
+		return []UserImage{}, err
+	}
+	return userImages, nil
+}
+
+func (r *MemoryUserImageRepository) put(userImages []UserImage, userToken string) error {
+	data, err := json.Marshal(userImages)
+	if err != nil {
+		return err
+	}
+	r.userImages[userToken] = data
+	return nil
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"time"
+)
+
+//именованные наборы параметров ресайза, например avatar-small. общие для всех пользователей
+type PresetRepository interface {
+	//если пресета нет возвращаеться nil без ошибки
+	Get(name string) (*Preset, error)
+	//все пресеты по алфавиту
+	List() ([]Preset, error)
+	//создает пресет или заменяет существующий с тем же именем
+	Put(preset Preset) error
+	//возвращает false если такого пресета не было
+	Delete(name string) (bool, error)
+}
+
+type Preset struct {
+	Name string `json:"name"`
+	//параметры сохраняються как их передали, значения по умолчанию подставляються при ресайзе
+	Options   imagemanager.ResizeOptions `json:"options"`
+	UpdatedAt time.Time                  `json:"updatedAt"`
+}
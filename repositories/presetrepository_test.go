@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPresetRepository(t *testing.T) {
+	for driver, repos := range testRepositories(t) {
+		t.Run(driver, func(t *testing.T) {
+			preset, err := repos.PresetRepository.Get("missing")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if preset != nil {
+				t.Fatalf("missing preset %+v", preset)
+			}
+
+			updatedAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+			card := Preset{
+				Name: "product-card",
+				Options: imagemanager.ResizeOptions{
+					Width:   300,
+					Height:  200,
+					Mode:    imagemanager.ModeFill,
+					Format:  imagemanager.FormatJPEG,
+					Quality: 80,
+					Crop:    &imagemanager.CropRect{Width: 10, Height: 10},
+				},
+				UpdatedAt: updatedAt,
+			}
+			avatar := Preset{Name: "avatar-small", Options: imagemanager.WidthOptions(64), UpdatedAt: updatedAt}
+			for _, preset := range []Preset{card, avatar} {
+				err = repos.PresetRepository.Put(preset)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			preset, err = repos.PresetRepository.Get(card.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if preset == nil || preset.Name != card.Name || !reflect.DeepEqual(preset.Options, card.Options) || !preset.UpdatedAt.Equal(updatedAt) {
+				t.Fatalf("preset %+v, want %+v", preset, card)
+			}
+
+			//пресет с тем же именем заменяеться
+			avatar.Options = imagemanager.WidthOptions(32)
+			err = repos.PresetRepository.Put(avatar)
+			if err != nil {
+				t.Fatal(err)
+			}
+			presets, err := repos.PresetRepository.List()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			var widths []uint
+			for _, preset := range presets {
+				names = append(names, preset.Name)
+				widths = append(widths, preset.Options.Width)
+			}
+			//по алфавиту, а не в порядке добавления
+			if !reflect.DeepEqual(names, []string{avatar.Name, card.Name}) || !reflect.DeepEqual(widths, []uint{32, 300}) {
+				t.Fatalf("presets %v %v", names, widths)
+			}
+
+			tests := []struct {
+				name    string
+				deleted bool
+			}{
+				{card.Name, true},
+				{card.Name, false},
+				{"missing", false},
+			}
+			for _, test := range tests {
+				deleted, err := repos.PresetRepository.Delete(test.name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if deleted != test.deleted {
+					t.Fatalf("Delete(%s) = %v, want %v", test.name, deleted, test.deleted)
+				}
+			}
+			preset, err = repos.PresetRepository.Get(card.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if preset != nil {
+				t.Fatalf("deleted preset %+v", preset)
+			}
+		})
+	}
+}
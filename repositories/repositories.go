@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	_ "modernc.org/sqlite"
+)
+
+const DriverLevelDB = "leveldb"
+const DriverMemory = "memory"
+const DriverSQLite = "sqlite"
+
+type Config struct {
+	Driver string
+	//путь к папке leveldb или к файлу sqlite. для памяти не нужен
+	Path string
+}
+
+func NewConfig(driver string, path string) Config {
+	return Config{
+		Driver: driver,
+		Path:   path,
+	}
+}
+
+//набор всех репозиториев поверх одной базы
+//что бы сменить базу достаточно поменять Config.Driver, остальной код работает только с интерфейсами
+type Repositories struct {
+	ImageRepository     ImageRepository
+	UserImageRepository UserImageRepository
+	ResizeRepository    ResizeRepository
+	TaskRepository      TaskRepository
+	DeliveryRepository  DeliveryRepository
+	PresetRepository    PresetRepository
+	close               func() error
+}
+
+func NewRepositories(config Config) (*Repositories, error) {
+	switch config.Driver {
+	case DriverLevelDB:
+		db, err := leveldb.OpenFile(config.Path, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &Repositories{
+			ImageRepository:     NewLevelDBImageRepository(db),
+			UserImageRepository: NewLevelDBUserImageRepository(db),
+			ResizeRepository:    NewLevelDBResizeRepository(db),
+			TaskRepository:      NewLevelDBTaskRepository(db),
+			DeliveryRepository:  NewLevelDBDeliveryRepository(db),
+			PresetRepository:    NewLevelDBPresetRepository(db),
+			close:               db.Close,
+		}, nil
+	case DriverSQLite:
+		db, err := sql.Open("sqlite", config.Path)
+		if err != nil {
+			return nil, err
+		}
+		//sqlite не умеет параллельную запись, без этого будут ошибки database is locked
+		db.SetMaxOpenConns(1)
+		err = MigrateSQL(db)
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+		return &Repositories{
+			ImageRepository:     NewSQLImageRepository(db),
+			UserImageRepository: NewSQLUserImageRepository(db),
+			ResizeRepository:    NewSQLResizeRepository(db),
+			TaskRepository:      NewSQLTaskRepository(db),
+			DeliveryRepository:  NewSQLDeliveryRepository(db),
+			PresetRepository:    NewSQLPresetRepository(db),
+			close:               db.Close,
+		}, nil
+	case DriverMemory:
+		return &Repositories{
+			ImageRepository:     NewMemoryImageRepository(),
+			UserImageRepository: NewMemoryUserImageRepository(),
+			ResizeRepository:    NewMemoryResizeRepository(),
+			TaskRepository:      NewMemoryTaskRepository(),
+			DeliveryRepository:  NewMemoryDeliveryRepository(),
+			PresetRepository:    NewMemoryPresetRepository(),
+			close: func() error {
+				return nil
+			},
+		}, nil
+	default:
+		return nil, errors.New(config.Driver + " database driver is not supported")
+	}
+}
+
+func (r *Repositories) Close() error {
+	return r.close()
+}
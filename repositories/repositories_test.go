@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+//репозитории на всех базах, у каждого теста свои
+func testRepositories(t *testing.T) map[string]*Repositories {
+	dir, err := ioutil.TempDir("", "repositories")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+	})
+	result := make(map[string]*Repositories)
+	for _, config := range []Config{
+		NewConfig(DriverLevelDB, filepath.Join(dir, "leveldb")),
+		NewConfig(DriverMemory, ""),
+		NewConfig(DriverSQLite, ":memory:"),
+	} {
+		repos, err := NewRepositories(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = repos.Close()
+		})
+		result[config.Driver] = repos
+	}
+	return result
+}
+
+func TestImageRepository(t *testing.T) {
+	for driver, repos := range testRepositories(t) {
+		t.Run(driver, func(t *testing.T) {
+			image, err := repos.ImageRepository.Get("missing")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if image.Uuid != "" {
+				t.Fatalf("missing image %+v", image)
+			}
+
+			want := Image{Uuid: "image", FileName: "image.png", FilePath: "memory://image.png"}
+			err = repos.ImageRepository.Put(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			image, err = repos.ImageRepository.Get(want.Uuid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(image, want) {
+				t.Fatalf("image %+v, want %+v", image, want)
+			}
+		})
+	}
+}
+
+func TestResizeRepositoryAppend(t *testing.T) {
+	for driver, repos := range testRepositories(t) {
+		t.Run(driver, func(t *testing.T) {
+			image := "image"
+			resizes, err := repos.ResizeRepository.Get(image)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resizes) != 0 {
+				t.Fatalf("resizes of a new image %+v", resizes)
+			}
+
+			for _, width := range []int{100, 200, 300} {
+				err = repos.ResizeRepository.Append([]ImageResizeInfo{{
+					ResizedFileName: "image_" + strconv.Itoa(width) + ".png",
+					ResizeParam:     int64(width),
+				}}, image)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			resizes, err = repos.ResizeRepository.Get(image)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var widths []int64
+			for _, resize := range resizes {
+				widths = append(widths, resize.ResizeParam)
+			}
+			//новые ресайзы в начале списка
+			if !reflect.DeepEqual(widths, []int64{300, 200, 100}) {
+				t.Fatalf("widths %v", widths)
+			}
+		})
+	}
+}
+
+func TestDeliveryRepositoryAppend(t *testing.T) {
+	for driver, repos := range testRepositories(t) {
+		t.Run(driver, func(t *testing.T) {
+			taskId := "task"
+			for attempt := 1; attempt <= 3; attempt++ {
+				err := repos.DeliveryRepository.Append(WebhookDelivery{
+					URL:       "http://example.com/hook",
+					Attempt:   attempt,
+					Time:      time.Now(),
+					Delivered: attempt == 3,
+				}, taskId)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			deliveries, err := repos.DeliveryRepository.Get(taskId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(deliveries) != 3 || deliveries[0].Attempt != 3 || !deliveries[0].Delivered || deliveries[2].Attempt != 1 {
+				t.Fatalf("deliveries %+v", deliveries)
+			}
+		})
+	}
+}
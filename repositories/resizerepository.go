@@ -1,98 +1,97 @@
 package repositories
 
 import (
-	"encoding/json"
-	"github.com/syndtr/goleveldb/leveldb"
-	"sync"
+	"github.com/xan-mortum/apimediaservice/components/imagemanager"
+	"path/filepath"
 )
 
-const resizeKey = "resize"
-
-var resizeRepositoryInstance *resizeRepositoryPrivate
-
-type ResizeRepository struct {
-	rp *resizeRepositoryPrivate
-}
-
-func NewResizeRepository(db *leveldb.DB) *ResizeRepository {
-	if resizeRepositoryInstance == nil {
-		resizeRepositoryInstance = &resizeRepositoryPrivate{
-			db: db,
-		}
-	}
-
-	return &ResizeRepository{
-		rp: resizeRepositoryInstance,
-	}
+type ResizeRepository interface {
+	Get(image string) ([]ImageResizeInfo, error)
+	Put(resize []ImageResizeInfo, image string) error
+	//добавляет ресайзы в начало списка
+	Append(resize []ImageResizeInfo, image string) error
 }
 
-type resizeRepositoryPrivate struct {
-	mx sync.Mutex
-	db *leveldb.DB
-}
-
-func (r *ResizeRepository) Get(image string) ([]ImageResizeInfo, error) {
-	r.rp.mx.Lock()
-	defer r.rp.mx.Unlock()
-	has, err := r.rp.db.Has([]byte(resizeKey + ":" + image), nil)
-	if err != nil {
-		return []ImageResizeInfo{}, err
-	}
-	if !has {
-		return []ImageResizeInfo{}, nil
-	}
-	data, err := r.rp.db.Get([]byte(resizeKey + ":" + image), nil)
-	var result []ImageResizeInfo
-	err = json.Unmarshal(data, &result)
-	if err != nil {
-		return []ImageResizeInfo{}, err
-	}
-	return result, nil
+type ImageResizeInfo struct {
+	ResizedFileName string `json:"resizedFileName"`
+	ResizedFilePath string `json:"resizedFilePath"`
+	//ширина
+	ResizeParam int64 `json:"resizeParam"`
+	//остальные параметры ресайза, пустые если ресайз был только по ширине
+	Height     int64  `json:"height,omitempty"`
+	Mode       string `json:"mode,omitempty"`
+	Gravity    string `json:"gravity,omitempty"`
+	Background string `json:"background,omitempty"`
+	//формат в котором сохранен ресайз
+	Format string `json:"format,omitempty"`
+	//фильтр которым ресайзили
+	Filter string `json:"filter,omitempty"`
+	//настройки кодировщика, что бы ресайз можно было повторить
+	Quality     int64  `json:"quality,omitempty"`
+	Compression string `json:"compression,omitempty"`
+	Colors      int64  `json:"colors,omitempty"`
+	NoDither    bool   `json:"noDither,omitempty"`
+	FirstFrame  bool   `json:"firstFrame,omitempty"`
+	//операции до ресайза
+	Crop   *imagemanager.CropRect `json:"crop,omitempty"`
+	Rotate float64                `json:"rotate,omitempty"`
+	Flip   string                 `json:"flip,omitempty"`
+	//операции после ресайза
+	Brightness float64                   `json:"brightness,omitempty"`
+	Contrast   float64                   `json:"contrast,omitempty"`
+	Gamma      float64                   `json:"gamma,omitempty"`
+	Saturation float64                   `json:"saturation,omitempty"`
+	Grayscale  bool                      `json:"grayscale,omitempty"`
+	Sepia      bool                      `json:"sepia,omitempty"`
+	Blur       float64                   `json:"blur,omitempty"`
+	Sharpen    *imagemanager.UnsharpMask `json:"sharpen,omitempty"`
+	//водяной знак поверх результата
+	Watermark *imagemanager.Watermark `json:"watermark,omitempty"`
+	//канонический вид конвеера операций, если ресайз сделан конвеером. остальные параметры тогда пустые
+	Pipeline string `json:"pipeline,omitempty"`
+	//имя пресета, если параметры взяли из него. сами параметры тоже сохраняються, пресет потом могут изменить
+	Preset string `json:"preset,omitempty"`
 }
 
-func (r *ResizeRepository) Put(resize ImageResizeInfo, image string) error {
-	r.rp.mx.Lock()
-	defer r.rp.mx.Unlock()
-
-	data, err := json.Marshal(resize)
-	if err != nil {
-		return err
+//запись о ресайзе сделанном с параметрами options
+//формат берется из имени получившегося файла, так он есть и когда формат не меняли
+func NewImageResizeInfo(fileName string, filePath string, options imagemanager.ResizeOptions) ImageResizeInfo {
+	return ImageResizeInfo{
+		ResizedFileName: fileName,
+		ResizedFilePath: filePath,
+		ResizeParam:     int64(options.Width),
+		Height:          int64(options.Height),
+		Mode:            options.Mode,
+		Gravity:         options.Gravity,
+		Background:      options.Background,
+		Format:          imagemanager.FormatByExtension(filepath.Ext(fileName)),
+		Filter:          options.Filter,
+		Quality:         int64(options.Quality),
+		Compression:     options.Compression,
+		Colors:          int64(options.Colors),
+		NoDither:        options.NoDither,
+		FirstFrame:      options.FirstFrame,
+		Crop:            options.Crop,
+		Rotate:          options.Rotate,
+		Flip:            options.Flip,
+		Brightness:      options.Brightness,
+		Contrast:        options.Contrast,
+		Gamma:           options.Gamma,
+		Saturation:      options.Saturation,
+		Grayscale:       options.Grayscale,
+		Sepia:           options.Sepia,
+		Blur:            options.Blur,
+		Sharpen:         options.Sharpen,
+		Watermark:       options.Watermark,
 	}
-	err = r.rp.db.Put([]byte(resizeKey + ":" + image), data, nil)
-	if err != nil {
-		return err
-	}
-	return nil
 }
 
-func (r *ResizeRepository) Append(resize []ImageResizeInfo, image string) error {
-	r.rp.mx.Lock()
-	defer r.rp.mx.Unlock()
-	var imageResizeInfos []ImageResizeInfo
-	has, err := r.rp.db.Has([]byte(resizeKey+":"+image), nil)
-	if err != nil {
-		return err
-	}
-	if has {
-		oldResize, err := r.rp.db.Get([]byte(resizeKey+":"+image), nil)
-		err = json.Unmarshal(oldResize, &imageResizeInfos)
-		if err != nil {
-			return err
-		}
-	}
-
-	resize = append(resize, imageResizeInfos...)
-
-	allResizeJson, err := json.Marshal(resize)
-	err = r.rp.db.Put([]byte(resizeKey+":"+image), allResizeJson, nil)
-	if err != nil {
-		return err
+//запись о результате конвеера, по Pipeline потом находяться такие же конвееры
+func NewPipelineResizeInfo(fileName string, filePath string, pipeline imagemanager.Pipeline) ImageResizeInfo {
+	return ImageResizeInfo{
+		ResizedFileName: fileName,
+		ResizedFilePath: filePath,
+		Format:          imagemanager.FormatByExtension(filepath.Ext(fileName)),
+		Pipeline:        pipeline.Canonical(),
 	}
-	return nil
 }
-
-type ImageResizeInfo struct {
-	ResizedFileName string `json:"resizedFileName"`
-	ResizedFilePath string `json:"resizedFilePath"`
-	ResizeParam     int64  `json:"resizeParam"`
-}
\ No newline at end of file
@@ -0,0 +1,68 @@
+package repositories
+
+import "database/sql"
+
+//схема для встроенной sql базы
+//структуры хранятся в json в колонке data, отдельными колонками вынесено только то по чему идет поиск
+//так добавление полей в структуры не требует миграций
+var sqlSchema = []string{
+	`CREATE TABLE IF NOT EXISTS images (
+		uuid TEXT PRIMARY KEY,
+		data TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS user_images (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		token TEXT NOT NULL,
+		data TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS user_images_token ON user_images (token)`,
+	`CREATE TABLE IF NOT EXISTS resizes (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		image TEXT NOT NULL,
+		data TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS resizes_image ON resizes (image)`,
+	`CREATE TABLE IF NOT EXISTS tasks (
+		id TEXT PRIMARY KEY,
+		status TEXT NOT NULL,
+		data TEXT NOT NULL,
+		lease_owner TEXT NOT NULL DEFAULT '',
+		lease_expires INTEGER NOT NULL DEFAULT 0
+	)`,
+	`CREATE INDEX IF NOT EXISTS tasks_status ON tasks (status)`,
+	`CREATE TABLE IF NOT EXISTS deliveries (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task TEXT NOT NULL,
+		data TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS deliveries_task ON deliveries (task)`,
+	`CREATE TABLE IF NOT EXISTS presets (
+		name TEXT PRIMARY KEY,
+		data TEXT NOT NULL
+	)`,
+}
+
+//создает таблицы если их еще нет. безопасно вызывать при каждом запуске
+func MigrateSQL(db *sql.DB) error {
+	for _, query := range sqlSchema {
+		_, err := db.Exec(query)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//откатывает транзакцию если fn вернула ошибку
+func withTx(db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	err = fn(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
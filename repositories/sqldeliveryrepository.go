@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+type SQLDeliveryRepository struct {
+	db *sql.DB
+}
+
+func NewSQLDeliveryRepository(db *sql.DB) *SQLDeliveryRepository {
+	return &SQLDeliveryRepository{
+		db: db,
+	}
+}
+
+func (r *SQLDeliveryRepository) Get(taskId string) ([]WebhookDelivery, error) {
+	rows, err := r.db.Query(`SELECT data FROM deliveries WHERE task = ? ORDER BY id DESC`, taskId)
+	if err != nil {
+		return []WebhookDelivery{}, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := []WebhookDelivery{}
+	for rows.Next() {
+		var data []byte
+		err = rows.Scan(&data)
+		if err != nil {
+			return []WebhookDelivery{}, err
+		}
+		var delivery WebhookDelivery
+		err = json.Unmarshal(data, &delivery)
+		if err != nil {
+			return []WebhookDelivery{}, err
+		}
+		result = append(result, delivery)
+	}
+	err = rows.Err()
+	if err != nil {
+		return []WebhookDelivery{}, err
+	}
+	return result, nil
+}
+
+func (r *SQLDeliveryRepository) Append(delivery WebhookDelivery, taskId string) error {
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(`INSERT INTO deliveries (task, data) VALUES (?, ?)`, taskId, string(data))
+	return err
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+type SQLImageRepository struct {
+	db *sql.DB
+}
+
+func NewSQLImageRepository(db *sql.DB) *SQLImageRepository {
+	return &SQLImageRepository{
+		db: db,
+	}
+}
+
+func (r *SQLImageRepository) Get(image string) (Image, error) {
+	var data []byte
+	err := r.db.QueryRow(`SELECT data FROM images WHERE uuid = ?`, image).Scan(&data)
+	if err == sql.ErrNoRows {
+		return Image{}, nil
+	}
+	if err != nil {
+		return Image{}, err
+	}
+	var result Image
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return Image{}, err
+	}
+	return result, nil
+}
+
+func (r *SQLImageRepository) Put(image Image) error {
+	data, err := json.Marshal(image)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(
+		`INSERT INTO images (uuid, data) VALUES (?, ?) ON CONFLICT (uuid) DO UPDATE SET data = excluded.data`,
+		image.Uuid,
+		string(data),
+	)
+	return err
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+type SQLPresetRepository struct {
+	db *sql.DB
+}
+
+func NewSQLPresetRepository(db *sql.DB) *SQLPresetRepository {
+	return &SQLPresetRepository{
+		db: db,
+	}
+}
+
+func (r *SQLPresetRepository) Get(name string) (*Preset, error) {
+	var data []byte
+	err := r.db.QueryRow(`SELECT data FROM presets WHERE name = ?`, name).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var result Preset
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *SQLPresetRepository) List() ([]Preset, error) {
+	rows, err := r.db.Query(`SELECT data FROM presets ORDER BY name`)
+	if err != nil {
+		return []Preset{}, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := []Preset{}
+	for rows.Next() {
+		var data []byte
+		err = rows.Scan(&data)
+		if err != nil {
+			return []Preset{}, err
+		}
+		var preset Preset
+		err = json.Unmarshal(data, &preset)
+		if err != nil {
+			return []Preset{}, err
+		}
+		result = append(result, preset)
+	}
+	err = rows.Err()
+	if err != nil {
+		return []Preset{}, err
+	}
+	return result, nil
+}
+
+func (r *SQLPresetRepository) Put(preset Preset) error {
+	data, err := json.Marshal(preset)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(
+		`INSERT INTO presets (name, data) VALUES (?, ?) ON CONFLICT (name) DO UPDATE SET data = excluded.data`,
+		preset.Name,
+		string(data),
+	)
+	return err
+}
+
+func (r *SQLPresetRepository) Delete(name string) (bool, error) {
+	result, err := r.db.Exec(`DELETE FROM presets WHERE name = ?`, name)
+	if err != nil {
+		return false, err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return deleted != 0, nil
+}
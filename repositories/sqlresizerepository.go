@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+type SQLResizeRepository struct {
+	db *sql.DB
+}
+
+func NewSQLResizeRepository(db *sql.DB) *SQLResizeRepository {
+	return &SQLResizeRepository{
+		db: db,
+	}
+}
+
+//новые записи имеют больший id, поэтому сортировка по убыванию дает тот же порядок что и в остальных реализациях
+func (r *SQLResizeRepository) Get(image string) ([]ImageResizeInfo, error) {
+	rows, err := r.db.Query(`SELECT data FROM resizes WHERE image = ? ORDER BY id DESC`, image)
+	if err != nil {
+		return []ImageResizeInfo{}, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := []ImageResizeInfo{}
+	for rows.Next() {
+		var data []byte
+		err = rows.Scan(&data)
+		if err != nil {
+			return []ImageResizeInfo{}, err
+		}
+		var resize ImageResizeInfo
+		err = json.Unmarshal(data, &resize)
+		if err != nil {
+			return []ImageResizeInfo{}, err
+		}
+		result = append(result, resize)
+	}
+	err = rows.Err()
+	if err != nil {
+		return []ImageResizeInfo{}, err
+	}
+	return result, nil
+}
+
+func (r *SQLResizeRepository) Put(resize []ImageResizeInfo, image string) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		_, err := tx.Exec(`DELETE FROM resizes WHERE image = ?`, image)
+		if err != nil {
+			return err
+		}
+		return r.insert(tx, resize, image)
+	})
+}
+
+func (r *SQLResizeRepository) Append(resize []ImageResizeInfo, image string) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		return r.insert(tx, resize, image)
+	})
+}
+
+//вставляем с конца что бы первый элемент получил самый большой id
+func (r *SQLResizeRepository) insert(tx *sql.Tx, resize []ImageResizeInfo, image string) error {
+	for i := len(resize) - 1; i >= 0; i-- {
+		data, err := json.Marshal(resize[i])
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO resizes (image, data) VALUES (?, ?)`, image, string(data))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+//аренда хранится в отдельных колонках что бы ее можно было захватить одним атомарным UPDATE
+//даже если с базой работают несколько экземпляров сервиса
+type SQLTaskRepository struct {
+	db *sql.DB
+}
+
+func NewSQLTaskRepository(db *sql.DB) *SQLTaskRepository {
+	return &SQLTaskRepository{
+		db: db,
+	}
+}
+
+func (r *SQLTaskRepository) Get(taskId string) (*Task, error) {
+	row := r.db.QueryRow(`SELECT data, lease_owner, lease_expires FROM tasks WHERE id = ?`, taskId)
+	task, err := scanTask(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (r *SQLTaskRepository) Put(task Task, taskId string) error {
+	task.UUID = taskId
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(
+		`INSERT INTO tasks (id, status, data, lease_owner, lease_expires) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET status = excluded.status, data = excluded.data,
+			lease_owner = excluded.lease_owner, lease_expires = excluded.lease_expires`,
+		taskId,
+		task.Status,
+		string(data),
+		task.LeaseOwner,
+		toUnixNano(task.LeaseExpires),
+	)
+	return err
+}
+
+func (r *SQLTaskRepository) PutIfStatus(task Task, taskId string, status string) (bool, error) {
+	task.UUID = taskId
+	data, err := json.Marshal(task)
+	if err != nil {
+		return false, err
+	}
+	result, err := r.db.Exec(
+		`UPDATE tasks SET status = ?, data = ?, lease_owner = ?, lease_expires = ? WHERE id = ? AND status = ?`,
+		task.Status,
+		string(data),
+		task.LeaseOwner,
+		toUnixNano(task.LeaseExpires),
+		taskId,
+		status,
+	)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+func (r *SQLTaskRepository) GetByStatus(status string) ([]Task, error) {
+	rows, err := r.db.Query(`SELECT data, lease_owner, lease_expires FROM tasks WHERE status = ? ORDER BY id`, status)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *task)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *SQLTaskRepository) Delete(taskId string) error {
+	_, err := r.db.Exec(`DELETE FROM tasks WHERE id = ?`, taskId)
+	return err
+}
+
+func (r *SQLTaskRepository) AcquireLease(taskId string, owner string, until time.Time) (bool, error) {
+	result, err := r.db.Exec(
+		`UPDATE tasks SET lease_owner = ?, lease_expires = ?
+		WHERE id = ? AND status = ? AND (lease_owner = '' OR lease_owner = ? OR lease_expires < ?)`,
+		owner,
+		until.UnixNano(),
+		taskId,
+		StatusInProgress,
+		owner,
+		time.Now().UnixNano(),
+	)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+//колонки аренды важнее того что лежит в data, data при захвате аренды не обновляеться
+func scanTask(row rowScanner) (*Task, error) {
+	var data []byte
+	var leaseOwner string
+	var leaseExpires int64
+	err := row.Scan(&data, &leaseOwner, &leaseExpires)
+	if err != nil {
+		return nil, err
+	}
+	var task Task
+	err = json.Unmarshal(data, &task)
+	if err != nil {
+		return nil, err
+	}
+	task.LeaseOwner = leaseOwner
+	task.LeaseExpires = fromUnixNano(leaseExpires)
+	return &task, nil
+}
+
+//пустое время храниться как 0, у time.Time{} UnixNano не определен
+func toUnixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func fromUnixNano(nano int64) time.Time {
+	if nano == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nano)
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+type SQLUserImageRepository struct {
+	db *sql.DB
+}
+
+func NewSQLUserImageRepository(db *sql.DB) *SQLUserImageRepository {
+	return &SQLUserImageRepository{
+		db: db,
+	}
+}
+
+func (r *SQLUserImageRepository) Get(userToken string) ([]UserImage, error) {
+	rows, err := r.db.Query(`SELECT data FROM user_images WHERE token = ? ORDER BY id DESC`, userToken)
+	if err != nil {
+		return []UserImage{}, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	userImages := []UserImage{}
+	for rows.Next() {
+		var data []byte
+		err = rows.Scan(&data)
+		if err != nil {
+			return []UserImage{}, err
+		}
+		var userImage UserImage
+		err = json.Unmarshal(data, &userImage)
+		if err != nil {
+			return []UserImage{}, err
+		}
+		userImages = append(userImages, userImage)
+	}
+	err = rows.Err()
+	if err != nil {
+		return []UserImage{}, err
+	}
+	return userImages, nil
+}
+
+func (r *SQLUserImageRepository) Put(userImages []UserImage, userToken string) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		_, err := tx.Exec(`DELETE FROM user_images WHERE token = ?`, userToken)
+		if err != nil {
+			return err
+		}
+		return r.insert(tx, userImages, userToken)
+	})
+}
+
+func (r *SQLUserImageRepository) Append(userImages []UserImage, userToken string) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		return r.insert(tx, userImages, userToken)
+	})
+}
+
+func (r *SQLUserImageRepository) insert(tx *sql.Tx, userImages []UserImage, userToken string) error {
+	for i := len(userImages) - 1; i >= 0; i-- {
+		data, err := json.Marshal(userImages[i])
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO user_images (token, data) VALUES (?, ?)`, userToken, string(data))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
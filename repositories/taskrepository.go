@@ -2,79 +2,67 @@ package repositories
 
 import (
 	"encoding/json"
-	"github.com/syndtr/goleveldb/leveldb"
-	"sync"
+	"time"
 )
 
-const taskKey = "task"
-
-var taskRepositoryInstance *taskRepositoryPrivate
-
-type TaskRepository struct {
-	rp *taskRepositoryPrivate
-}
-
-func NewTaskRepository(db *leveldb.DB) *TaskRepository {
-	if taskRepositoryInstance == nil {
-		taskRepositoryInstance = &taskRepositoryPrivate{
-			db: db,
-		}
-	}
-
-	return &TaskRepository{
-		rp: taskRepositoryInstance,
-	}
-}
-
-type taskRepositoryPrivate struct {
-	mx sync.Mutex
-	db *leveldb.DB
-}
-
-func (r *TaskRepository) Get(taskId string) (*Task, error) {
-	r.rp.mx.Lock()
-	defer r.rp.mx.Unlock()
-	has, err := r.rp.db.Has([]byte(taskKey+":"+taskId), nil)
-	if err != nil {
-		return nil, err
-	}
-	if !has {
-		return nil, nil
-	}
-	data, err := r.rp.db.Get([]byte(taskKey+":"+taskId), nil)
-	var result Task
-	err = json.Unmarshal(data, &result)
-	if err != nil {
-		return nil, err
-	}
-	return &result, nil
-}
-
-func (r *TaskRepository) Put(task Task, taskId string) error {
-	r.rp.mx.Lock()
-	defer r.rp.mx.Unlock()
-
-	data, err := json.Marshal(task)
-	if err != nil {
-		return err
-	}
-	err = r.rp.db.Put([]byte(taskKey+":"+taskId), data, nil)
-	if err != nil {
-		return err
-	}
-
-	return nil
+type TaskRepository interface {
+	//если задачи нет возвращаеться nil без ошибки
+	Get(taskId string) (*Task, error)
+	Put(task Task, taskId string) error
+	//сохраняет задачу только если в базе она все еще в статусе status, иначе возвращает false
+	//так отмена и завершение задачи не затирают друг друга
+	PutIfStatus(task Task, taskId string, status string) (bool, error)
+	GetByStatus(status string) ([]Task, error)
+	//атомарно захватывает задачу в статусе in_progress для owner до времени until
+	//получиться если задачу никто не держит, аренда истекла или она уже принадлежит owner (так продлеваеться аренда)
+	//owner должен быть свой у каждого воркера, иначе два воркера смогут держать одну задачу
+	AcquireLease(taskId string, owner string, until time.Time) (bool, error)
+	//удаляет задачу, если ее нет ошибки тоже нет
+	Delete(taskId string) error
 }
 
 const StatusDone = "done"
 const StatusInProgress = "in_progress"
 const StatusError = "error"
 
+//задача исчерпала все попытки. такие задачи можно вернуть в очередь вручную
+const StatusDead = "dead"
+
+//задачу отменил пользователь. повторно она не запускаеться
+const StatusCancelled = "cancelled"
+
 type Task struct {
+	UUID            string `json:"uuid"`
+	Token           string `json:"token"`
 	Status          string
 	FileName        string `json:"fileName"`
 	FilePath        string `json:"filePath"`
 	ResizedFileName string `json:"resizedFileName"`
 	ResizedFilePath string `json:"resizedFilePath"`
 	Error           string `json:"error"`
+	//результаты по каждому размеру. ResizedFileName и ResizedFilePath повторяют первый из них
+	Resized []ImageResizeInfo `json:"resized,omitempty"`
+	//все параметры задачи, по ним задача восстанавливаеться после перезапуска
+	Payload json.RawMessage `json:"payload,omitempty"`
+	//кто и до какого времени обрабатывает задачу. пока аренда не истекла другой воркер задачу не возьмет
+	LeaseOwner   string    `json:"leaseOwner,omitempty"`
+	LeaseExpires time.Time `json:"leaseExpires"`
+	//сколько раз задача запускалась и ошибки последних попыток
+	Attempts int         `json:"attempts"`
+	Errors   []TaskError `json:"errors,omitempty"`
+	//раньше этого времени задача не будет запущена повторно
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+}
+
+type TaskError struct {
+	Attempt int       `json:"attempt"`
+	Error   string    `json:"error"`
+	Time    time.Time `json:"time"`
+}
+
+func (t *Task) canBeLeased(owner string, now time.Time) bool {
+	if t.Status != StatusInProgress {
+		return false
+	}
+	return t.LeaseOwner == "" || t.LeaseOwner == owner || t.LeaseExpires.Before(now)
 }
@@ -0,0 +1,247 @@
+package repositories
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTaskPutGet(t *testing.T) {
+	for driver, repos := range testRepositories(t) {
+		t.Run(driver, func(t *testing.T) {
+			taskId := "task"
+			task, err := repos.TaskRepository.Get(taskId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if task != nil {
+				t.Fatalf("missing task %+v", task)
+			}
+
+			err = repos.TaskRepository.Put(Task{
+				Token:    "token",
+				Status:   StatusInProgress,
+				Payload:  json.RawMessage(`{"image":"a.png"}`),
+				Attempts: 2,
+			}, taskId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			task, err = repos.TaskRepository.Get(taskId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if task == nil || task.UUID != taskId || task.Token != "token" || task.Status != StatusInProgress ||
+				string(task.Payload) != `{"image":"a.png"}` || task.Attempts != 2 {
+				t.Fatalf("task %+v", task)
+			}
+		})
+	}
+}
+
+func TestTaskGetByStatus(t *testing.T) {
+	for driver, repos := range testRepositories(t) {
+		t.Run(driver, func(t *testing.T) {
+			statuses := map[string]string{
+				"a": StatusInProgress,
+				"b": StatusDone,
+				"c": StatusInProgress,
+				"d": StatusDead,
+			}
+			for name, status := range statuses {
+				err := repos.TaskRepository.Put(Task{Status: status}, name)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			tasks, err := repos.TaskRepository.GetByStatus(StatusInProgress)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, task := range tasks {
+				ids = append(ids, task.UUID)
+			}
+			if len(ids) != 2 || ids[0] != "a" || ids[1] != "c" {
+				t.Fatalf("in progress tasks %v", ids)
+			}
+		})
+	}
+}
+
+func TestAcquireLease(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		task *Task
+		//владелец и до какого времени держит задачу до проверки
+		owner   string
+		expires time.Time
+		//кто пробует захватить
+		newOwner string
+		acquired bool
+	}{
+		{"free task", &Task{Status: StatusInProgress}, "", time.Time{}, "process/1", true},
+		{"held by another process", &Task{Status: StatusInProgress}, "process/1", now.Add(time.Minute), "other/1", false},
+		//у каждого воркера свой владелец, второй воркер того же процесса не должен взять чужую задачу
+		{"held by another worker of the same process", &Task{Status: StatusInProgress}, "process/1", now.Add(time.Minute), "process/2", false},
+		{"extended by the same worker", &Task{Status: StatusInProgress}, "process/1", now.Add(time.Minute), "process/1", true},
+		{"expired lease", &Task{Status: StatusInProgress}, "process/1", now.Add(-time.Second), "process/2", true},
+		{"done task", &Task{Status: StatusDone}, "", time.Time{}, "process/1", false},
+		{"cancelled task", &Task{Status: StatusCancelled}, "", time.Time{}, "process/1", false},
+		{"missing task", nil, "", time.Time{}, "process/1", false},
+	}
+	for driver, repos := range testRepositories(t) {
+		for _, test := range tests {
+			t.Run(driver+"/"+test.name, func(t *testing.T) {
+				taskId := "task"
+				if test.task != nil {
+					task := *test.task
+					task.LeaseOwner = test.owner
+					task.LeaseExpires = test.expires
+					err := repos.TaskRepository.Put(task, taskId)
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				until := time.Now().Add(2 * time.Minute)
+				acquired, err := repos.TaskRepository.AcquireLease(taskId, test.newOwner, until)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if acquired != test.acquired {
+					t.Fatalf("acquired %v, want %v", acquired, test.acquired)
+				}
+				if test.task == nil {
+					return
+				}
+
+				task, err := repos.TaskRepository.Get(taskId)
+				if err != nil {
+					t.Fatal(err)
+				}
+				wantOwner, wantExpires := test.owner, test.expires
+				if test.acquired {
+					wantOwner, wantExpires = test.newOwner, until
+				}
+				if task.LeaseOwner != wantOwner || !task.LeaseExpires.Equal(wantExpires) {
+					t.Fatalf("lease %s until %s, want %s until %s", task.LeaseOwner, task.LeaseExpires, wantOwner, wantExpires)
+				}
+			})
+		}
+	}
+}
+
+func TestAcquireLeaseExpiry(t *testing.T) {
+	for driver, repos := range testRepositories(t) {
+		t.Run(driver, func(t *testing.T) {
+			taskId := "task"
+			err := repos.TaskRepository.Put(Task{Status: StatusInProgress}, taskId)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			acquired, err := repos.TaskRepository.AcquireLease(taskId, "process/1", time.Now().Add(50*time.Millisecond))
+			if err != nil || !acquired {
+				t.Fatalf("first lease: %v %v", acquired, err)
+			}
+			acquired, err = repos.TaskRepository.AcquireLease(taskId, "process/2", time.Now().Add(time.Minute))
+			if err != nil || acquired {
+				t.Fatalf("lease before expiry: %v %v", acquired, err)
+			}
+
+			time.Sleep(100 * time.Millisecond)
+			acquired, err = repos.TaskRepository.AcquireLease(taskId, "process/2", time.Now().Add(time.Minute))
+			if err != nil || !acquired {
+				t.Fatalf("lease after expiry: %v %v", acquired, err)
+			}
+			//прежний владелец больше не может продлить аренду
+			acquired, err = repos.TaskRepository.AcquireLease(taskId, "process/1", time.Now().Add(time.Minute))
+			if err != nil || acquired {
+				t.Fatalf("old owner extended the lease: %v %v", acquired, err)
+			}
+		})
+	}
+}
+
+func TestPutIfStatus(t *testing.T) {
+	for driver, repos := range testRepositories(t) {
+		t.Run(driver, func(t *testing.T) {
+			taskId := "task"
+			err := repos.TaskRepository.Put(Task{Token: "token", Status: StatusInProgress}, taskId)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			//отмена и завершение прочитали задачу одновременно, успевает только тот кто записал первым
+			cancelled, err := repos.TaskRepository.Get(taskId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			done := *cancelled
+			cancelled.Status = StatusCancelled
+			done.Status = StatusDone
+
+			saved, err := repos.TaskRepository.PutIfStatus(*cancelled, taskId, StatusInProgress)
+			if err != nil || !saved {
+				t.Fatalf("cancel: %v %v", saved, err)
+			}
+			saved, err = repos.TaskRepository.PutIfStatus(done, taskId, StatusInProgress)
+			if err != nil || saved {
+				t.Fatalf("complete after cancel: %v %v", saved, err)
+			}
+			task, err := repos.TaskRepository.Get(taskId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if task.Status != StatusCancelled {
+				t.Fatalf("status %s, want %s", task.Status, StatusCancelled)
+			}
+
+			saved, err = repos.TaskRepository.PutIfStatus(done, "missing", StatusInProgress)
+			if err != nil || saved {
+				t.Fatalf("missing task: %v %v", saved, err)
+			}
+			task, err = repos.TaskRepository.Get("missing")
+			if err != nil || task != nil {
+				t.Fatalf("missing task is created: %+v %v", task, err)
+			}
+		})
+	}
+}
+
+func TestTaskDelete(t *testing.T) {
+	for driver, repos := range testRepositories(t) {
+		t.Run(driver, func(t *testing.T) {
+			taskId := "task"
+			err := repos.TaskRepository.Put(Task{Token: "token", Status: StatusInProgress}, taskId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = repos.TaskRepository.Delete(taskId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			task, err := repos.TaskRepository.Get(taskId)
+			if err != nil || task != nil {
+				t.Fatalf("deleted task %+v %v", task, err)
+			}
+			tasks, err := repos.TaskRepository.GetByStatus(StatusInProgress)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, task := range tasks {
+				if task.UUID == taskId {
+					t.Fatalf("deleted task is in progress %+v", task)
+				}
+			}
+
+			//удаление отсутствующей задачи не ошибка
+			err = repos.TaskRepository.Delete("missing")
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}